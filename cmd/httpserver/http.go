@@ -1,10 +1,15 @@
 package httpserver
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"robinhood/config"
+	"robinhood/internal/core/constants"
+	"robinhood/internal/core/ports"
 	"robinhood/internal/dto"
+	"robinhood/internal/errmsg"
 	"robinhood/internal/handlers/bloghdl"
 	"robinhood/internal/handlers/userhdl"
 	"robinhood/pkg/auth"
@@ -23,6 +28,7 @@ import (
 func NewHTTPServer(
 	bh *bloghdl.Handler,
 	uh *userhdl.Handler,
+	blobs ports.BlobStore,
 ) *echo.Echo {
 	e := echo.New()
 	e.Use(middleware.Logger())
@@ -32,12 +38,24 @@ func NewHTTPServer(
 	}))
 	e.HTTPErrorHandler = customHTTPErrorHandler
 
+	// client ip, used by the login lockout
+	ipExtractor, err := IPExtractor(config.Get().Endpoint.TrustedProxies)
+	if err != nil {
+		log.Fatalf("failed to read trusted proxies: %s\n", err.Error())
+	}
+	e.IPExtractor = ipExtractor
+
 	// auth middleware
 	authMiddleware := echojwt.WithConfig(echojwt.Config{
-		SigningKey: []byte(config.Get().JWT.Secret),
-		NewClaimsFunc: func(c echo.Context) jwt.Claims {
-			return new(auth.JWTCustomClaims)
-		},
+		ParseTokenFunc: uh.ParseToken,
+		ErrorHandler:   authErrorHandler,
+	})
+
+	// the blog and comment apis are open to scripts with an api key too,
+	// the user apis are left to the tokens of a login
+	authOrAPIKeyMiddleware := echojwt.WithConfig(echojwt.Config{
+		ParseTokenFunc: uh.ParseTokenOrAPIKey,
+		ErrorHandler:   authErrorHandler,
 	})
 
 	// swagger
@@ -50,28 +68,135 @@ func NewHTTPServer(
 		return c.String(http.StatusOK, "server is running...")
 	})
 
+	e.GET("/.well-known/jwks.json", uh.JWKS)
+
 	// add prefix for all routes below
 	v1 := e.Group("/api/v1")
+
+	// the local blob store serves its files itself, the others have their own urls
+	if h, ok := blobs.(http.Handler); ok {
+		v1.GET("/files/*", echo.WrapHandler(http.StripPrefix("/api/v1/files", h)))
+	}
+
+	// room for the multipart envelope around the largest profile image
+	profileImageLimit := middleware.BodyLimit(fmt.Sprintf("%dK", config.Get().User.ProfileImageMaxBytes/1024+64))
 	user := v1.Group("/user")
 	user.POST("/register", uh.Register)
 	user.POST("/login", uh.Login)
+	user.POST("/login/mfa", uh.LoginMFA)
+	user.GET("/oidc/login", uh.OIDCLogin)
+	user.GET("/oidc/callback", uh.OIDCCallback)
+	user.POST("/token/refresh", uh.RefreshToken)
+	user.POST("/password/forgot", uh.ForgotPassword)
+	user.POST("/password/reset", uh.ResetPassword)
+	user.GET("/verify", uh.VerifyEmail)
+	user.POST("/verify/resend", uh.ResendVerification, authMiddleware)
+	user.POST("/mfa/totp", uh.EnrollTOTP, authMiddleware)
+	user.POST("/mfa/totp/confirm", uh.ConfirmTOTP, authMiddleware)
+	user.POST("/logout", uh.Logout, authMiddleware)
+	user.POST("/logout-all", uh.LogoutAll, authMiddleware)
+	user.GET("", uh.SearchUsers, authMiddleware)
+	user.GET("/me", uh.GetMe, authMiddleware)
+	user.GET("/:userId", uh.GetUser, authMiddleware)
 	user.PUT("", uh.UpdateUser, authMiddleware)
+	user.PUT("/profile-image", uh.UploadProfileImage, authMiddleware, profileImageLimit)
+	user.GET("/:userId/profile-image", uh.GetProfileImage)
+	user.PUT("/password", uh.ChangePassword, authMiddleware)
+	user.PUT("/email", uh.ChangeEmail, authMiddleware)
+	user.PUT("/username", uh.ChangeUsername, authMiddleware)
+	user.POST("/me/deactivate", uh.DeactivateUser, authMiddleware)
+	user.DELETE("/me", uh.DeleteUser, authMiddleware)
+	user.GET("/me/export", uh.ExportUser, authMiddleware)
+	user.POST("/api-keys", uh.CreateAPIKey, authMiddleware)
+	user.GET("/api-keys", uh.ListAPIKeys, authMiddleware)
+	user.DELETE("/api-keys/:apiKeyId", uh.RevokeAPIKey, authMiddleware)
+	user.PUT("/:userId/role", uh.UpdateUserRole, authMiddleware, requirePermission(constants.PERMISSION_USER_MANAGE))
+	user.POST("/:userId/unlock", uh.UnlockUser, authMiddleware, requirePermission(constants.PERMISSION_USER_MANAGE))
 
-	blog := v1.Group("/blog", authMiddleware)
-	blog.GET("", bh.ListBlog)
-	blog.GET("/:blogId", bh.GetBlogByID)
-	blog.POST("", bh.CreateBlog)
-	blog.PUT("/:blogId", bh.UpdateBlogStatus)
-	blog.DELETE("/:blogId", bh.ArchiveBlog)
+	blog := v1.Group("/blog", authOrAPIKeyMiddleware)
+	blog.GET("", bh.ListBlog, requirePermission(constants.PERMISSION_BLOG_READ))
+	blog.GET("/workflow", bh.GetWorkflow, requirePermission(constants.PERMISSION_BLOG_READ))
+	blog.GET("/:blogId", bh.GetBlogByID, requirePermission(constants.PERMISSION_BLOG_READ))
+	blog.GET("/:blogId/history", bh.ListBlogHistory, requirePermission(constants.PERMISSION_BLOG_READ))
+	blog.POST("", bh.CreateBlog, requirePermission(constants.PERMISSION_BLOG_WRITE), requireVerifiedEmail)
+	blog.PUT("/:blogId", bh.UpdateBlogStatus, requirePermission(constants.PERMISSION_BLOG_WRITE))
+	blog.PATCH("/:blogId", bh.EditBlog, requirePermission(constants.PERMISSION_BLOG_WRITE))
+	blog.PUT("/:blogId/due", bh.SetBlogDue, requirePermission(constants.PERMISSION_BLOG_WRITE))
+	blog.POST("/:blogId/labels", bh.AttachLabel, requirePermission(constants.PERMISSION_BLOG_WRITE))
+	blog.DELETE("/:blogId/labels/:labelId", bh.DetachLabel, requirePermission(constants.PERMISSION_BLOG_WRITE))
+	blog.POST("/:blogId/assignees", bh.AssignBlog, requirePermission(constants.PERMISSION_BLOG_WRITE))
+	blog.DELETE("/:blogId/assignees/:userId", bh.UnassignBlog, requirePermission(constants.PERMISSION_BLOG_WRITE))
+	blog.DELETE("/:blogId", bh.ArchiveBlog, requirePermission(constants.PERMISSION_BLOG_WRITE))
+	blog.POST("/:blogId/restore", bh.RestoreBlog, requirePermission(constants.PERMISSION_BLOG_WRITE))
 
-	comment := v1.Group("/comment", authMiddleware)
-	comment.GET("/:blogId", bh.ListComment)
-	comment.POST("/:blogId", bh.CreateComment)
+	label := v1.Group("/label", authOrAPIKeyMiddleware)
+	label.GET("", bh.ListLabels, requirePermission(constants.PERMISSION_BLOG_READ))
+	label.POST("", bh.CreateLabel, requirePermission(constants.PERMISSION_LABEL_MANAGE))
+	label.PATCH("/:labelId", bh.UpdateLabel, requirePermission(constants.PERMISSION_LABEL_MANAGE))
+	label.DELETE("/:labelId", bh.DeleteLabel, requirePermission(constants.PERMISSION_LABEL_MANAGE))
+
+	comment := v1.Group("/comment", authOrAPIKeyMiddleware)
+	comment.GET("/:blogId", bh.ListComment, requirePermission(constants.PERMISSION_COMMENT_READ))
+	comment.POST("/:blogId", bh.CreateComment, requirePermission(constants.PERMISSION_COMMENT_WRITE), requireVerifiedEmail)
 
 	return e
 }
 
+// requirePermission only lets the request through when the role of the
+// authenticated user grants the permission, and the scopes of an api key too.
+func requirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := c.Get("user").(*jwt.Token)
+			if !ok {
+				return errmsg.TokenMissing
+			}
+			claims, ok := user.Claims.(*auth.JWTCustomClaims)
+			if !ok || !constants.HasPermission(claims.Role, permission) || !claims.HasScope(permission) {
+				return errmsg.Forbidden
+			}
+			return next(c)
+		}
+	}
+}
+
+// requireVerifiedEmail blocks users who haven't verified their email yet,
+// it is switched on by REQUIRE_VERIFIED_EMAIL.
+func requireVerifiedEmail(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !config.Get().User.RequireVerifiedEmail {
+			return next(c)
+		}
+		user, ok := c.Get("user").(*jwt.Token)
+		if !ok {
+			return errmsg.TokenMissing
+		}
+		claims, ok := user.Claims.(*auth.JWTCustomClaims)
+		if !ok || !claims.Verified {
+			return errmsg.EmailNotVerified
+		}
+		return next(c)
+	}
+}
+
+func authErrorHandler(c echo.Context, err error) error {
+	var tokenErr *echojwt.TokenParsingError
+	if !errors.As(err, &tokenErr) {
+		return errmsg.TokenMissing
+	}
+	if m, ok := meta.IsError(tokenErr.Err); ok {
+		return m
+	}
+	return errmsg.TokenInvalid
+}
+
 func customHTTPErrorHandler(err error, c echo.Context) {
+	// a streamed response is cut off, an error body would corrupt it
+	if c.Response().Committed {
+		c.Logger().Error(err)
+		return
+	}
+
 	var m *meta.MetaError
 
 	if metaErr, ok := meta.IsError(err); ok {
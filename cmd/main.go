@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"log"
 	"os"
 	"os/signal"
 	"robinhood/cmd/httpserver"
@@ -10,10 +11,13 @@ import (
 	infrastructure "robinhood/infrastructures"
 	"robinhood/internal/core/services/blogsvc"
 	"robinhood/internal/core/services/commentsvc"
+	"robinhood/internal/core/services/labelsvc"
 	"robinhood/internal/core/services/usersvc"
 	"robinhood/internal/handlers/bloghdl"
 	"robinhood/internal/handlers/userhdl"
+	"robinhood/internal/jobs"
 	"robinhood/internal/repositories"
+	"robinhood/pkg/auth"
 	"syscall"
 	"time"
 )
@@ -43,22 +47,58 @@ func init() {
 }
 
 func main() {
+	// fail fast when the jwt signing keys are misconfigured
+	if err := auth.LoadKeys(); err != nil {
+		log.Fatalf("load jwt keys error : %s", err.Error())
+	}
+
 	// infrastructures
 	mc := infrastructure.NewMongoDB()
+	mailer := infrastructure.NewMailer()
+	idp := infrastructure.NewIdentityProvider()
+	blobs := infrastructure.NewBlobStore()
+	workflow := infrastructure.NewWorkflow()
 
 	// repositories
 	br := repositories.NewBlogRepository(mc, config.Get().Mongo.Database)
+	bhr := repositories.NewBlogHistoryRepository(mc, config.Get().Mongo.Database)
 	cr := repositories.NewCommentRepository(mc, config.Get().Mongo.Database)
 	ur := repositories.NewUserRepository(mc, config.Get().Mongo.Database)
+	rtr := repositories.NewRefreshTokenRepository(mc, config.Get().Mongo.Database)
+	rvr := repositories.NewRevokedTokenRepository(mc, config.Get().Mongo.Database)
+	utr := repositories.NewUserTokenRepository(mc, config.Get().Mongo.Database)
+	lar := repositories.NewLoginAttemptRepository(mc, config.Get().Mongo.Database)
+	akr := repositories.NewAPIKeyRepository(mc, config.Get().Mongo.Database)
+	osr := repositories.NewOIDCStateRepository(mc, config.Get().Mongo.Database)
+	lr := repositories.NewLabelRepository(mc, config.Get().Mongo.Database)
 	// services
-	bs := blogsvc.New(br, ur)
+	bs := blogsvc.New(br, bhr, cr, ur, lr, mailer, workflow)
 	cs := commentsvc.New(cr, ur)
-	us := usersvc.New(ur)
+	ls := labelsvc.New(lr, br)
+	us := usersvc.New(ur, br, cr, rtr, rvr, utr, lar, akr, osr, mailer, idp, blobs)
 	// handlers
-	bh := bloghdl.New(bs, cs)
+	bh := bloghdl.New(bs, cs, ls)
 	uh := userhdl.New(us)
 
-	e := httpserver.NewHTTPServer(bh, uh)
+	// background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	jobs.Every(jobsCtx, "purgeArchivedBlogs", time.Duration(config.Get().Blog.RetentionIntervalMinutes)*time.Minute, func(ctx context.Context) error {
+		n, err := bs.PurgeArchivedBlogs(ctx)
+		if n > 0 {
+			log.Printf("[jobs::purgeArchivedBlogs] deleted %d archived blogs", n)
+		}
+		return err
+	})
+	jobs.Every(jobsCtx, "sendDueReminders", time.Duration(config.Get().Blog.ReminderIntervalMinutes)*time.Minute, func(ctx context.Context) error {
+		n, err := bs.SendDueReminders(ctx)
+		if n > 0 {
+			log.Printf("[jobs::sendDueReminders] reminded %d blogs", n)
+		}
+		return err
+	})
+
+	e := httpserver.NewHTTPServer(bh, uh, blobs)
 
 	go func() {
 		if err := e.Start(fmt.Sprintf(":%s", config.Get().Endpoint.Port)); err != nil {
@@ -12,14 +12,25 @@ type config struct {
 	Endpoint endpoint
 	Mongo    mongo
 	JWT      jwt
+	User     user
+	Mail     mail
+	OIDC     oidc
+	Blob     blob
+	Blog     blog
 }
 
 type app struct {
 	EnableSwagger bool `envconfig:"ENABLE_SWAGGER" default:"true"`
+	// base url of the api as the clients reach it, used for the links to
+	// profile images and to the files of the local blob store
+	PublicURL string `envconfig:"PUBLIC_URL" default:"http://localhost:8080/api/v1"`
 }
 
 type endpoint struct {
 	Port string `envconfig:"PORT" default:"8080"`
+	// CIDR ranges of the reverse proxies in front of the api, the client ip
+	// is read from X-Forwarded-For only when the request comes from them
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
 }
 
 type mongo struct {
@@ -28,11 +39,94 @@ type mongo struct {
 }
 
 type jwt struct {
-	Secret          string `envconfig:"JWT_SECRET"`
-	AUD             string `envconfig:"JWT_AUD"`
-	ISS             string `envconfig:"JWT_ISS"`
-	ExpiresHours    uint   `envconfig:"JWT_EXPIRES_HOURS" default:"730"`
-	AutoLogoffHours uint   `envconfig:"JWT_AUTO_LOGOFF_HOURS" default:"730"`
+	Secret          string            `envconfig:"JWT_SECRET"`
+	Algorithm       string            `envconfig:"JWT_ALGORITHM" default:"HS256"`
+	SigningKeyId    string            `envconfig:"JWT_SIGNING_KEY_ID"`
+	PrivateKeyFiles map[string]string `envconfig:"JWT_PRIVATE_KEY_FILES"`
+	PublicKeyFiles  map[string]string `envconfig:"JWT_PUBLIC_KEY_FILES"`
+	AUD             string            `envconfig:"JWT_AUD"`
+	ISS             string            `envconfig:"JWT_ISS"`
+	ExpiresHours    uint              `envconfig:"JWT_EXPIRES_HOURS" default:"1"`
+	RefreshHours    uint              `envconfig:"JWT_REFRESH_EXPIRES_HOURS" default:"730"`
+	AutoLogoffHours uint              `envconfig:"JWT_AUTO_LOGOFF_HOURS" default:"730"`
+}
+
+type user struct {
+	PasswordResetExpiresMinutes uint   `envconfig:"PASSWORD_RESET_EXPIRES_MINUTES" default:"30"`
+	PasswordResetURL            string `envconfig:"PASSWORD_RESET_URL"`
+	VerificationExpiresHours    uint   `envconfig:"EMAIL_VERIFICATION_EXPIRES_HOURS" default:"24"`
+	VerificationResendSeconds   uint   `envconfig:"EMAIL_VERIFICATION_RESEND_SECONDS" default:"60"`
+	VerificationURL             string `envconfig:"EMAIL_VERIFICATION_URL"`
+	// blocks creating blogs and comments until the email is verified
+	RequireVerifiedEmail bool `envconfig:"REQUIRE_VERIFIED_EMAIL" default:"false"`
+	// failed logins allowed per username and per client ip before locking,
+	// every failure after that doubles the lockout up to the max
+	LoginMaxAttempts          uint `envconfig:"LOGIN_MAX_ATTEMPTS" default:"5"`
+	LoginMaxAttemptsPerIP     uint `envconfig:"LOGIN_MAX_ATTEMPTS_PER_IP" default:"20"`
+	LoginLockoutSeconds       uint `envconfig:"LOGIN_LOCKOUT_SECONDS" default:"30"`
+	LoginMaxLockoutMinutes    uint `envconfig:"LOGIN_MAX_LOCKOUT_MINUTES" default:"60"`
+	LoginAttemptWindowMinutes uint `envconfig:"LOGIN_ATTEMPT_WINDOW_MINUTES" default:"60"`
+	// issuer shown by authenticator apps
+	MFAIssuer                  string `envconfig:"MFA_ISSUER" default:"Robinhood"`
+	MFAChallengeExpiresMinutes uint   `envconfig:"MFA_CHALLENGE_EXPIRES_MINUTES" default:"5"`
+	MFARecoveryCodes           uint   `envconfig:"MFA_RECOVERY_CODES" default:"10"`
+	// 5 MiB
+	ProfileImageMaxBytes uint `envconfig:"PROFILE_IMAGE_MAX_BYTES" default:"5242880"`
+}
+
+type mail struct {
+	// smtp or outbox
+	Driver       string `envconfig:"MAIL_DRIVER" default:"outbox"`
+	From         string `envconfig:"MAIL_FROM" default:"no-reply@robinhood.local"`
+	SMTPHost     string `envconfig:"SMTP_HOST"`
+	SMTPPort     string `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUsername string `envconfig:"SMTP_USERNAME"`
+	SMTPPassword string `envconfig:"SMTP_PASSWORD"`
+	OutboxDir    string `envconfig:"MAIL_OUTBOX_DIR"`
+}
+
+type oidc struct {
+	// the OIDC login is turned off without an issuer, its discovery document
+	// is read from /.well-known/openid-configuration
+	Issuer       string   `envconfig:"OIDC_ISSUER"`
+	ClientId     string   `envconfig:"OIDC_CLIENT_ID"`
+	ClientSecret string   `envconfig:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string   `envconfig:"OIDC_REDIRECT_URL"`
+	Scopes       []string `envconfig:"OIDC_SCOPES" default:"openid,email,profile"`
+	// how long the user has to complete the login at the identity provider
+	StateExpiresMinutes uint `envconfig:"OIDC_STATE_EXPIRES_MINUTES" default:"10"`
+}
+
+type blog struct {
+	// json file of the workflow of the blog statuses, see workflow.example.json
+	WorkflowFile string `envconfig:"BLOG_WORKFLOW_FILE"`
+	// archived blogs and their comments are deleted for good after this, never when 0
+	ArchiveRetentionDays uint `envconfig:"BLOG_ARCHIVE_RETENTION_DAYS" default:"30"`
+	// how often the archived blogs past the retention are looked for
+	RetentionIntervalMinutes uint `envconfig:"BLOG_RETENTION_INTERVAL_MINUTES" default:"60"`
+	// the assignees are reminded this long before the due date, only when overdue if 0
+	DueReminderMinutes uint `envconfig:"BLOG_DUE_REMINDER_MINUTES" default:"1440"`
+	// how often the blogs due soon or overdue are looked for
+	ReminderIntervalMinutes uint `envconfig:"BLOG_REMINDER_INTERVAL_MINUTES" default:"5"`
+}
+
+type blob struct {
+	// local or s3
+	Driver string `envconfig:"BLOB_DRIVER" default:"local"`
+	// how long a signed url to a file stays valid
+	URLExpiresMinutes uint   `envconfig:"BLOB_URL_EXPIRES_MINUTES" default:"15"`
+	LocalDir          string `envconfig:"BLOB_LOCAL_DIR" default:"data/blobs"`
+	// signs the urls of the local files, a random one is used when empty
+	LocalSigningSecret string `envconfig:"BLOB_LOCAL_SIGNING_SECRET"`
+	// any S3 compatible storage, https://s3.<region>.amazonaws.com when empty
+	S3Endpoint  string `envconfig:"S3_ENDPOINT"`
+	S3Region    string `envconfig:"S3_REGION" default:"us-east-1"`
+	S3Bucket    string `envconfig:"S3_BUCKET"`
+	S3AccessKey string `envconfig:"S3_ACCESS_KEY"`
+	S3SecretKey string `envconfig:"S3_SECRET_KEY"`
+	// path style urls (endpoint/bucket/key) work with most S3 compatible
+	// storages, false uses virtual hosted urls (bucket.endpoint/key)
+	S3PathStyle bool `envconfig:"S3_PATH_STYLE" default:"true"`
 }
 
 var cfg config
@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"robinhood/config"
+	"robinhood/internal/blobstores"
+	"robinhood/internal/core/ports"
+	"robinhood/pkg/utils"
+)
+
+func NewBlobStore() ports.BlobStore {
+	cfg := config.Get().Blob
+	switch cfg.Driver {
+	case "local":
+		secret := cfg.LocalSigningSecret
+		if secret == "" {
+			// signed urls then stop working on a restart or on another instance
+			secret, _ = utils.GenerateRandomString(32)
+			log.Println("BLOB_LOCAL_SIGNING_SECRET is empty, signing the file urls with a random secret")
+		}
+		return blobstores.NewLocalStore(cfg.LocalDir, config.Get().App.PublicURL, secret)
+	case "s3":
+		endpoint := cfg.S3Endpoint
+		if endpoint == "" {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.S3Region)
+		}
+		store, err := blobstores.NewS3Store(endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3PathStyle)
+		if err != nil {
+			log.Fatalf("failed to create S3 blob store: %s\n", err.Error())
+		}
+		return store
+	default:
+		log.Fatalf("unknown blob driver: %s\n", cfg.Driver)
+		return nil
+	}
+}
@@ -0,0 +1,17 @@
+package infrastructure
+
+import (
+	"robinhood/config"
+	"robinhood/internal/core/ports"
+	"robinhood/internal/identityproviders"
+)
+
+// NewIdentityProvider returns nil when no OIDC issuer is configured, the
+// OIDC login is then turned off.
+func NewIdentityProvider() ports.IdentityProvider {
+	cfg := config.Get().OIDC
+	if cfg.Issuer == "" {
+		return nil
+	}
+	return identityproviders.NewOIDCProvider(cfg.Issuer, cfg.ClientId, cfg.ClientSecret, cfg.RedirectURL, cfg.Scopes)
+}
@@ -0,0 +1,21 @@
+package infrastructure
+
+import (
+	"log"
+	"robinhood/config"
+	"robinhood/internal/core/ports"
+	"robinhood/internal/mailers"
+)
+
+func NewMailer() ports.Mailer {
+	cfg := config.Get().Mail
+	switch cfg.Driver {
+	case "smtp":
+		return mailers.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case "outbox":
+		return mailers.NewOutboxMailer(cfg.OutboxDir)
+	default:
+		log.Fatalf("unknown mail driver: %s\n", cfg.Driver)
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"robinhood/config"
+	"robinhood/internal/core/domains"
+)
+
+// NewWorkflow reads the blog workflow from BLOG_WORKFLOW_FILE, the default
+// workflow is used without it.
+func NewWorkflow() *domains.Workflow {
+	file := config.Get().Blog.WorkflowFile
+	if file == "" {
+		return domains.DefaultWorkflow()
+	}
+
+	b, err := os.ReadFile(file)
+	if err != nil {
+		log.Fatalf("failed to read workflow: %s\n", err.Error())
+	}
+	var workflow domains.Workflow
+	if err := json.Unmarshal(b, &workflow); err != nil {
+		log.Fatalf("failed to parse workflow: %s\n", err.Error())
+	}
+	if err := workflow.Validate(); err != nil {
+		log.Fatalf("invalid workflow: %s\n", err.Error())
+	}
+	return &workflow
+}
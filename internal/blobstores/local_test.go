@@ -0,0 +1,157 @@
+package blobstores
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"robinhood/internal/core/domains"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newLocalStore(t *testing.T) (*LocalStore, string) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "blobs")
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "secret.txt"), []byte("secret"), 0o644))
+
+	s := NewLocalStore(dir, "http://localhost:8080/api/v1", "signing-secret")
+	assert.NoError(t, s.Put(context.Background(), &domains.Blob{Key: "users/a.png", ContentType: "image/png", Data: []byte("png")}))
+	return s, root
+}
+
+// serve requests a signed url of the store the way it is mounted, under
+// /files/ with the prefix stripped.
+func serve(s *LocalStore, signedURL string) *httptest.ResponseRecorder {
+	u, _ := url.Parse(signedURL)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.URL.Path = strings.TrimPrefix(u.Path, "/api/v1/files")
+	req.URL.RawQuery = u.RawQuery
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLocalSignedURL(t *testing.T) {
+	s, _ := newLocalStore(t)
+
+	signedURL, err := s.SignedURL(context.Background(), "users/a.png", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signedURL, "http://localhost:8080/api/v1/files/users/a.png?"))
+
+	rec := serve(s, signedURL)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "png", rec.Body.String())
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+}
+
+func TestLocalServeHTTP(t *testing.T) {
+	s, _ := newLocalStore(t)
+	exp := time.Now().Add(time.Minute).Unix()
+	expired := time.Now().Add(-time.Second).Unix()
+
+	type test struct {
+		name         string
+		key          string
+		expires      string
+		signature    string
+		expectedCode int
+	}
+
+	tests := []test{
+		{
+			name:         "should serve a file of a valid signature",
+			key:          "users/a.png",
+			expires:      strconv.FormatInt(exp, 10),
+			signature:    s.sign("users/a.png", exp),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "should refuse a bad signature",
+			key:          "users/a.png",
+			expires:      strconv.FormatInt(exp, 10),
+			signature:    s.sign("users/b.png", exp),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "should refuse a signature of another expiry",
+			key:          "users/a.png",
+			expires:      strconv.FormatInt(exp+60, 10),
+			signature:    s.sign("users/a.png", exp),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "should refuse an expired url",
+			key:          "users/a.png",
+			expires:      strconv.FormatInt(expired, 10),
+			signature:    s.sign("users/a.png", expired),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "should refuse a url without an expiry",
+			key:          "users/a.png",
+			signature:    s.sign("users/a.png", 0),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "should not serve a file outside the directory",
+			key:          "../secret.txt",
+			expires:      strconv.FormatInt(exp, 10),
+			signature:    s.sign("../secret.txt", exp),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "should not serve a file outside the directory through a subdirectory",
+			key:          "users/../../secret.txt",
+			expires:      strconv.FormatInt(exp, 10),
+			signature:    s.sign("users/../../secret.txt", exp),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "should not serve the directory",
+			key:          "users",
+			expires:      strconv.FormatInt(exp, 10),
+			signature:    s.sign("users", exp),
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.URL.Path = "/" + tc.key
+			req.URL.RawQuery = url.Values{"expires": {tc.expires}, "signature": {tc.signature}}.Encode()
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.NotContains(t, rec.Body.String(), "secret")
+		})
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	s, root := newLocalStore(t)
+
+	name, err := s.path("users/a.png")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "blobs", "users", "a.png"), name)
+
+	for _, key := range []string{"", ".", "..", "../secret.txt", "users/../../secret.txt", "users/../a.png", "/etc/passwd", "users//a.png", "users/a.png/"} {
+		_, err := s.path(key)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+
+	_, err = s.SignedURL(context.Background(), "../secret.txt", time.Minute)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	assert.ErrorIs(t, s.Put(context.Background(), &domains.Blob{Key: "../secret.txt", Data: []byte("x")}), ErrInvalidKey)
+	assert.ErrorIs(t, s.Delete(context.Background(), "../secret.txt"), ErrInvalidKey)
+	_, err = os.Stat(filepath.Join(root, "secret.txt"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,22 @@
+package constants
+
+// API_KEY_PREFIX tells an api key apart from a jwt in the Authorization header.
+const API_KEY_PREFIX = "rh_"
+
+// apiKeyScopes are the permissions an api key can be limited to, keys are
+// for scripts calling the blog and comment apis so user management is left out.
+var apiKeyScopes = []string{
+	PERMISSION_BLOG_READ,
+	PERMISSION_BLOG_WRITE,
+	PERMISSION_COMMENT_READ,
+	PERMISSION_COMMENT_WRITE,
+}
+
+func IsValidAPIKeyScope(scope string) bool {
+	for _, s := range apiKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
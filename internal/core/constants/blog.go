@@ -0,0 +1,33 @@
+package constants
+
+// limits of an edited blog in characters
+const (
+	BLOG_TITLE_MAX_LENGTH   = 200
+	BLOG_CONTENT_MAX_LENGTH = 20000
+)
+
+// archived blogs deleted in one transaction by the retention
+const BLOG_PURGE_BATCH_SIZE = 100
+
+// users a blog can be assigned to at once
+const BLOG_MAX_ASSIGNEES = 10
+
+// kinds of reminder sent about the due date of a blog
+const (
+	BLOG_REMINDER_DUE_SOON = "dueSoon"
+	BLOG_REMINDER_OVERDUE  = "overdue"
+)
+
+// blogs reminded of each kind in one run of the reminder job
+const BLOG_REMINDER_BATCH_SIZE = 100
+
+// priorities of a blog from the lowest to the highest
+const (
+	PRIORITY_LOW    = "low"
+	PRIORITY_MEDIUM = "medium"
+	PRIORITY_HIGH   = "high"
+	PRIORITY_URGENT = "urgent"
+)
+
+// fields the blog list can be sorted by, a leading - sorts descending
+var BLOG_SORT_FIELDS = []string{"priority", "createdAt", "updatedAt", "dueAt", "title"}
@@ -0,0 +1,12 @@
+package constants
+
+import "regexp"
+
+// limits of a label
+const (
+	LABEL_NAME_MAX_LENGTH = 30
+	BLOG_MAX_LABELS       = 20
+)
+
+// LABEL_COLOR is a hex color like #1f883d
+var LABEL_COLOR = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
@@ -0,0 +1,29 @@
+package constants
+
+// PROFILE_IMAGE_ORIGINAL is the size name of an uploaded profile image as it was sent.
+const PROFILE_IMAGE_ORIGINAL = "original"
+
+// PROFILE_IMAGE_DEFAULT_SIZE is served when no size is asked for.
+const PROFILE_IMAGE_DEFAULT_SIZE = "256"
+
+// PROFILE_IMAGE_MAX_PIXELS rejects images which are small files but would take
+// a lot of memory to decode.
+const PROFILE_IMAGE_MAX_PIXELS = 25_000_000
+
+// PROFILE_IMAGE_THUMBNAILS are the square thumbnails made from an uploaded
+// profile image, by their width in pixels.
+var PROFILE_IMAGE_THUMBNAILS = []int{64, 256}
+
+// profileImageTypes are the accepted content types of a profile image with
+// the extension they are stored with.
+var profileImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// ProfileImageExtension returns the file extension of an accepted content type.
+func ProfileImageExtension(contentType string) (string, bool) {
+	ext, ok := profileImageTypes[contentType]
+	return ext, ok
+}
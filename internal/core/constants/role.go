@@ -0,0 +1,62 @@
+package constants
+
+const (
+	ROLE_ADMIN  = "admin"
+	ROLE_EDITOR = "editor"
+	ROLE_MEMBER = "member"
+	ROLE_VIEWER = "viewer"
+)
+
+const (
+	PERMISSION_BLOG_READ     = "blog:read"
+	PERMISSION_BLOG_WRITE    = "blog:write"
+	PERMISSION_COMMENT_READ  = "comment:read"
+	PERMISSION_COMMENT_WRITE = "comment:write"
+	PERMISSION_USER_MANAGE   = "user:manage"
+	PERMISSION_LABEL_MANAGE  = "label:manage"
+)
+
+// DEFAULT_ROLE is given to newly registered users and to users created before roles existed.
+const DEFAULT_ROLE = ROLE_MEMBER
+
+var rolePermissions = map[string][]string{
+	ROLE_ADMIN: {
+		PERMISSION_BLOG_READ,
+		PERMISSION_BLOG_WRITE,
+		PERMISSION_COMMENT_READ,
+		PERMISSION_COMMENT_WRITE,
+		PERMISSION_USER_MANAGE,
+		PERMISSION_LABEL_MANAGE,
+	},
+	ROLE_EDITOR: {
+		PERMISSION_BLOG_READ,
+		PERMISSION_BLOG_WRITE,
+		PERMISSION_COMMENT_READ,
+		PERMISSION_COMMENT_WRITE,
+		PERMISSION_LABEL_MANAGE,
+	},
+	ROLE_MEMBER: {
+		PERMISSION_BLOG_READ,
+		PERMISSION_BLOG_WRITE,
+		PERMISSION_COMMENT_READ,
+		PERMISSION_COMMENT_WRITE,
+	},
+	ROLE_VIEWER: {
+		PERMISSION_BLOG_READ,
+		PERMISSION_COMMENT_READ,
+	},
+}
+
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+func HasPermission(role string, permission string) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,8 @@
+package constants
+
+// purposes of single-use user tokens
+const (
+	TOKEN_PURPOSE_RESET_PASSWORD = "reset_password"
+	TOKEN_PURPOSE_VERIFY_EMAIL   = "verify_email"
+	TOKEN_PURPOSE_MFA_CHALLENGE  = "mfa_challenge"
+)
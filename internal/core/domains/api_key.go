@@ -0,0 +1,47 @@
+package domains
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKey is a personal key of a user for scripts, only the hash of the key is
+// stored and the prefix is kept to recognize it in the list.
+type APIKey struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	UserId     primitive.ObjectID `bson:"userId"`
+	Name       string             `bson:"name"`
+	Prefix     string             `bson:"prefix"`
+	KeyHash    string             `bson:"keyHash"`
+	Scopes     []string           `bson:"scopes"`
+	ExpiresAt  *time.Time         `bson:"expiresAt"`
+	LastUsedAt *time.Time         `bson:"lastUsedAt"`
+	CreatedAt  time.Time          `bson:"createdAt"`
+}
+
+type CreateAPIKeyRequest struct {
+	UserId    string
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+type CreateAPIKeyResponse struct {
+	APIKey APIKey
+	Key    string
+}
+
+type StoreAPIKeyRequest struct {
+	UserId    primitive.ObjectID
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+type RevokeAPIKeyRequest struct {
+	UserId   string
+	APIKeyId string
+}
@@ -0,0 +1,7 @@
+package domains
+
+type Blob struct {
+	Key         string
+	ContentType string
+	Data        []byte
+}
@@ -7,13 +7,23 @@ import (
 )
 
 type Blog struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty"`
-	Title      string             `bson:"title"`
-	Content    string             `bson:"content"`
-	AuthorId   primitive.ObjectID `bson:"authorId"`
-	Status     string             `bson:"status"`
-	IsArchived bool               `bson:"isArchived"`
-	CreatedAt  time.Time          `bson:"createdAt"`
+	ID          primitive.ObjectID   `bson:"_id,omitempty"`
+	Title       string               `bson:"title"`
+	Content     string               `bson:"content"`
+	AuthorId    primitive.ObjectID   `bson:"authorId"`
+	AssigneeIds []primitive.ObjectID `bson:"assigneeIds,omitempty"`
+	LabelIds    []primitive.ObjectID `bson:"labelIds,omitempty"`
+	Status      string               `bson:"status"`
+	Priority    Priority             `bson:"priority,omitempty"`
+	DueAt       time.Time            `bson:"dueAt,omitempty"`
+	IsArchived  bool                 `bson:"isArchived"`
+	CreatedAt   time.Time            `bson:"createdAt"`
+	UpdatedAt   time.Time            `bson:"updatedAt,omitempty"`
+	UpdatedBy   primitive.ObjectID   `bson:"updatedBy,omitempty"`
+	ArchivedAt  time.Time            `bson:"archivedAt,omitempty"`
+	ArchivedBy  primitive.ObjectID   `bson:"archivedBy,omitempty"`
+	// when each kind of reminder was sent for the current due date
+	Reminders map[string]time.Time `bson:"reminders,omitempty"`
 }
 
 type PopulatedBlog struct {
@@ -21,20 +31,71 @@ type PopulatedBlog struct {
 	Title      string             `bson:"title"`
 	Content    string             `bson:"content"`
 	Author     User               `bson:"author"`
+	Assignees  []User             `bson:"assignees"`
+	Labels     []Label            `bson:"labels"`
 	Status     string             `bson:"status"`
+	Priority   Priority           `bson:"priority,omitempty"`
+	DueAt      time.Time          `bson:"dueAt,omitempty"`
 	IsArchived bool               `bson:"isArchived"`
 	CreatedAt  time.Time          `bson:"createdAt"`
+	UpdatedAt  time.Time          `bson:"updatedAt,omitempty"`
+	UpdatedBy  primitive.ObjectID `bson:"updatedBy,omitempty"`
+	ArchivedAt time.Time          `bson:"archivedAt,omitempty"`
+	ArchivedBy primitive.ObjectID `bson:"archivedBy,omitempty"`
 }
 
 type CreateBlogRequest struct {
 	Title    string
 	Content  string
 	AuthorId string
+	Status   string
+	// one of the priority names, medium when it's empty
+	Priority string
+	DueAt    *time.Time
 }
 
 type ListBlogRequest struct {
-	Page  uint32
-	Limit uint32
+	Page       uint32
+	Limit      uint32
+	Archived   bool
+	AssigneeId string
+	Overdue    bool
+	LabelIds   []string
+	AllLabels  bool
+	Statuses   []string
+	AuthorId   string
+	// created in [CreatedFrom, CreatedTo), a zero time leaves the side open
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// part of the title, case insensitive
+	Title string
+	// one of the BLOG_SORT_FIELDS, a leading - sorts descending
+	Sort string
+}
+
+// BlogFilter narrows the blogs which are listed and counted.
+type BlogFilter struct {
+	Archived   bool
+	AssigneeId string
+	// blogs due before it which aren't in one of the done statuses, when set
+	OverdueAt    time.Time
+	DoneStatuses []string
+	// blogs with any of the labels, or all of them with AllLabels
+	LabelIds  []string
+	AllLabels bool
+	// blogs in any of the statuses
+	Statuses    []string
+	AuthorId    string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Title       string
+}
+
+// BlogSort orders the listed blogs, the ones sorted the same are ordered by
+// their creation in the same direction, so the newest come first when Desc.
+type BlogSort struct {
+	Field string
+	Desc  bool
 }
 
 type PaginationOptions struct {
@@ -55,8 +116,64 @@ type ListBlog struct {
 type UpdateBlogStatusRequest struct {
 	BlogId string
 	Status string
+	UserId string
+	Role   string
+}
+
+type EditBlogRequest struct {
+	BlogId   string
+	Title    *string
+	Content  *string
+	Priority *string
+	UserId   string
 }
 
 type ArchiveBlogRequest struct {
 	BlogId string
+	UserId string
+	Role   string
+}
+
+// SetBlogDueRequest sets the due date, a nil DueAt removes it.
+type SetBlogDueRequest struct {
+	BlogId string
+	DueAt  *time.Time
+	UserId string
+	Role   string
+}
+
+// ListBlogReminderRequest looks for the blogs due in [DueAfter, DueBefore)
+// which haven't got the kind of reminder yet.
+type ListBlogReminderRequest struct {
+	Kind         string
+	DueAfter     time.Time
+	DueBefore    time.Time
+	DoneStatuses []string
+	Limit        int64
+}
+
+type AssignBlogRequest struct {
+	BlogId     string
+	AssigneeId string
+	UserId     string
+	Role       string
+}
+
+type UnassignBlogRequest struct {
+	BlogId     string
+	AssigneeId string
+	UserId     string
+	Role       string
+}
+
+type RestoreBlogRequest struct {
+	BlogId string
+	UserId string
+	Role   string
+}
+
+// PurgeArchivedBlogsRequest deletes up to Limit blogs archived before ArchivedBefore.
+type PurgeArchivedBlogsRequest struct {
+	ArchivedBefore time.Time
+	Limit          int64
 }
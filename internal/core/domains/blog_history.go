@@ -0,0 +1,33 @@
+package domains
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BlogHistory is a status change of a blog.
+type BlogHistory struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	BlogId    primitive.ObjectID `bson:"blogId"`
+	From      string             `bson:"from"`
+	To        string             `bson:"to"`
+	ActorId   primitive.ObjectID `bson:"actorId"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
+
+type PopulatedBlogHistory struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	BlogId    primitive.ObjectID `bson:"blogId"`
+	From      string             `bson:"from"`
+	To        string             `bson:"to"`
+	Actor     User               `bson:"actor"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
+
+type CreateBlogHistoryRequest struct {
+	BlogId  string
+	From    string
+	To      string
+	ActorId string
+}
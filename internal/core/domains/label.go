@@ -0,0 +1,44 @@
+package domains
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrLabelNameTaken is returned by the label repository when the name is used by another label.
+var ErrLabelNameTaken = errors.New("label name is already taken")
+
+type Label struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Name      string             `bson:"name"`
+	Color     string             `bson:"color"`
+	CreatedAt time.Time          `bson:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt,omitempty"`
+}
+
+type CreateLabelRequest struct {
+	Name  string
+	Color string
+}
+
+type UpdateLabelRequest struct {
+	LabelId string
+	Name    *string
+	Color   *string
+}
+
+type AttachLabelRequest struct {
+	BlogId  string
+	LabelId string
+	UserId  string
+	Role    string
+}
+
+type DetachLabelRequest struct {
+	BlogId  string
+	LabelId string
+	UserId  string
+	Role    string
+}
@@ -0,0 +1,21 @@
+package domains
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LoginAttempt counts the failed logins of a username or a client ip.
+type LoginAttempt struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	Key          string             `bson:"key"`
+	Failures     int                `bson:"failures"`
+	LockedUntil  time.Time          `bson:"lockedUntil"`
+	LastFailedAt time.Time          `bson:"lastFailedAt"`
+	ExpiresAt    time.Time          `bson:"expiresAt"`
+}
+
+type UnlockUserRequest struct {
+	UserId string
+}
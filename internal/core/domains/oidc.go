@@ -0,0 +1,56 @@
+package domains
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OIDCIdentity is the user as told by the id token of the identity provider.
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Username      string
+	Name          string
+}
+
+// OIDCState is a pending login at the identity provider, it is consumed by
+// the callback so a state can't be replayed.
+type OIDCState struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	StateHash    string             `bson:"stateHash"`
+	Nonce        string             `bson:"nonce"`
+	CodeVerifier string             `bson:"codeVerifier"`
+	ExpiresAt    time.Time          `bson:"expiresAt"`
+	CreatedAt    time.Time          `bson:"createdAt"`
+}
+
+type CreateOIDCStateRequest struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+type AuthCodeURLRequest struct {
+	State         string
+	Nonce         string
+	CodeChallenge string
+}
+
+type ExchangeCodeRequest struct {
+	Code         string
+	CodeVerifier string
+	Nonce        string
+}
+
+type OIDCLoginResponse struct {
+	URL string
+}
+
+type OIDCCallbackRequest struct {
+	State string
+	Code  string
+}
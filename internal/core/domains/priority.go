@@ -0,0 +1,31 @@
+package domains
+
+import "robinhood/internal/core/constants"
+
+var priorities = []string{
+	constants.PRIORITY_LOW,
+	constants.PRIORITY_MEDIUM,
+	constants.PRIORITY_HIGH,
+	constants.PRIORITY_URGENT,
+}
+
+// Priority is stored as a rank so that the blogs sort from low to urgent,
+// a blog created before the priorities has none.
+type Priority int
+
+// ParsePriority returns the priority of the name, it is false for an unknown one.
+func ParsePriority(name string) (Priority, bool) {
+	for i, p := range priorities {
+		if p == name {
+			return Priority(i + 1), true
+		}
+	}
+	return 0, false
+}
+
+func (p Priority) String() string {
+	if p < 1 || int(p) > len(priorities) {
+		return ""
+	}
+	return priorities[p-1]
+}
@@ -0,0 +1,29 @@
+package domains
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RefreshToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserId    primitive.ObjectID `bson:"userId"`
+	FamilyId  string             `bson:"familyId"`
+	TokenHash string             `bson:"tokenHash"`
+	UsedAt    *time.Time         `bson:"usedAt"`
+	RevokedAt *time.Time         `bson:"revokedAt"`
+	ExpiresAt time.Time          `bson:"expiresAt"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
+
+type CreateRefreshTokenRequest struct {
+	UserId    primitive.ObjectID
+	FamilyId  string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string
+}
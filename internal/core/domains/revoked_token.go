@@ -0,0 +1,21 @@
+package domains
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RevokedToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	TokenId   string             `bson:"tokenId"`
+	UserId    primitive.ObjectID `bson:"userId"`
+	ExpiresAt time.Time          `bson:"expiresAt"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
+
+type RevokeTokenRequest struct {
+	TokenId   string
+	UserId    string
+	ExpiresAt time.Time
+}
@@ -4,3 +4,6 @@ import "context"
 
 type CreateBlogFn func(context.Context, *CreateBlogRequest) (*PopulatedBlog, error)
 type CreateCommentFn func(context.Context, *CreateCommentRequest) (*PopulatedComment, error)
+type UpdateBlogStatusFn func(context.Context, *UpdateBlogStatusRequest) error
+type PurgeArchivedBlogsFn func(context.Context, *PurgeArchivedBlogsRequest) (int64, error)
+type DeleteLabelFn func(context.Context, string) error
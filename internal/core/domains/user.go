@@ -1,18 +1,46 @@
 package domains
 
 import (
+	"errors"
+	"io"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// errors of the user repository when a unique index is violated
+var (
+	ErrUsernameTaken = errors.New("username is already taken")
+	ErrEmailTaken    = errors.New("email is already taken")
+)
+
 type User struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty"`
 	Username     string             `bson:"username"`
 	Password     string             `bson:"password"`
 	Email        string             `bson:"email"`
+	Role         string             `bson:"role"`
+	Verified     bool               `bson:"verified"`
 	ProfileImage string             `bson:"profileImage"`
-	CreatedAt    time.Time          `bson:"createdAt"`
+	// blob keys of an uploaded profile image by size
+	ProfileImageKeys map[string]string `bson:"profileImageKeys,omitempty"`
+	LastActiveAt     time.Time         `bson:"lastActiveAt"`
+	TokenVersion     int               `bson:"tokenVersion"`
+	MFAEnabled       bool              `bson:"mfaEnabled"`
+	TOTPSecret       string            `bson:"totpSecret,omitempty"`
+	TOTPLastCounter  int64             `bson:"totpLastCounter"`
+	RecoveryCodes    []string          `bson:"recoveryCodes,omitempty"`
+	OIDCIssuer       string            `bson:"oidcIssuer,omitempty"`
+	OIDCSubject      string            `bson:"oidcSubject,omitempty"`
+	Deactivated      bool              `bson:"deactivated"`
+	DeactivatedAt    time.Time         `bson:"deactivatedAt,omitempty"`
+	CreatedAt        time.Time         `bson:"createdAt"`
+}
+
+// HasPassword tells if the user has a password of its own, a user provisioned
+// by the identity provider has none until one is set with forgot password.
+func (u *User) HasPassword() bool {
+	return u.Password != ""
 }
 
 type RegisterRequest struct {
@@ -24,19 +52,121 @@ type RegisterRequest struct {
 type LoginRequest struct {
 	Username string
 	Password string
+	IP       string
 }
 
 type LoginResponse struct {
-	Token string
+	Token        string
+	RefreshToken string
+	MFARequired  bool
+	MFAToken     string
+}
+
+type LoginMFARequest struct {
+	MFAToken string
+	Code     string
+	IP       string
+}
+
+type EnrollTOTPResponse struct {
+	Secret string
+	URI    string
+}
+
+type ConfirmTOTPRequest struct {
+	UserId string
+	Code   string
+}
+
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string
 }
 
 type CreateUserRequest struct {
-	Username string
-	Password string
-	Email    string
+	Username    string
+	Password    string
+	Email       string
+	Role        string
+	Verified    bool
+	OIDCIssuer  string
+	OIDCSubject string
+}
+
+type SearchUserRequest struct {
+	Query string
+	Page  uint32
+	Limit uint32
+}
+
+type SearchUserResponse struct {
+	Data    []User
+	HasNext bool
+}
+
+type UploadProfileImageRequest struct {
+	UserId string
+	Size   int64
+	File   io.Reader
+}
+
+type ProfileImageURLRequest struct {
+	UserId string
+	Size   string
 }
 
 type UpdateUserRequest struct {
 	UserId       string
 	ProfileImage string
 }
+
+type ChangePasswordRequest struct {
+	UserId          string
+	CurrentPassword string
+	NewPassword     string
+}
+
+type ChangeEmailRequest struct {
+	UserId   string
+	Email    string
+	Password string
+}
+
+type ChangeUsernameRequest struct {
+	UserId   string
+	Username string
+}
+
+type DeactivateUserRequest struct {
+	UserId   string
+	Password string
+}
+
+type DeleteUserRequest struct {
+	UserId   string
+	Password string
+}
+
+type UpdateUserRoleRequest struct {
+	UserId string
+	Role   string
+}
+
+type LogoutRequest struct {
+	UserId    string
+	TokenId   string
+	SessionId string
+	ExpiresAt time.Time
+}
+
+type ForgotPasswordRequest struct {
+	Email string
+}
+
+type VerifyEmailRequest struct {
+	Token string
+}
+
+type ResetPasswordRequest struct {
+	Token    string
+	Password string
+}
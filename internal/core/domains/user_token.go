@@ -0,0 +1,25 @@
+package domains
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserToken is a single-use token sent to the user out of band, e.g. by email.
+type UserToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserId    primitive.ObjectID `bson:"userId"`
+	Purpose   string             `bson:"purpose"`
+	TokenHash string             `bson:"tokenHash"`
+	UsedAt    *time.Time         `bson:"usedAt"`
+	ExpiresAt time.Time          `bson:"expiresAt"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
+
+type CreateUserTokenRequest struct {
+	UserId    primitive.ObjectID
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+}
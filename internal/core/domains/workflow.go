@@ -0,0 +1,132 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"robinhood/internal/core/constants"
+)
+
+// Workflow is the statuses a blog goes through and who can move it from
+// one status to another.
+type Workflow struct {
+	Name          string               `json:"name"`
+	InitialStatus string               `json:"initialStatus"`
+	Statuses      []string             `json:"statuses"`
+	Transitions   []WorkflowTransition `json:"transitions"`
+	// statuses of a finished blog, it is never overdue
+	DoneStatuses []string `json:"doneStatuses"`
+}
+
+type WorkflowTransition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// roles which can make the transition, every role when empty. a role
+	// named here can make it on the blogs of other authors as well
+	Roles []string `json:"roles,omitempty"`
+}
+
+// DefaultWorkflow moves a blog forward one status at a time, it can go back
+// from IN PROGRESS and only an admin or an editor, of any blog, can reopen it
+// once DONE.
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Name:          "default",
+		InitialStatus: constants.TO_DO,
+		Statuses:      []string{constants.TO_DO, constants.IN_PROGRESS, constants.DONE},
+		Transitions: []WorkflowTransition{
+			{From: constants.TO_DO, To: constants.IN_PROGRESS},
+			{From: constants.IN_PROGRESS, To: constants.TO_DO},
+			{From: constants.IN_PROGRESS, To: constants.DONE},
+			{From: constants.DONE, To: constants.IN_PROGRESS, Roles: []string{constants.ROLE_ADMIN, constants.ROLE_EDITOR}},
+		},
+		DoneStatuses: []string{constants.DONE},
+	}
+}
+
+// Validate checks that the workflow only refers to its own statuses and
+// to roles which exist.
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return errors.New("workflow has no status")
+	}
+	seen := map[string]bool{}
+	for _, s := range w.Statuses {
+		if s == "" || seen[s] {
+			return fmt.Errorf("workflow status %q is empty or duplicated", s)
+		}
+		seen[s] = true
+	}
+	if !seen[w.InitialStatus] {
+		return fmt.Errorf("workflow initial status %q is not one of its statuses", w.InitialStatus)
+	}
+	for _, s := range w.DoneStatuses {
+		if !seen[s] {
+			return fmt.Errorf("workflow done status %q is not one of its statuses", s)
+		}
+	}
+	for _, t := range w.Transitions {
+		if !seen[t.From] || !seen[t.To] || t.From == t.To {
+			return fmt.Errorf("workflow transition from %q to %q is invalid", t.From, t.To)
+		}
+		for _, r := range t.Roles {
+			if !constants.IsValidRole(r) {
+				return fmt.Errorf("workflow transition from %q to %q has an unknown role %q", t.From, t.To, r)
+			}
+		}
+	}
+	return nil
+}
+
+func (w *Workflow) HasStatus(status string) bool {
+	for _, s := range w.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransition tells whether the role can move a blog between the statuses.
+func (w *Workflow) CanTransition(from string, to string, role string) bool {
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to && t.allows(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// GrantsTransition tells whether a transition between the statuses names the
+// role, which lets the role make it on any blog.
+func (w *Workflow) GrantsTransition(from string, to string, role string) bool {
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to && len(t.Roles) > 0 && t.allows(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStatuses returns the statuses the role can move a blog to, in the
+// order of the workflow.
+func (w *Workflow) NextStatuses(from string, role string) []string {
+	result := []string{}
+	for _, s := range w.Statuses {
+		if w.CanTransition(from, s, role) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func (t WorkflowTransition) allows(role string) bool {
+	if len(t.Roles) == 0 {
+		return true
+	}
+	for _, r := range t.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+	"time"
+)
+
+// BlobStore keeps uploaded files, they are read back through signed urls
+// which expire.
+type BlobStore interface {
+	Put(context.Context, *domains.Blob) error
+	Delete(context.Context, string) error
+	SignedURL(context.Context, string, time.Duration) (string, error)
+}
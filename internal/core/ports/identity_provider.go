@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+)
+
+// IdentityProvider is an external OpenID Connect provider users can sign in with.
+type IdentityProvider interface {
+	AuthCodeURL(context.Context, *domains.AuthCodeURLRequest) (string, error)
+	Exchange(context.Context, *domains.ExchangeCodeRequest) (*domains.OIDCIdentity, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+)
+
+type Mailer interface {
+	Send(context.Context, *domains.Mail) error
+}
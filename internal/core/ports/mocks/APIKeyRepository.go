@@ -0,0 +1,348 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+type APIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyRepository) EXPECT() *APIKeyRepository_Expecter {
+	return &APIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *APIKeyRepository) Create(_a0 context.Context, _a1 *domains.StoreAPIKeyRequest) (*domains.APIKey, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.StoreAPIKeyRequest) (*domains.APIKey, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.StoreAPIKeyRequest) *domains.APIKey); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.StoreAPIKeyRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type APIKeyRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.StoreAPIKeyRequest
+func (_e *APIKeyRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *APIKeyRepository_Create_Call {
+	return &APIKeyRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *APIKeyRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *domains.StoreAPIKeyRequest)) *APIKeyRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.StoreAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_Create_Call) Return(_a0 *domains.APIKey, _a1 error) *APIKeyRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_Create_Call) RunAndReturn(run func(context.Context, *domains.StoreAPIKeyRequest) (*domains.APIKey, error)) *APIKeyRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: _a0, _a1, _a2
+func (_m *APIKeyRepository) Delete(_a0 context.Context, _a1 primitive.ObjectID, _a2 primitive.ObjectID) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID) (bool, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type APIKeyRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 primitive.ObjectID
+func (_e *APIKeyRepository_Expecter) Delete(_a0 interface{}, _a1 interface{}, _a2 interface{}) *APIKeyRepository_Delete_Call {
+	return &APIKeyRepository_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1, _a2)}
+}
+
+func (_c *APIKeyRepository_Delete_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 primitive.ObjectID)) *APIKeyRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_Delete_Call) Return(_a0 bool, _a1 error) *APIKeyRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_Delete_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, primitive.ObjectID) (bool, error)) *APIKeyRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUserID provides a mock function with given fields: _a0, _a1
+func (_m *APIKeyRepository) DeleteByUserID(_a0 context.Context, _a1 primitive.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyRepository_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type APIKeyRepository_DeleteByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+func (_e *APIKeyRepository_Expecter) DeleteByUserID(_a0 interface{}, _a1 interface{}) *APIKeyRepository_DeleteByUserID_Call {
+	return &APIKeyRepository_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", _a0, _a1)}
+}
+
+func (_c *APIKeyRepository_DeleteByUserID_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID)) *APIKeyRepository_DeleteByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_DeleteByUserID_Call) Return(_a0 error) *APIKeyRepository_DeleteByUserID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APIKeyRepository_DeleteByUserID_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *APIKeyRepository_DeleteByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByKeyHash provides a mock function with given fields: _a0, _a1
+func (_m *APIKeyRepository) GetByKeyHash(_a0 context.Context, _a1 string) (*domains.APIKey, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.APIKey, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.APIKey); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_GetByKeyHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByKeyHash'
+type APIKeyRepository_GetByKeyHash_Call struct {
+	*mock.Call
+}
+
+// GetByKeyHash is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *APIKeyRepository_Expecter) GetByKeyHash(_a0 interface{}, _a1 interface{}) *APIKeyRepository_GetByKeyHash_Call {
+	return &APIKeyRepository_GetByKeyHash_Call{Call: _e.mock.On("GetByKeyHash", _a0, _a1)}
+}
+
+func (_c *APIKeyRepository_GetByKeyHash_Call) Run(run func(_a0 context.Context, _a1 string)) *APIKeyRepository_GetByKeyHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_GetByKeyHash_Call) Return(_a0 *domains.APIKey, _a1 error) *APIKeyRepository_GetByKeyHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_GetByKeyHash_Call) RunAndReturn(run func(context.Context, string) (*domains.APIKey, error)) *APIKeyRepository_GetByKeyHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUserID provides a mock function with given fields: _a0, _a1
+func (_m *APIKeyRepository) ListByUserID(_a0 context.Context, _a1 primitive.ObjectID) ([]domains.APIKey, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []domains.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) ([]domains.APIKey, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) []domains.APIKey); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_ListByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUserID'
+type APIKeyRepository_ListByUserID_Call struct {
+	*mock.Call
+}
+
+// ListByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+func (_e *APIKeyRepository_Expecter) ListByUserID(_a0 interface{}, _a1 interface{}) *APIKeyRepository_ListByUserID_Call {
+	return &APIKeyRepository_ListByUserID_Call{Call: _e.mock.On("ListByUserID", _a0, _a1)}
+}
+
+func (_c *APIKeyRepository_ListByUserID_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID)) *APIKeyRepository_ListByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_ListByUserID_Call) Return(_a0 []domains.APIKey, _a1 error) *APIKeyRepository_ListByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_ListByUserID_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) ([]domains.APIKey, error)) *APIKeyRepository_ListByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLastUsedAt provides a mock function with given fields: _a0, _a1, _a2
+func (_m *APIKeyRepository) UpdateLastUsedAt(_a0 context.Context, _a1 primitive.ObjectID, _a2 time.Time) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyRepository_UpdateLastUsedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLastUsedAt'
+type APIKeyRepository_UpdateLastUsedAt_Call struct {
+	*mock.Call
+}
+
+// UpdateLastUsedAt is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 time.Time
+func (_e *APIKeyRepository_Expecter) UpdateLastUsedAt(_a0 interface{}, _a1 interface{}, _a2 interface{}) *APIKeyRepository_UpdateLastUsedAt_Call {
+	return &APIKeyRepository_UpdateLastUsedAt_Call{Call: _e.mock.On("UpdateLastUsedAt", _a0, _a1, _a2)}
+}
+
+func (_c *APIKeyRepository_UpdateLastUsedAt_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 time.Time)) *APIKeyRepository_UpdateLastUsedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_UpdateLastUsedAt_Call) Return(_a0 error) *APIKeyRepository_UpdateLastUsedAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APIKeyRepository_UpdateLastUsedAt_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, time.Time) error) *APIKeyRepository_UpdateLastUsedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewAPIKeyRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAPIKeyRepository(t mockConstructorTestingTNewAPIKeyRepository) *APIKeyRepository {
+	mock := &APIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,180 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BlobStore is an autogenerated mock type for the BlobStore type
+type BlobStore struct {
+	mock.Mock
+}
+
+type BlobStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlobStore) EXPECT() *BlobStore_Expecter {
+	return &BlobStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *BlobStore) Delete(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlobStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type BlobStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *BlobStore_Expecter) Delete(_a0 interface{}, _a1 interface{}) *BlobStore_Delete_Call {
+	return &BlobStore_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *BlobStore_Delete_Call) Run(run func(_a0 context.Context, _a1 string)) *BlobStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlobStore_Delete_Call) Return(_a0 error) *BlobStore_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlobStore_Delete_Call) RunAndReturn(run func(context.Context, string) error) *BlobStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: _a0, _a1
+func (_m *BlobStore) Put(_a0 context.Context, _a1 *domains.Blob) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.Blob) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlobStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type BlobStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.Blob
+func (_e *BlobStore_Expecter) Put(_a0 interface{}, _a1 interface{}) *BlobStore_Put_Call {
+	return &BlobStore_Put_Call{Call: _e.mock.On("Put", _a0, _a1)}
+}
+
+func (_c *BlobStore_Put_Call) Run(run func(_a0 context.Context, _a1 *domains.Blob)) *BlobStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.Blob))
+	})
+	return _c
+}
+
+func (_c *BlobStore_Put_Call) Return(_a0 error) *BlobStore_Put_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlobStore_Put_Call) RunAndReturn(run func(context.Context, *domains.Blob) error) *BlobStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignedURL provides a mock function with given fields: _a0, _a1, _a2
+func (_m *BlobStore) SignedURL(_a0 context.Context, _a1 string, _a2 time.Duration) (string, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (string, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) string); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlobStore_SignedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignedURL'
+type BlobStore_SignedURL_Call struct {
+	*mock.Call
+}
+
+// SignedURL is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 time.Duration
+func (_e *BlobStore_Expecter) SignedURL(_a0 interface{}, _a1 interface{}, _a2 interface{}) *BlobStore_SignedURL_Call {
+	return &BlobStore_SignedURL_Call{Call: _e.mock.On("SignedURL", _a0, _a1, _a2)}
+}
+
+func (_c *BlobStore_SignedURL_Call) Run(run func(_a0 context.Context, _a1 string, _a2 time.Duration)) *BlobStore_SignedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *BlobStore_SignedURL_Call) Return(_a0 string, _a1 error) *BlobStore_SignedURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlobStore_SignedURL_Call) RunAndReturn(run func(context.Context, string, time.Duration) (string, error)) *BlobStore_SignedURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewBlobStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBlobStore creates a new instance of BlobStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBlobStore(t mockConstructorTestingTNewBlobStore) *BlobStore {
+	mock := &BlobStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
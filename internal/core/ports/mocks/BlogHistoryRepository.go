@@ -0,0 +1,191 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlogHistoryRepository is an autogenerated mock type for the BlogHistoryRepository type
+type BlogHistoryRepository struct {
+	mock.Mock
+}
+
+type BlogHistoryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlogHistoryRepository) EXPECT() *BlogHistoryRepository_Expecter {
+	return &BlogHistoryRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *BlogHistoryRepository) Create(_a0 context.Context, _a1 *domains.CreateBlogHistoryRequest) (*domains.BlogHistory, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.BlogHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateBlogHistoryRequest) (*domains.BlogHistory, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateBlogHistoryRequest) *domains.BlogHistory); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.BlogHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.CreateBlogHistoryRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogHistoryRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type BlogHistoryRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.CreateBlogHistoryRequest
+func (_e *BlogHistoryRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *BlogHistoryRepository_Create_Call {
+	return &BlogHistoryRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *BlogHistoryRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *domains.CreateBlogHistoryRequest)) *BlogHistoryRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.CreateBlogHistoryRequest))
+	})
+	return _c
+}
+
+func (_c *BlogHistoryRepository_Create_Call) Return(_a0 *domains.BlogHistory, _a1 error) *BlogHistoryRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogHistoryRepository_Create_Call) RunAndReturn(run func(context.Context, *domains.CreateBlogHistoryRequest) (*domains.BlogHistory, error)) *BlogHistoryRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByBlogIDs provides a mock function with given fields: _a0, _a1
+func (_m *BlogHistoryRepository) DeleteByBlogIDs(_a0 context.Context, _a1 []string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogHistoryRepository_DeleteByBlogIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByBlogIDs'
+type BlogHistoryRepository_DeleteByBlogIDs_Call struct {
+	*mock.Call
+}
+
+// DeleteByBlogIDs is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []string
+func (_e *BlogHistoryRepository_Expecter) DeleteByBlogIDs(_a0 interface{}, _a1 interface{}) *BlogHistoryRepository_DeleteByBlogIDs_Call {
+	return &BlogHistoryRepository_DeleteByBlogIDs_Call{Call: _e.mock.On("DeleteByBlogIDs", _a0, _a1)}
+}
+
+func (_c *BlogHistoryRepository_DeleteByBlogIDs_Call) Run(run func(_a0 context.Context, _a1 []string)) *BlogHistoryRepository_DeleteByBlogIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *BlogHistoryRepository_DeleteByBlogIDs_Call) Return(_a0 error) *BlogHistoryRepository_DeleteByBlogIDs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogHistoryRepository_DeleteByBlogIDs_Call) RunAndReturn(run func(context.Context, []string) error) *BlogHistoryRepository_DeleteByBlogIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByBlogID provides a mock function with given fields: _a0, _a1
+func (_m *BlogHistoryRepository) ListByBlogID(_a0 context.Context, _a1 string) ([]domains.PopulatedBlogHistory, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []domains.PopulatedBlogHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.PopulatedBlogHistory, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.PopulatedBlogHistory); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PopulatedBlogHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogHistoryRepository_ListByBlogID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByBlogID'
+type BlogHistoryRepository_ListByBlogID_Call struct {
+	*mock.Call
+}
+
+// ListByBlogID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *BlogHistoryRepository_Expecter) ListByBlogID(_a0 interface{}, _a1 interface{}) *BlogHistoryRepository_ListByBlogID_Call {
+	return &BlogHistoryRepository_ListByBlogID_Call{Call: _e.mock.On("ListByBlogID", _a0, _a1)}
+}
+
+func (_c *BlogHistoryRepository_ListByBlogID_Call) Run(run func(_a0 context.Context, _a1 string)) *BlogHistoryRepository_ListByBlogID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlogHistoryRepository_ListByBlogID_Call) Return(_a0 []domains.PopulatedBlogHistory, _a1 error) *BlogHistoryRepository_ListByBlogID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogHistoryRepository_ListByBlogID_Call) RunAndReturn(run func(context.Context, string) ([]domains.PopulatedBlogHistory, error)) *BlogHistoryRepository_ListByBlogID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewBlogHistoryRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBlogHistoryRepository creates a new instance of BlogHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBlogHistoryRepository(t mockConstructorTestingTNewBlogHistoryRepository) *BlogHistoryRepository {
+	mock := &BlogHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,6 +22,49 @@ func (_m *BlogRepository) EXPECT() *BlogRepository_Expecter {
 	return &BlogRepository_Expecter{mock: &_m.Mock}
 }
 
+// AnonymizeAuthor provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) AnonymizeAuthor(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_AnonymizeAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymizeAuthor'
+type BlogRepository_AnonymizeAuthor_Call struct {
+	*mock.Call
+}
+
+// AnonymizeAuthor is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *BlogRepository_Expecter) AnonymizeAuthor(_a0 interface{}, _a1 interface{}) *BlogRepository_AnonymizeAuthor_Call {
+	return &BlogRepository_AnonymizeAuthor_Call{Call: _e.mock.On("AnonymizeAuthor", _a0, _a1)}
+}
+
+func (_c *BlogRepository_AnonymizeAuthor_Call) Run(run func(_a0 context.Context, _a1 string)) *BlogRepository_AnonymizeAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_AnonymizeAuthor_Call) Return(_a0 error) *BlogRepository_AnonymizeAuthor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_AnonymizeAuthor_Call) RunAndReturn(run func(context.Context, string) error) *BlogRepository_AnonymizeAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Archive provides a mock function with given fields: _a0, _a1
 func (_m *BlogRepository) Archive(_a0 context.Context, _a1 *domains.ArchiveBlogRequest) error {
 	ret := _m.Called(_a0, _a1)
@@ -65,23 +108,109 @@ func (_c *BlogRepository_Archive_Call) RunAndReturn(run func(context.Context, *d
 	return _c
 }
 
-// Count provides a mock function with given fields: _a0, _a1
-func (_m *BlogRepository) Count(_a0 context.Context, _a1 *domains.PaginationOptions) (int64, error) {
+// Assign provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) Assign(_a0 context.Context, _a1 *domains.AssignBlogRequest) error {
 	ret := _m.Called(_a0, _a1)
 
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.AssignBlogRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_Assign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Assign'
+type BlogRepository_Assign_Call struct {
+	*mock.Call
+}
+
+// Assign is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.AssignBlogRequest
+func (_e *BlogRepository_Expecter) Assign(_a0 interface{}, _a1 interface{}) *BlogRepository_Assign_Call {
+	return &BlogRepository_Assign_Call{Call: _e.mock.On("Assign", _a0, _a1)}
+}
+
+func (_c *BlogRepository_Assign_Call) Run(run func(_a0 context.Context, _a1 *domains.AssignBlogRequest)) *BlogRepository_Assign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.AssignBlogRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_Assign_Call) Return(_a0 error) *BlogRepository_Assign_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_Assign_Call) RunAndReturn(run func(context.Context, *domains.AssignBlogRequest) error) *BlogRepository_Assign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AttachLabel provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) AttachLabel(_a0 context.Context, _a1 *domains.AttachLabelRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.AttachLabelRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_AttachLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AttachLabel'
+type BlogRepository_AttachLabel_Call struct {
+	*mock.Call
+}
+
+// AttachLabel is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.AttachLabelRequest
+func (_e *BlogRepository_Expecter) AttachLabel(_a0 interface{}, _a1 interface{}) *BlogRepository_AttachLabel_Call {
+	return &BlogRepository_AttachLabel_Call{Call: _e.mock.On("AttachLabel", _a0, _a1)}
+}
+
+func (_c *BlogRepository_AttachLabel_Call) Run(run func(_a0 context.Context, _a1 *domains.AttachLabelRequest)) *BlogRepository_AttachLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.AttachLabelRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_AttachLabel_Call) Return(_a0 error) *BlogRepository_AttachLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_AttachLabel_Call) RunAndReturn(run func(context.Context, *domains.AttachLabelRequest) error) *BlogRepository_AttachLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function with given fields: _a0, _a1, _a2
+func (_m *BlogRepository) Count(_a0 context.Context, _a1 *domains.BlogFilter, _a2 *domains.PaginationOptions) (int64, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.PaginationOptions) (int64, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.BlogFilter, *domains.PaginationOptions) (int64, error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.PaginationOptions) int64); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.BlogFilter, *domains.PaginationOptions) int64); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domains.PaginationOptions) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.BlogFilter, *domains.PaginationOptions) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -96,14 +225,15 @@ type BlogRepository_Count_Call struct {
 
 // Count is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *domains.PaginationOptions
-func (_e *BlogRepository_Expecter) Count(_a0 interface{}, _a1 interface{}) *BlogRepository_Count_Call {
-	return &BlogRepository_Count_Call{Call: _e.mock.On("Count", _a0, _a1)}
+//   - _a1 *domains.BlogFilter
+//   - _a2 *domains.PaginationOptions
+func (_e *BlogRepository_Expecter) Count(_a0 interface{}, _a1 interface{}, _a2 interface{}) *BlogRepository_Count_Call {
+	return &BlogRepository_Count_Call{Call: _e.mock.On("Count", _a0, _a1, _a2)}
 }
 
-func (_c *BlogRepository_Count_Call) Run(run func(_a0 context.Context, _a1 *domains.PaginationOptions)) *BlogRepository_Count_Call {
+func (_c *BlogRepository_Count_Call) Run(run func(_a0 context.Context, _a1 *domains.BlogFilter, _a2 *domains.PaginationOptions)) *BlogRepository_Count_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domains.PaginationOptions))
+		run(args[0].(context.Context), args[1].(*domains.BlogFilter), args[2].(*domains.PaginationOptions))
 	})
 	return _c
 }
@@ -113,7 +243,7 @@ func (_c *BlogRepository_Count_Call) Return(_a0 int64, _a1 error) *BlogRepositor
 	return _c
 }
 
-func (_c *BlogRepository_Count_Call) RunAndReturn(run func(context.Context, *domains.PaginationOptions) (int64, error)) *BlogRepository_Count_Call {
+func (_c *BlogRepository_Count_Call) RunAndReturn(run func(context.Context, *domains.BlogFilter, *domains.PaginationOptions) (int64, error)) *BlogRepository_Count_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -229,79 +359,197 @@ func (_c *BlogRepository_CreateTx_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
-// GetPopulatedBlogByID provides a mock function with given fields: _a0, _a1
-func (_m *BlogRepository) GetPopulatedBlogByID(_a0 context.Context, _a1 string) (*domains.PopulatedBlog, error) {
+// DeleteByIDs provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) DeleteByIDs(_a0 context.Context, _a1 []string) error {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *domains.PopulatedBlog
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.PopulatedBlog, error)); ok {
-		return rf(_a0, _a1)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.PopulatedBlog); ok {
+
+	return r0
+}
+
+// BlogRepository_DeleteByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByIDs'
+type BlogRepository_DeleteByIDs_Call struct {
+	*mock.Call
+}
+
+// DeleteByIDs is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []string
+func (_e *BlogRepository_Expecter) DeleteByIDs(_a0 interface{}, _a1 interface{}) *BlogRepository_DeleteByIDs_Call {
+	return &BlogRepository_DeleteByIDs_Call{Call: _e.mock.On("DeleteByIDs", _a0, _a1)}
+}
+
+func (_c *BlogRepository_DeleteByIDs_Call) Run(run func(_a0 context.Context, _a1 []string)) *BlogRepository_DeleteByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_DeleteByIDs_Call) Return(_a0 error) *BlogRepository_DeleteByIDs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_DeleteByIDs_Call) RunAndReturn(run func(context.Context, []string) error) *BlogRepository_DeleteByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DetachLabel provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) DetachLabel(_a0 context.Context, _a1 *domains.DetachLabelRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.DetachLabelRequest) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domains.PopulatedBlog)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(_a0, _a1)
+	return r0
+}
+
+// BlogRepository_DetachLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetachLabel'
+type BlogRepository_DetachLabel_Call struct {
+	*mock.Call
+}
+
+// DetachLabel is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.DetachLabelRequest
+func (_e *BlogRepository_Expecter) DetachLabel(_a0 interface{}, _a1 interface{}) *BlogRepository_DetachLabel_Call {
+	return &BlogRepository_DetachLabel_Call{Call: _e.mock.On("DetachLabel", _a0, _a1)}
+}
+
+func (_c *BlogRepository_DetachLabel_Call) Run(run func(_a0 context.Context, _a1 *domains.DetachLabelRequest)) *BlogRepository_DetachLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.DetachLabelRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_DetachLabel_Call) Return(_a0 error) *BlogRepository_DetachLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_DetachLabel_Call) RunAndReturn(run func(context.Context, *domains.DetachLabelRequest) error) *BlogRepository_DetachLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachByAuthorID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *BlogRepository) EachByAuthorID(_a0 context.Context, _a1 string, _a2 func(domains.Blog) error) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(domains.Blog) error) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// BlogRepository_GetPopulatedBlogByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPopulatedBlogByID'
-type BlogRepository_GetPopulatedBlogByID_Call struct {
+// BlogRepository_EachByAuthorID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachByAuthorID'
+type BlogRepository_EachByAuthorID_Call struct {
 	*mock.Call
 }
 
-// GetPopulatedBlogByID is a helper method to define mock.On call
+// EachByAuthorID is a helper method to define mock.On call
 //   - _a0 context.Context
 //   - _a1 string
-func (_e *BlogRepository_Expecter) GetPopulatedBlogByID(_a0 interface{}, _a1 interface{}) *BlogRepository_GetPopulatedBlogByID_Call {
-	return &BlogRepository_GetPopulatedBlogByID_Call{Call: _e.mock.On("GetPopulatedBlogByID", _a0, _a1)}
+//   - _a2 func(domains.Blog) error
+func (_e *BlogRepository_Expecter) EachByAuthorID(_a0 interface{}, _a1 interface{}, _a2 interface{}) *BlogRepository_EachByAuthorID_Call {
+	return &BlogRepository_EachByAuthorID_Call{Call: _e.mock.On("EachByAuthorID", _a0, _a1, _a2)}
 }
 
-func (_c *BlogRepository_GetPopulatedBlogByID_Call) Run(run func(_a0 context.Context, _a1 string)) *BlogRepository_GetPopulatedBlogByID_Call {
+func (_c *BlogRepository_EachByAuthorID_Call) Run(run func(_a0 context.Context, _a1 string, _a2 func(domains.Blog) error)) *BlogRepository_EachByAuthorID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(func(domains.Blog) error))
 	})
 	return _c
 }
 
-func (_c *BlogRepository_GetPopulatedBlogByID_Call) Return(_a0 *domains.PopulatedBlog, _a1 error) *BlogRepository_GetPopulatedBlogByID_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *BlogRepository_EachByAuthorID_Call) Return(_a0 error) *BlogRepository_EachByAuthorID_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *BlogRepository_GetPopulatedBlogByID_Call) RunAndReturn(run func(context.Context, string) (*domains.PopulatedBlog, error)) *BlogRepository_GetPopulatedBlogByID_Call {
+func (_c *BlogRepository_EachByAuthorID_Call) RunAndReturn(run func(context.Context, string, func(domains.Blog) error) error) *BlogRepository_EachByAuthorID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// List provides a mock function with given fields: _a0, _a1
-func (_m *BlogRepository) List(_a0 context.Context, _a1 *domains.PaginationOptions) ([]domains.PopulatedBlog, error) {
+// Edit provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) Edit(_a0 context.Context, _a1 *domains.EditBlogRequest) error {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 []domains.PopulatedBlog
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.EditBlogRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_Edit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Edit'
+type BlogRepository_Edit_Call struct {
+	*mock.Call
+}
+
+// Edit is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.EditBlogRequest
+func (_e *BlogRepository_Expecter) Edit(_a0 interface{}, _a1 interface{}) *BlogRepository_Edit_Call {
+	return &BlogRepository_Edit_Call{Call: _e.mock.On("Edit", _a0, _a1)}
+}
+
+func (_c *BlogRepository_Edit_Call) Run(run func(_a0 context.Context, _a1 *domains.EditBlogRequest)) *BlogRepository_Edit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.EditBlogRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_Edit_Call) Return(_a0 error) *BlogRepository_Edit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_Edit_Call) RunAndReturn(run func(context.Context, *domains.EditBlogRequest) error) *BlogRepository_Edit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArchivedByID provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) GetArchivedByID(_a0 context.Context, _a1 string) (*domains.Blog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.Blog
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.PaginationOptions) ([]domains.PopulatedBlog, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.Blog, error)); ok {
 		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.PaginationOptions) []domains.PopulatedBlog); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.Blog); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domains.PopulatedBlog)
+			r0 = ret.Get(0).(*domains.Blog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domains.PaginationOptions) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -310,74 +558,730 @@ func (_m *BlogRepository) List(_a0 context.Context, _a1 *domains.PaginationOptio
 	return r0, r1
 }
 
-// BlogRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
-type BlogRepository_List_Call struct {
+// BlogRepository_GetArchivedByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchivedByID'
+type BlogRepository_GetArchivedByID_Call struct {
 	*mock.Call
 }
 
-// List is a helper method to define mock.On call
+// GetArchivedByID is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *domains.PaginationOptions
-func (_e *BlogRepository_Expecter) List(_a0 interface{}, _a1 interface{}) *BlogRepository_List_Call {
-	return &BlogRepository_List_Call{Call: _e.mock.On("List", _a0, _a1)}
+//   - _a1 string
+func (_e *BlogRepository_Expecter) GetArchivedByID(_a0 interface{}, _a1 interface{}) *BlogRepository_GetArchivedByID_Call {
+	return &BlogRepository_GetArchivedByID_Call{Call: _e.mock.On("GetArchivedByID", _a0, _a1)}
 }
 
-func (_c *BlogRepository_List_Call) Run(run func(_a0 context.Context, _a1 *domains.PaginationOptions)) *BlogRepository_List_Call {
+func (_c *BlogRepository_GetArchivedByID_Call) Run(run func(_a0 context.Context, _a1 string)) *BlogRepository_GetArchivedByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domains.PaginationOptions))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *BlogRepository_List_Call) Return(_a0 []domains.PopulatedBlog, _a1 error) *BlogRepository_List_Call {
+func (_c *BlogRepository_GetArchivedByID_Call) Return(_a0 *domains.Blog, _a1 error) *BlogRepository_GetArchivedByID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *BlogRepository_List_Call) RunAndReturn(run func(context.Context, *domains.PaginationOptions) ([]domains.PopulatedBlog, error)) *BlogRepository_List_Call {
+func (_c *BlogRepository_GetArchivedByID_Call) RunAndReturn(run func(context.Context, string) (*domains.Blog, error)) *BlogRepository_GetArchivedByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateStatus provides a mock function with given fields: _a0, _a1
-func (_m *BlogRepository) UpdateStatus(_a0 context.Context, _a1 *domains.UpdateBlogStatusRequest) error {
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) GetByID(_a0 context.Context, _a1 string) (*domains.Blog, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateBlogStatusRequest) error); ok {
+	var r0 *domains.Blog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.Blog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.Blog); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.Blog)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// BlogRepository_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
-type BlogRepository_UpdateStatus_Call struct {
+// BlogRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type BlogRepository_GetByID_Call struct {
 	*mock.Call
 }
 
-// UpdateStatus is a helper method to define mock.On call
+// GetByID is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *domains.UpdateBlogStatusRequest
-func (_e *BlogRepository_Expecter) UpdateStatus(_a0 interface{}, _a1 interface{}) *BlogRepository_UpdateStatus_Call {
-	return &BlogRepository_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", _a0, _a1)}
+//   - _a1 string
+func (_e *BlogRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *BlogRepository_GetByID_Call {
+	return &BlogRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
 }
 
-func (_c *BlogRepository_UpdateStatus_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateBlogStatusRequest)) *BlogRepository_UpdateStatus_Call {
+func (_c *BlogRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 string)) *BlogRepository_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domains.UpdateBlogStatusRequest))
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_GetByID_Call) Return(_a0 *domains.Blog, _a1 error) *BlogRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (*domains.Blog, error)) *BlogRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPopulatedBlogByID provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) GetPopulatedBlogByID(_a0 context.Context, _a1 string) (*domains.PopulatedBlog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.PopulatedBlog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.PopulatedBlog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.PopulatedBlog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PopulatedBlog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogRepository_GetPopulatedBlogByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPopulatedBlogByID'
+type BlogRepository_GetPopulatedBlogByID_Call struct {
+	*mock.Call
+}
+
+// GetPopulatedBlogByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *BlogRepository_Expecter) GetPopulatedBlogByID(_a0 interface{}, _a1 interface{}) *BlogRepository_GetPopulatedBlogByID_Call {
+	return &BlogRepository_GetPopulatedBlogByID_Call{Call: _e.mock.On("GetPopulatedBlogByID", _a0, _a1)}
+}
+
+func (_c *BlogRepository_GetPopulatedBlogByID_Call) Run(run func(_a0 context.Context, _a1 string)) *BlogRepository_GetPopulatedBlogByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_GetPopulatedBlogByID_Call) Return(_a0 *domains.PopulatedBlog, _a1 error) *BlogRepository_GetPopulatedBlogByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogRepository_GetPopulatedBlogByID_Call) RunAndReturn(run func(context.Context, string) (*domains.PopulatedBlog, error)) *BlogRepository_GetPopulatedBlogByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *BlogRepository) List(_a0 context.Context, _a1 *domains.BlogFilter, _a2 *domains.BlogSort, _a3 *domains.PaginationOptions) ([]domains.PopulatedBlog, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 []domains.PopulatedBlog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.BlogFilter, *domains.BlogSort, *domains.PaginationOptions) ([]domains.PopulatedBlog, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.BlogFilter, *domains.BlogSort, *domains.PaginationOptions) []domains.PopulatedBlog); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PopulatedBlog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.BlogFilter, *domains.BlogSort, *domains.PaginationOptions) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type BlogRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.BlogFilter
+//   - _a2 *domains.BlogSort
+//   - _a3 *domains.PaginationOptions
+func (_e *BlogRepository_Expecter) List(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *BlogRepository_List_Call {
+	return &BlogRepository_List_Call{Call: _e.mock.On("List", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *BlogRepository_List_Call) Run(run func(_a0 context.Context, _a1 *domains.BlogFilter, _a2 *domains.BlogSort, _a3 *domains.PaginationOptions)) *BlogRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.BlogFilter), args[2].(*domains.BlogSort), args[3].(*domains.PaginationOptions))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_List_Call) Return(_a0 []domains.PopulatedBlog, _a1 error) *BlogRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogRepository_List_Call) RunAndReturn(run func(context.Context, *domains.BlogFilter, *domains.BlogSort, *domains.PaginationOptions) ([]domains.PopulatedBlog, error)) *BlogRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListArchivedIDs provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) ListArchivedIDs(_a0 context.Context, _a1 *domains.PurgeArchivedBlogsRequest) ([]string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.PurgeArchivedBlogsRequest) ([]string, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.PurgeArchivedBlogsRequest) []string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.PurgeArchivedBlogsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogRepository_ListArchivedIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListArchivedIDs'
+type BlogRepository_ListArchivedIDs_Call struct {
+	*mock.Call
+}
+
+// ListArchivedIDs is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.PurgeArchivedBlogsRequest
+func (_e *BlogRepository_Expecter) ListArchivedIDs(_a0 interface{}, _a1 interface{}) *BlogRepository_ListArchivedIDs_Call {
+	return &BlogRepository_ListArchivedIDs_Call{Call: _e.mock.On("ListArchivedIDs", _a0, _a1)}
+}
+
+func (_c *BlogRepository_ListArchivedIDs_Call) Run(run func(_a0 context.Context, _a1 *domains.PurgeArchivedBlogsRequest)) *BlogRepository_ListArchivedIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.PurgeArchivedBlogsRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_ListArchivedIDs_Call) Return(_a0 []string, _a1 error) *BlogRepository_ListArchivedIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogRepository_ListArchivedIDs_Call) RunAndReturn(run func(context.Context, *domains.PurgeArchivedBlogsRequest) ([]string, error)) *BlogRepository_ListArchivedIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListForReminder provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) ListForReminder(_a0 context.Context, _a1 *domains.ListBlogReminderRequest) ([]domains.Blog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []domains.Blog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ListBlogReminderRequest) ([]domains.Blog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ListBlogReminderRequest) []domains.Blog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.Blog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.ListBlogReminderRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogRepository_ListForReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListForReminder'
+type BlogRepository_ListForReminder_Call struct {
+	*mock.Call
+}
+
+// ListForReminder is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.ListBlogReminderRequest
+func (_e *BlogRepository_Expecter) ListForReminder(_a0 interface{}, _a1 interface{}) *BlogRepository_ListForReminder_Call {
+	return &BlogRepository_ListForReminder_Call{Call: _e.mock.On("ListForReminder", _a0, _a1)}
+}
+
+func (_c *BlogRepository_ListForReminder_Call) Run(run func(_a0 context.Context, _a1 *domains.ListBlogReminderRequest)) *BlogRepository_ListForReminder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.ListBlogReminderRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_ListForReminder_Call) Return(_a0 []domains.Blog, _a1 error) *BlogRepository_ListForReminder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogRepository_ListForReminder_Call) RunAndReturn(run func(context.Context, *domains.ListBlogReminderRequest) ([]domains.Blog, error)) *BlogRepository_ListForReminder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkReminded provides a mock function with given fields: _a0, _a1, _a2
+func (_m *BlogRepository) MarkReminded(_a0 context.Context, _a1 string, _a2 string) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogRepository_MarkReminded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkReminded'
+type BlogRepository_MarkReminded_Call struct {
+	*mock.Call
+}
+
+// MarkReminded is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *BlogRepository_Expecter) MarkReminded(_a0 interface{}, _a1 interface{}, _a2 interface{}) *BlogRepository_MarkReminded_Call {
+	return &BlogRepository_MarkReminded_Call{Call: _e.mock.On("MarkReminded", _a0, _a1, _a2)}
+}
+
+func (_c *BlogRepository_MarkReminded_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *BlogRepository_MarkReminded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_MarkReminded_Call) Return(_a0 bool, _a1 error) *BlogRepository_MarkReminded_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogRepository_MarkReminded_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *BlogRepository_MarkReminded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTx provides a mock function with given fields: _a0, _a1, _a2
+func (_m *BlogRepository) PurgeTx(_a0 context.Context, _a1 *domains.PurgeArchivedBlogsRequest, _a2 domains.PurgeArchivedBlogsFn) (int64, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.PurgeArchivedBlogsRequest, domains.PurgeArchivedBlogsFn) (int64, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.PurgeArchivedBlogsRequest, domains.PurgeArchivedBlogsFn) int64); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.PurgeArchivedBlogsRequest, domains.PurgeArchivedBlogsFn) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogRepository_PurgeTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTx'
+type BlogRepository_PurgeTx_Call struct {
+	*mock.Call
+}
+
+// PurgeTx is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.PurgeArchivedBlogsRequest
+//   - _a2 domains.PurgeArchivedBlogsFn
+func (_e *BlogRepository_Expecter) PurgeTx(_a0 interface{}, _a1 interface{}, _a2 interface{}) *BlogRepository_PurgeTx_Call {
+	return &BlogRepository_PurgeTx_Call{Call: _e.mock.On("PurgeTx", _a0, _a1, _a2)}
+}
+
+func (_c *BlogRepository_PurgeTx_Call) Run(run func(_a0 context.Context, _a1 *domains.PurgeArchivedBlogsRequest, _a2 domains.PurgeArchivedBlogsFn)) *BlogRepository_PurgeTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.PurgeArchivedBlogsRequest), args[2].(domains.PurgeArchivedBlogsFn))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_PurgeTx_Call) Return(_a0 int64, _a1 error) *BlogRepository_PurgeTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogRepository_PurgeTx_Call) RunAndReturn(run func(context.Context, *domains.PurgeArchivedBlogsRequest, domains.PurgeArchivedBlogsFn) (int64, error)) *BlogRepository_PurgeTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveLabel provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) RemoveLabel(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_RemoveLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveLabel'
+type BlogRepository_RemoveLabel_Call struct {
+	*mock.Call
+}
+
+// RemoveLabel is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *BlogRepository_Expecter) RemoveLabel(_a0 interface{}, _a1 interface{}) *BlogRepository_RemoveLabel_Call {
+	return &BlogRepository_RemoveLabel_Call{Call: _e.mock.On("RemoveLabel", _a0, _a1)}
+}
+
+func (_c *BlogRepository_RemoveLabel_Call) Run(run func(_a0 context.Context, _a1 string)) *BlogRepository_RemoveLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_RemoveLabel_Call) Return(_a0 error) *BlogRepository_RemoveLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_RemoveLabel_Call) RunAndReturn(run func(context.Context, string) error) *BlogRepository_RemoveLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) Restore(_a0 context.Context, _a1 *domains.RestoreBlogRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.RestoreBlogRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type BlogRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.RestoreBlogRequest
+func (_e *BlogRepository_Expecter) Restore(_a0 interface{}, _a1 interface{}) *BlogRepository_Restore_Call {
+	return &BlogRepository_Restore_Call{Call: _e.mock.On("Restore", _a0, _a1)}
+}
+
+func (_c *BlogRepository_Restore_Call) Run(run func(_a0 context.Context, _a1 *domains.RestoreBlogRequest)) *BlogRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.RestoreBlogRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_Restore_Call) Return(_a0 error) *BlogRepository_Restore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_Restore_Call) RunAndReturn(run func(context.Context, *domains.RestoreBlogRequest) error) *BlogRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDue provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) SetDue(_a0 context.Context, _a1 *domains.SetBlogDueRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.SetBlogDueRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_SetDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDue'
+type BlogRepository_SetDue_Call struct {
+	*mock.Call
+}
+
+// SetDue is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.SetBlogDueRequest
+func (_e *BlogRepository_Expecter) SetDue(_a0 interface{}, _a1 interface{}) *BlogRepository_SetDue_Call {
+	return &BlogRepository_SetDue_Call{Call: _e.mock.On("SetDue", _a0, _a1)}
+}
+
+func (_c *BlogRepository_SetDue_Call) Run(run func(_a0 context.Context, _a1 *domains.SetBlogDueRequest)) *BlogRepository_SetDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.SetBlogDueRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_SetDue_Call) Return(_a0 error) *BlogRepository_SetDue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_SetDue_Call) RunAndReturn(run func(context.Context, *domains.SetBlogDueRequest) error) *BlogRepository_SetDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unassign provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) Unassign(_a0 context.Context, _a1 *domains.UnassignBlogRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UnassignBlogRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_Unassign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unassign'
+type BlogRepository_Unassign_Call struct {
+	*mock.Call
+}
+
+// Unassign is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UnassignBlogRequest
+func (_e *BlogRepository_Expecter) Unassign(_a0 interface{}, _a1 interface{}) *BlogRepository_Unassign_Call {
+	return &BlogRepository_Unassign_Call{Call: _e.mock.On("Unassign", _a0, _a1)}
+}
+
+func (_c *BlogRepository_Unassign_Call) Run(run func(_a0 context.Context, _a1 *domains.UnassignBlogRequest)) *BlogRepository_Unassign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UnassignBlogRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_Unassign_Call) Return(_a0 error) *BlogRepository_Unassign_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_Unassign_Call) RunAndReturn(run func(context.Context, *domains.UnassignBlogRequest) error) *BlogRepository_Unassign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnassignUser provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) UnassignUser(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_UnassignUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignUser'
+type BlogRepository_UnassignUser_Call struct {
+	*mock.Call
+}
+
+// UnassignUser is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *BlogRepository_Expecter) UnassignUser(_a0 interface{}, _a1 interface{}) *BlogRepository_UnassignUser_Call {
+	return &BlogRepository_UnassignUser_Call{Call: _e.mock.On("UnassignUser", _a0, _a1)}
+}
+
+func (_c *BlogRepository_UnassignUser_Call) Run(run func(_a0 context.Context, _a1 string)) *BlogRepository_UnassignUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_UnassignUser_Call) Return(_a0 error) *BlogRepository_UnassignUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogRepository_UnassignUser_Call) RunAndReturn(run func(context.Context, string) error) *BlogRepository_UnassignUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function with given fields: _a0, _a1
+func (_m *BlogRepository) UpdateStatus(_a0 context.Context, _a1 *domains.UpdateBlogStatusRequest) (*domains.Blog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.Blog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateBlogStatusRequest) (*domains.Blog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateBlogStatusRequest) *domains.Blog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.Blog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.UpdateBlogStatusRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogRepository_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type BlogRepository_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UpdateBlogStatusRequest
+func (_e *BlogRepository_Expecter) UpdateStatus(_a0 interface{}, _a1 interface{}) *BlogRepository_UpdateStatus_Call {
+	return &BlogRepository_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", _a0, _a1)}
+}
+
+func (_c *BlogRepository_UpdateStatus_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateBlogStatusRequest)) *BlogRepository_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UpdateBlogStatusRequest))
+	})
+	return _c
+}
+
+func (_c *BlogRepository_UpdateStatus_Call) Return(_a0 *domains.Blog, _a1 error) *BlogRepository_UpdateStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogRepository_UpdateStatus_Call) RunAndReturn(run func(context.Context, *domains.UpdateBlogStatusRequest) (*domains.Blog, error)) *BlogRepository_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatusTx provides a mock function with given fields: _a0, _a1, _a2
+func (_m *BlogRepository) UpdateStatusTx(_a0 context.Context, _a1 *domains.UpdateBlogStatusRequest, _a2 domains.UpdateBlogStatusFn) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateBlogStatusRequest, domains.UpdateBlogStatusFn) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogRepository_UpdateStatusTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatusTx'
+type BlogRepository_UpdateStatusTx_Call struct {
+	*mock.Call
+}
+
+// UpdateStatusTx is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UpdateBlogStatusRequest
+//   - _a2 domains.UpdateBlogStatusFn
+func (_e *BlogRepository_Expecter) UpdateStatusTx(_a0 interface{}, _a1 interface{}, _a2 interface{}) *BlogRepository_UpdateStatusTx_Call {
+	return &BlogRepository_UpdateStatusTx_Call{Call: _e.mock.On("UpdateStatusTx", _a0, _a1, _a2)}
+}
+
+func (_c *BlogRepository_UpdateStatusTx_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateBlogStatusRequest, _a2 domains.UpdateBlogStatusFn)) *BlogRepository_UpdateStatusTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UpdateBlogStatusRequest), args[2].(domains.UpdateBlogStatusFn))
 	})
 	return _c
 }
 
-func (_c *BlogRepository_UpdateStatus_Call) Return(_a0 error) *BlogRepository_UpdateStatus_Call {
+func (_c *BlogRepository_UpdateStatusTx_Call) Return(_a0 error) *BlogRepository_UpdateStatusTx_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *BlogRepository_UpdateStatus_Call) RunAndReturn(run func(context.Context, *domains.UpdateBlogStatusRequest) error) *BlogRepository_UpdateStatus_Call {
+func (_c *BlogRepository_UpdateStatusTx_Call) RunAndReturn(run func(context.Context, *domains.UpdateBlogStatusRequest, domains.UpdateBlogStatusFn) error) *BlogRepository_UpdateStatusTx_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -65,6 +65,116 @@ func (_c *BlogService_ArchiveBlog_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
+// AssignBlog provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) AssignBlog(_a0 context.Context, _a1 *domains.AssignBlogRequest) (*domains.PopulatedBlog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.PopulatedBlog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.AssignBlogRequest) (*domains.PopulatedBlog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.AssignBlogRequest) *domains.PopulatedBlog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PopulatedBlog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.AssignBlogRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_AssignBlog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignBlog'
+type BlogService_AssignBlog_Call struct {
+	*mock.Call
+}
+
+// AssignBlog is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.AssignBlogRequest
+func (_e *BlogService_Expecter) AssignBlog(_a0 interface{}, _a1 interface{}) *BlogService_AssignBlog_Call {
+	return &BlogService_AssignBlog_Call{Call: _e.mock.On("AssignBlog", _a0, _a1)}
+}
+
+func (_c *BlogService_AssignBlog_Call) Run(run func(_a0 context.Context, _a1 *domains.AssignBlogRequest)) *BlogService_AssignBlog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.AssignBlogRequest))
+	})
+	return _c
+}
+
+func (_c *BlogService_AssignBlog_Call) Return(_a0 *domains.PopulatedBlog, _a1 error) *BlogService_AssignBlog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_AssignBlog_Call) RunAndReturn(run func(context.Context, *domains.AssignBlogRequest) (*domains.PopulatedBlog, error)) *BlogService_AssignBlog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AttachLabel provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) AttachLabel(_a0 context.Context, _a1 *domains.AttachLabelRequest) (*domains.PopulatedBlog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.PopulatedBlog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.AttachLabelRequest) (*domains.PopulatedBlog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.AttachLabelRequest) *domains.PopulatedBlog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PopulatedBlog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.AttachLabelRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_AttachLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AttachLabel'
+type BlogService_AttachLabel_Call struct {
+	*mock.Call
+}
+
+// AttachLabel is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.AttachLabelRequest
+func (_e *BlogService_Expecter) AttachLabel(_a0 interface{}, _a1 interface{}) *BlogService_AttachLabel_Call {
+	return &BlogService_AttachLabel_Call{Call: _e.mock.On("AttachLabel", _a0, _a1)}
+}
+
+func (_c *BlogService_AttachLabel_Call) Run(run func(_a0 context.Context, _a1 *domains.AttachLabelRequest)) *BlogService_AttachLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.AttachLabelRequest))
+	})
+	return _c
+}
+
+func (_c *BlogService_AttachLabel_Call) Return(_a0 *domains.PopulatedBlog, _a1 error) *BlogService_AttachLabel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_AttachLabel_Call) RunAndReturn(run func(context.Context, *domains.AttachLabelRequest) (*domains.PopulatedBlog, error)) *BlogService_AttachLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateBlog provides a mock function with given fields: _a0, _a1
 func (_m *BlogService) CreateBlog(_a0 context.Context, _a1 *domains.CreateBlogRequest) (*domains.PopulatedBlog, error) {
 	ret := _m.Called(_a0, _a1)
@@ -175,6 +285,116 @@ func (_c *BlogService_CreateBlogTx_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DetachLabel provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) DetachLabel(_a0 context.Context, _a1 *domains.DetachLabelRequest) (*domains.PopulatedBlog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.PopulatedBlog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.DetachLabelRequest) (*domains.PopulatedBlog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.DetachLabelRequest) *domains.PopulatedBlog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PopulatedBlog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.DetachLabelRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_DetachLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetachLabel'
+type BlogService_DetachLabel_Call struct {
+	*mock.Call
+}
+
+// DetachLabel is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.DetachLabelRequest
+func (_e *BlogService_Expecter) DetachLabel(_a0 interface{}, _a1 interface{}) *BlogService_DetachLabel_Call {
+	return &BlogService_DetachLabel_Call{Call: _e.mock.On("DetachLabel", _a0, _a1)}
+}
+
+func (_c *BlogService_DetachLabel_Call) Run(run func(_a0 context.Context, _a1 *domains.DetachLabelRequest)) *BlogService_DetachLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.DetachLabelRequest))
+	})
+	return _c
+}
+
+func (_c *BlogService_DetachLabel_Call) Return(_a0 *domains.PopulatedBlog, _a1 error) *BlogService_DetachLabel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_DetachLabel_Call) RunAndReturn(run func(context.Context, *domains.DetachLabelRequest) (*domains.PopulatedBlog, error)) *BlogService_DetachLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditBlog provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) EditBlog(_a0 context.Context, _a1 *domains.EditBlogRequest) (*domains.PopulatedBlog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.PopulatedBlog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.EditBlogRequest) (*domains.PopulatedBlog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.EditBlogRequest) *domains.PopulatedBlog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PopulatedBlog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.EditBlogRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_EditBlog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditBlog'
+type BlogService_EditBlog_Call struct {
+	*mock.Call
+}
+
+// EditBlog is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.EditBlogRequest
+func (_e *BlogService_Expecter) EditBlog(_a0 interface{}, _a1 interface{}) *BlogService_EditBlog_Call {
+	return &BlogService_EditBlog_Call{Call: _e.mock.On("EditBlog", _a0, _a1)}
+}
+
+func (_c *BlogService_EditBlog_Call) Run(run func(_a0 context.Context, _a1 *domains.EditBlogRequest)) *BlogService_EditBlog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.EditBlogRequest))
+	})
+	return _c
+}
+
+func (_c *BlogService_EditBlog_Call) Return(_a0 *domains.PopulatedBlog, _a1 error) *BlogService_EditBlog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_EditBlog_Call) RunAndReturn(run func(context.Context, *domains.EditBlogRequest) (*domains.PopulatedBlog, error)) *BlogService_EditBlog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBlogByID provides a mock function with given fields: _a0, _a1
 func (_m *BlogService) GetBlogByID(_a0 context.Context, _a1 string) (*domains.PopulatedBlog, error) {
 	ret := _m.Called(_a0, _a1)
@@ -230,6 +450,50 @@ func (_c *BlogService_GetBlogByID_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// GetWorkflow provides a mock function with given fields: _a0
+func (_m *BlogService) GetWorkflow(_a0 context.Context) *domains.Workflow {
+	ret := _m.Called(_a0)
+
+	var r0 *domains.Workflow
+	if rf, ok := ret.Get(0).(func(context.Context) *domains.Workflow); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.Workflow)
+		}
+	}
+
+	return r0
+}
+
+// BlogService_GetWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflow'
+type BlogService_GetWorkflow_Call struct {
+	*mock.Call
+}
+
+// GetWorkflow is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *BlogService_Expecter) GetWorkflow(_a0 interface{}) *BlogService_GetWorkflow_Call {
+	return &BlogService_GetWorkflow_Call{Call: _e.mock.On("GetWorkflow", _a0)}
+}
+
+func (_c *BlogService_GetWorkflow_Call) Run(run func(_a0 context.Context)) *BlogService_GetWorkflow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BlogService_GetWorkflow_Call) Return(_a0 *domains.Workflow) *BlogService_GetWorkflow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogService_GetWorkflow_Call) RunAndReturn(run func(context.Context) *domains.Workflow) *BlogService_GetWorkflow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListBlog provides a mock function with given fields: _a0, _a1
 func (_m *BlogService) ListBlog(_a0 context.Context, _a1 *domains.ListBlogRequest) (*domains.ListBlogResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -285,6 +549,383 @@ func (_c *BlogService_ListBlog_Call) RunAndReturn(run func(context.Context, *dom
 	return _c
 }
 
+// ListBlogHistory provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) ListBlogHistory(_a0 context.Context, _a1 string) ([]domains.PopulatedBlogHistory, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []domains.PopulatedBlogHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.PopulatedBlogHistory, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.PopulatedBlogHistory); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PopulatedBlogHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_ListBlogHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBlogHistory'
+type BlogService_ListBlogHistory_Call struct {
+	*mock.Call
+}
+
+// ListBlogHistory is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *BlogService_Expecter) ListBlogHistory(_a0 interface{}, _a1 interface{}) *BlogService_ListBlogHistory_Call {
+	return &BlogService_ListBlogHistory_Call{Call: _e.mock.On("ListBlogHistory", _a0, _a1)}
+}
+
+func (_c *BlogService_ListBlogHistory_Call) Run(run func(_a0 context.Context, _a1 string)) *BlogService_ListBlogHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlogService_ListBlogHistory_Call) Return(_a0 []domains.PopulatedBlogHistory, _a1 error) *BlogService_ListBlogHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_ListBlogHistory_Call) RunAndReturn(run func(context.Context, string) ([]domains.PopulatedBlogHistory, error)) *BlogService_ListBlogHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeArchivedBlogs provides a mock function with given fields: _a0
+func (_m *BlogService) PurgeArchivedBlogs(_a0 context.Context) (int64, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_PurgeArchivedBlogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeArchivedBlogs'
+type BlogService_PurgeArchivedBlogs_Call struct {
+	*mock.Call
+}
+
+// PurgeArchivedBlogs is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *BlogService_Expecter) PurgeArchivedBlogs(_a0 interface{}) *BlogService_PurgeArchivedBlogs_Call {
+	return &BlogService_PurgeArchivedBlogs_Call{Call: _e.mock.On("PurgeArchivedBlogs", _a0)}
+}
+
+func (_c *BlogService_PurgeArchivedBlogs_Call) Run(run func(_a0 context.Context)) *BlogService_PurgeArchivedBlogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BlogService_PurgeArchivedBlogs_Call) Return(_a0 int64, _a1 error) *BlogService_PurgeArchivedBlogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_PurgeArchivedBlogs_Call) RunAndReturn(run func(context.Context) (int64, error)) *BlogService_PurgeArchivedBlogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeArchivedBlogsTx provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) PurgeArchivedBlogsTx(_a0 context.Context, _a1 *domains.PurgeArchivedBlogsRequest) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.PurgeArchivedBlogsRequest) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.PurgeArchivedBlogsRequest) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.PurgeArchivedBlogsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_PurgeArchivedBlogsTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeArchivedBlogsTx'
+type BlogService_PurgeArchivedBlogsTx_Call struct {
+	*mock.Call
+}
+
+// PurgeArchivedBlogsTx is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.PurgeArchivedBlogsRequest
+func (_e *BlogService_Expecter) PurgeArchivedBlogsTx(_a0 interface{}, _a1 interface{}) *BlogService_PurgeArchivedBlogsTx_Call {
+	return &BlogService_PurgeArchivedBlogsTx_Call{Call: _e.mock.On("PurgeArchivedBlogsTx", _a0, _a1)}
+}
+
+func (_c *BlogService_PurgeArchivedBlogsTx_Call) Run(run func(_a0 context.Context, _a1 *domains.PurgeArchivedBlogsRequest)) *BlogService_PurgeArchivedBlogsTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.PurgeArchivedBlogsRequest))
+	})
+	return _c
+}
+
+func (_c *BlogService_PurgeArchivedBlogsTx_Call) Return(_a0 int64, _a1 error) *BlogService_PurgeArchivedBlogsTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_PurgeArchivedBlogsTx_Call) RunAndReturn(run func(context.Context, *domains.PurgeArchivedBlogsRequest) (int64, error)) *BlogService_PurgeArchivedBlogsTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreBlog provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) RestoreBlog(_a0 context.Context, _a1 *domains.RestoreBlogRequest) (*domains.PopulatedBlog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.PopulatedBlog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.RestoreBlogRequest) (*domains.PopulatedBlog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.RestoreBlogRequest) *domains.PopulatedBlog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PopulatedBlog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.RestoreBlogRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_RestoreBlog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBlog'
+type BlogService_RestoreBlog_Call struct {
+	*mock.Call
+}
+
+// RestoreBlog is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.RestoreBlogRequest
+func (_e *BlogService_Expecter) RestoreBlog(_a0 interface{}, _a1 interface{}) *BlogService_RestoreBlog_Call {
+	return &BlogService_RestoreBlog_Call{Call: _e.mock.On("RestoreBlog", _a0, _a1)}
+}
+
+func (_c *BlogService_RestoreBlog_Call) Run(run func(_a0 context.Context, _a1 *domains.RestoreBlogRequest)) *BlogService_RestoreBlog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.RestoreBlogRequest))
+	})
+	return _c
+}
+
+func (_c *BlogService_RestoreBlog_Call) Return(_a0 *domains.PopulatedBlog, _a1 error) *BlogService_RestoreBlog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_RestoreBlog_Call) RunAndReturn(run func(context.Context, *domains.RestoreBlogRequest) (*domains.PopulatedBlog, error)) *BlogService_RestoreBlog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendDueReminders provides a mock function with given fields: _a0
+func (_m *BlogService) SendDueReminders(_a0 context.Context) (int64, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_SendDueReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDueReminders'
+type BlogService_SendDueReminders_Call struct {
+	*mock.Call
+}
+
+// SendDueReminders is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *BlogService_Expecter) SendDueReminders(_a0 interface{}) *BlogService_SendDueReminders_Call {
+	return &BlogService_SendDueReminders_Call{Call: _e.mock.On("SendDueReminders", _a0)}
+}
+
+func (_c *BlogService_SendDueReminders_Call) Run(run func(_a0 context.Context)) *BlogService_SendDueReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BlogService_SendDueReminders_Call) Return(_a0 int64, _a1 error) *BlogService_SendDueReminders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_SendDueReminders_Call) RunAndReturn(run func(context.Context) (int64, error)) *BlogService_SendDueReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBlogDue provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) SetBlogDue(_a0 context.Context, _a1 *domains.SetBlogDueRequest) (*domains.PopulatedBlog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.PopulatedBlog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.SetBlogDueRequest) (*domains.PopulatedBlog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.SetBlogDueRequest) *domains.PopulatedBlog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PopulatedBlog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.SetBlogDueRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_SetBlogDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBlogDue'
+type BlogService_SetBlogDue_Call struct {
+	*mock.Call
+}
+
+// SetBlogDue is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.SetBlogDueRequest
+func (_e *BlogService_Expecter) SetBlogDue(_a0 interface{}, _a1 interface{}) *BlogService_SetBlogDue_Call {
+	return &BlogService_SetBlogDue_Call{Call: _e.mock.On("SetBlogDue", _a0, _a1)}
+}
+
+func (_c *BlogService_SetBlogDue_Call) Run(run func(_a0 context.Context, _a1 *domains.SetBlogDueRequest)) *BlogService_SetBlogDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.SetBlogDueRequest))
+	})
+	return _c
+}
+
+func (_c *BlogService_SetBlogDue_Call) Return(_a0 *domains.PopulatedBlog, _a1 error) *BlogService_SetBlogDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_SetBlogDue_Call) RunAndReturn(run func(context.Context, *domains.SetBlogDueRequest) (*domains.PopulatedBlog, error)) *BlogService_SetBlogDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnassignBlog provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) UnassignBlog(_a0 context.Context, _a1 *domains.UnassignBlogRequest) (*domains.PopulatedBlog, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.PopulatedBlog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UnassignBlogRequest) (*domains.PopulatedBlog, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UnassignBlogRequest) *domains.PopulatedBlog); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PopulatedBlog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.UnassignBlogRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlogService_UnassignBlog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignBlog'
+type BlogService_UnassignBlog_Call struct {
+	*mock.Call
+}
+
+// UnassignBlog is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UnassignBlogRequest
+func (_e *BlogService_Expecter) UnassignBlog(_a0 interface{}, _a1 interface{}) *BlogService_UnassignBlog_Call {
+	return &BlogService_UnassignBlog_Call{Call: _e.mock.On("UnassignBlog", _a0, _a1)}
+}
+
+func (_c *BlogService_UnassignBlog_Call) Run(run func(_a0 context.Context, _a1 *domains.UnassignBlogRequest)) *BlogService_UnassignBlog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UnassignBlogRequest))
+	})
+	return _c
+}
+
+func (_c *BlogService_UnassignBlog_Call) Return(_a0 *domains.PopulatedBlog, _a1 error) *BlogService_UnassignBlog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlogService_UnassignBlog_Call) RunAndReturn(run func(context.Context, *domains.UnassignBlogRequest) (*domains.PopulatedBlog, error)) *BlogService_UnassignBlog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateBlogStatus provides a mock function with given fields: _a0, _a1
 func (_m *BlogService) UpdateBlogStatus(_a0 context.Context, _a1 *domains.UpdateBlogStatusRequest) error {
 	ret := _m.Called(_a0, _a1)
@@ -328,6 +969,49 @@ func (_c *BlogService_UpdateBlogStatus_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// UpdateBlogStatusTx provides a mock function with given fields: _a0, _a1
+func (_m *BlogService) UpdateBlogStatusTx(_a0 context.Context, _a1 *domains.UpdateBlogStatusRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateBlogStatusRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlogService_UpdateBlogStatusTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBlogStatusTx'
+type BlogService_UpdateBlogStatusTx_Call struct {
+	*mock.Call
+}
+
+// UpdateBlogStatusTx is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UpdateBlogStatusRequest
+func (_e *BlogService_Expecter) UpdateBlogStatusTx(_a0 interface{}, _a1 interface{}) *BlogService_UpdateBlogStatusTx_Call {
+	return &BlogService_UpdateBlogStatusTx_Call{Call: _e.mock.On("UpdateBlogStatusTx", _a0, _a1)}
+}
+
+func (_c *BlogService_UpdateBlogStatusTx_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateBlogStatusRequest)) *BlogService_UpdateBlogStatusTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UpdateBlogStatusRequest))
+	})
+	return _c
+}
+
+func (_c *BlogService_UpdateBlogStatusTx_Call) Return(_a0 error) *BlogService_UpdateBlogStatusTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlogService_UpdateBlogStatusTx_Call) RunAndReturn(run func(context.Context, *domains.UpdateBlogStatusRequest) error) *BlogService_UpdateBlogStatusTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewBlogService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -22,6 +22,49 @@ func (_m *CommentRepository) EXPECT() *CommentRepository_Expecter {
 	return &CommentRepository_Expecter{mock: &_m.Mock}
 }
 
+// AnonymizeAuthor provides a mock function with given fields: _a0, _a1
+func (_m *CommentRepository) AnonymizeAuthor(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CommentRepository_AnonymizeAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymizeAuthor'
+type CommentRepository_AnonymizeAuthor_Call struct {
+	*mock.Call
+}
+
+// AnonymizeAuthor is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *CommentRepository_Expecter) AnonymizeAuthor(_a0 interface{}, _a1 interface{}) *CommentRepository_AnonymizeAuthor_Call {
+	return &CommentRepository_AnonymizeAuthor_Call{Call: _e.mock.On("AnonymizeAuthor", _a0, _a1)}
+}
+
+func (_c *CommentRepository_AnonymizeAuthor_Call) Run(run func(_a0 context.Context, _a1 string)) *CommentRepository_AnonymizeAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *CommentRepository_AnonymizeAuthor_Call) Return(_a0 error) *CommentRepository_AnonymizeAuthor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommentRepository_AnonymizeAuthor_Call) RunAndReturn(run func(context.Context, string) error) *CommentRepository_AnonymizeAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: _a0, _a1
 func (_m *CommentRepository) Create(_a0 context.Context, _a1 *domains.CreateCommentRequest) (*domains.Comment, error) {
 	ret := _m.Called(_a0, _a1)
@@ -133,6 +176,93 @@ func (_c *CommentRepository_CreateTx_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// DeleteByBlogIDs provides a mock function with given fields: _a0, _a1
+func (_m *CommentRepository) DeleteByBlogIDs(_a0 context.Context, _a1 []string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CommentRepository_DeleteByBlogIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByBlogIDs'
+type CommentRepository_DeleteByBlogIDs_Call struct {
+	*mock.Call
+}
+
+// DeleteByBlogIDs is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []string
+func (_e *CommentRepository_Expecter) DeleteByBlogIDs(_a0 interface{}, _a1 interface{}) *CommentRepository_DeleteByBlogIDs_Call {
+	return &CommentRepository_DeleteByBlogIDs_Call{Call: _e.mock.On("DeleteByBlogIDs", _a0, _a1)}
+}
+
+func (_c *CommentRepository_DeleteByBlogIDs_Call) Run(run func(_a0 context.Context, _a1 []string)) *CommentRepository_DeleteByBlogIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *CommentRepository_DeleteByBlogIDs_Call) Return(_a0 error) *CommentRepository_DeleteByBlogIDs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommentRepository_DeleteByBlogIDs_Call) RunAndReturn(run func(context.Context, []string) error) *CommentRepository_DeleteByBlogIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachByAuthorID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CommentRepository) EachByAuthorID(_a0 context.Context, _a1 string, _a2 func(domains.Comment) error) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(domains.Comment) error) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CommentRepository_EachByAuthorID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachByAuthorID'
+type CommentRepository_EachByAuthorID_Call struct {
+	*mock.Call
+}
+
+// EachByAuthorID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 func(domains.Comment) error
+func (_e *CommentRepository_Expecter) EachByAuthorID(_a0 interface{}, _a1 interface{}, _a2 interface{}) *CommentRepository_EachByAuthorID_Call {
+	return &CommentRepository_EachByAuthorID_Call{Call: _e.mock.On("EachByAuthorID", _a0, _a1, _a2)}
+}
+
+func (_c *CommentRepository_EachByAuthorID_Call) Run(run func(_a0 context.Context, _a1 string, _a2 func(domains.Comment) error)) *CommentRepository_EachByAuthorID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(func(domains.Comment) error))
+	})
+	return _c
+}
+
+func (_c *CommentRepository_EachByAuthorID_Call) Return(_a0 error) *CommentRepository_EachByAuthorID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommentRepository_EachByAuthorID_Call) RunAndReturn(run func(context.Context, string, func(domains.Comment) error) error) *CommentRepository_EachByAuthorID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function with given fields: _a0, _a1
 func (_m *CommentRepository) List(_a0 context.Context, _a1 string) ([]domains.PopulatedComment, error) {
 	ret := _m.Called(_a0, _a1)
@@ -0,0 +1,146 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdentityProvider is an autogenerated mock type for the IdentityProvider type
+type IdentityProvider struct {
+	mock.Mock
+}
+
+type IdentityProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IdentityProvider) EXPECT() *IdentityProvider_Expecter {
+	return &IdentityProvider_Expecter{mock: &_m.Mock}
+}
+
+// AuthCodeURL provides a mock function with given fields: _a0, _a1
+func (_m *IdentityProvider) AuthCodeURL(_a0 context.Context, _a1 *domains.AuthCodeURLRequest) (string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.AuthCodeURLRequest) (string, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.AuthCodeURLRequest) string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.AuthCodeURLRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityProvider_AuthCodeURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthCodeURL'
+type IdentityProvider_AuthCodeURL_Call struct {
+	*mock.Call
+}
+
+// AuthCodeURL is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.AuthCodeURLRequest
+func (_e *IdentityProvider_Expecter) AuthCodeURL(_a0 interface{}, _a1 interface{}) *IdentityProvider_AuthCodeURL_Call {
+	return &IdentityProvider_AuthCodeURL_Call{Call: _e.mock.On("AuthCodeURL", _a0, _a1)}
+}
+
+func (_c *IdentityProvider_AuthCodeURL_Call) Run(run func(_a0 context.Context, _a1 *domains.AuthCodeURLRequest)) *IdentityProvider_AuthCodeURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.AuthCodeURLRequest))
+	})
+	return _c
+}
+
+func (_c *IdentityProvider_AuthCodeURL_Call) Return(_a0 string, _a1 error) *IdentityProvider_AuthCodeURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdentityProvider_AuthCodeURL_Call) RunAndReturn(run func(context.Context, *domains.AuthCodeURLRequest) (string, error)) *IdentityProvider_AuthCodeURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exchange provides a mock function with given fields: _a0, _a1
+func (_m *IdentityProvider) Exchange(_a0 context.Context, _a1 *domains.ExchangeCodeRequest) (*domains.OIDCIdentity, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.OIDCIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ExchangeCodeRequest) (*domains.OIDCIdentity, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ExchangeCodeRequest) *domains.OIDCIdentity); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.OIDCIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.ExchangeCodeRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityProvider_Exchange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exchange'
+type IdentityProvider_Exchange_Call struct {
+	*mock.Call
+}
+
+// Exchange is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.ExchangeCodeRequest
+func (_e *IdentityProvider_Expecter) Exchange(_a0 interface{}, _a1 interface{}) *IdentityProvider_Exchange_Call {
+	return &IdentityProvider_Exchange_Call{Call: _e.mock.On("Exchange", _a0, _a1)}
+}
+
+func (_c *IdentityProvider_Exchange_Call) Run(run func(_a0 context.Context, _a1 *domains.ExchangeCodeRequest)) *IdentityProvider_Exchange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.ExchangeCodeRequest))
+	})
+	return _c
+}
+
+func (_c *IdentityProvider_Exchange_Call) Return(_a0 *domains.OIDCIdentity, _a1 error) *IdentityProvider_Exchange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdentityProvider_Exchange_Call) RunAndReturn(run func(context.Context, *domains.ExchangeCodeRequest) (*domains.OIDCIdentity, error)) *IdentityProvider_Exchange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewIdentityProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIdentityProvider creates a new instance of IdentityProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIdentityProvider(t mockConstructorTestingTNewIdentityProvider) *IdentityProvider {
+	mock := &IdentityProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
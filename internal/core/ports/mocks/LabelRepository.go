@@ -0,0 +1,344 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LabelRepository is an autogenerated mock type for the LabelRepository type
+type LabelRepository struct {
+	mock.Mock
+}
+
+type LabelRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LabelRepository) EXPECT() *LabelRepository_Expecter {
+	return &LabelRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *LabelRepository) Create(_a0 context.Context, _a1 *domains.CreateLabelRequest) (*domains.Label, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateLabelRequest) (*domains.Label, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateLabelRequest) *domains.Label); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.CreateLabelRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LabelRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type LabelRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.CreateLabelRequest
+func (_e *LabelRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *LabelRepository_Create_Call {
+	return &LabelRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *LabelRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *domains.CreateLabelRequest)) *LabelRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.CreateLabelRequest))
+	})
+	return _c
+}
+
+func (_c *LabelRepository_Create_Call) Return(_a0 *domains.Label, _a1 error) *LabelRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LabelRepository_Create_Call) RunAndReturn(run func(context.Context, *domains.CreateLabelRequest) (*domains.Label, error)) *LabelRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *LabelRepository) Delete(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LabelRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type LabelRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *LabelRepository_Expecter) Delete(_a0 interface{}, _a1 interface{}) *LabelRepository_Delete_Call {
+	return &LabelRepository_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *LabelRepository_Delete_Call) Run(run func(_a0 context.Context, _a1 string)) *LabelRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LabelRepository_Delete_Call) Return(_a0 error) *LabelRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LabelRepository_Delete_Call) RunAndReturn(run func(context.Context, string) error) *LabelRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTx provides a mock function with given fields: _a0, _a1, _a2
+func (_m *LabelRepository) DeleteTx(_a0 context.Context, _a1 string, _a2 domains.DeleteLabelFn) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domains.DeleteLabelFn) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LabelRepository_DeleteTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTx'
+type LabelRepository_DeleteTx_Call struct {
+	*mock.Call
+}
+
+// DeleteTx is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 domains.DeleteLabelFn
+func (_e *LabelRepository_Expecter) DeleteTx(_a0 interface{}, _a1 interface{}, _a2 interface{}) *LabelRepository_DeleteTx_Call {
+	return &LabelRepository_DeleteTx_Call{Call: _e.mock.On("DeleteTx", _a0, _a1, _a2)}
+}
+
+func (_c *LabelRepository_DeleteTx_Call) Run(run func(_a0 context.Context, _a1 string, _a2 domains.DeleteLabelFn)) *LabelRepository_DeleteTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domains.DeleteLabelFn))
+	})
+	return _c
+}
+
+func (_c *LabelRepository_DeleteTx_Call) Return(_a0 error) *LabelRepository_DeleteTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LabelRepository_DeleteTx_Call) RunAndReturn(run func(context.Context, string, domains.DeleteLabelFn) error) *LabelRepository_DeleteTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *LabelRepository) GetByID(_a0 context.Context, _a1 string) (*domains.Label, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.Label, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.Label); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LabelRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type LabelRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *LabelRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *LabelRepository_GetByID_Call {
+	return &LabelRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+}
+
+func (_c *LabelRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 string)) *LabelRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LabelRepository_GetByID_Call) Return(_a0 *domains.Label, _a1 error) *LabelRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LabelRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (*domains.Label, error)) *LabelRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: _a0
+func (_m *LabelRepository) List(_a0 context.Context) ([]domains.Label, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []domains.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domains.Label, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domains.Label); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LabelRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type LabelRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *LabelRepository_Expecter) List(_a0 interface{}) *LabelRepository_List_Call {
+	return &LabelRepository_List_Call{Call: _e.mock.On("List", _a0)}
+}
+
+func (_c *LabelRepository_List_Call) Run(run func(_a0 context.Context)) *LabelRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *LabelRepository_List_Call) Return(_a0 []domains.Label, _a1 error) *LabelRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LabelRepository_List_Call) RunAndReturn(run func(context.Context) ([]domains.Label, error)) *LabelRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: _a0, _a1
+func (_m *LabelRepository) Update(_a0 context.Context, _a1 *domains.UpdateLabelRequest) (*domains.Label, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateLabelRequest) (*domains.Label, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateLabelRequest) *domains.Label); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.UpdateLabelRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LabelRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type LabelRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UpdateLabelRequest
+func (_e *LabelRepository_Expecter) Update(_a0 interface{}, _a1 interface{}) *LabelRepository_Update_Call {
+	return &LabelRepository_Update_Call{Call: _e.mock.On("Update", _a0, _a1)}
+}
+
+func (_c *LabelRepository_Update_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateLabelRequest)) *LabelRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UpdateLabelRequest))
+	})
+	return _c
+}
+
+func (_c *LabelRepository_Update_Call) Return(_a0 *domains.Label, _a1 error) *LabelRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LabelRepository_Update_Call) RunAndReturn(run func(context.Context, *domains.UpdateLabelRequest) (*domains.Label, error)) *LabelRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewLabelRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLabelRepository creates a new instance of LabelRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLabelRepository(t mockConstructorTestingTNewLabelRepository) *LabelRepository {
+	mock := &LabelRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
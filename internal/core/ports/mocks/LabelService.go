@@ -0,0 +1,288 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LabelService is an autogenerated mock type for the LabelService type
+type LabelService struct {
+	mock.Mock
+}
+
+type LabelService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LabelService) EXPECT() *LabelService_Expecter {
+	return &LabelService_Expecter{mock: &_m.Mock}
+}
+
+// CreateLabel provides a mock function with given fields: _a0, _a1
+func (_m *LabelService) CreateLabel(_a0 context.Context, _a1 *domains.CreateLabelRequest) (*domains.Label, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateLabelRequest) (*domains.Label, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateLabelRequest) *domains.Label); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.CreateLabelRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LabelService_CreateLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLabel'
+type LabelService_CreateLabel_Call struct {
+	*mock.Call
+}
+
+// CreateLabel is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.CreateLabelRequest
+func (_e *LabelService_Expecter) CreateLabel(_a0 interface{}, _a1 interface{}) *LabelService_CreateLabel_Call {
+	return &LabelService_CreateLabel_Call{Call: _e.mock.On("CreateLabel", _a0, _a1)}
+}
+
+func (_c *LabelService_CreateLabel_Call) Run(run func(_a0 context.Context, _a1 *domains.CreateLabelRequest)) *LabelService_CreateLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.CreateLabelRequest))
+	})
+	return _c
+}
+
+func (_c *LabelService_CreateLabel_Call) Return(_a0 *domains.Label, _a1 error) *LabelService_CreateLabel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LabelService_CreateLabel_Call) RunAndReturn(run func(context.Context, *domains.CreateLabelRequest) (*domains.Label, error)) *LabelService_CreateLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteLabel provides a mock function with given fields: _a0, _a1
+func (_m *LabelService) DeleteLabel(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LabelService_DeleteLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLabel'
+type LabelService_DeleteLabel_Call struct {
+	*mock.Call
+}
+
+// DeleteLabel is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *LabelService_Expecter) DeleteLabel(_a0 interface{}, _a1 interface{}) *LabelService_DeleteLabel_Call {
+	return &LabelService_DeleteLabel_Call{Call: _e.mock.On("DeleteLabel", _a0, _a1)}
+}
+
+func (_c *LabelService_DeleteLabel_Call) Run(run func(_a0 context.Context, _a1 string)) *LabelService_DeleteLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LabelService_DeleteLabel_Call) Return(_a0 error) *LabelService_DeleteLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LabelService_DeleteLabel_Call) RunAndReturn(run func(context.Context, string) error) *LabelService_DeleteLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteLabelTx provides a mock function with given fields: _a0, _a1
+func (_m *LabelService) DeleteLabelTx(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LabelService_DeleteLabelTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLabelTx'
+type LabelService_DeleteLabelTx_Call struct {
+	*mock.Call
+}
+
+// DeleteLabelTx is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *LabelService_Expecter) DeleteLabelTx(_a0 interface{}, _a1 interface{}) *LabelService_DeleteLabelTx_Call {
+	return &LabelService_DeleteLabelTx_Call{Call: _e.mock.On("DeleteLabelTx", _a0, _a1)}
+}
+
+func (_c *LabelService_DeleteLabelTx_Call) Run(run func(_a0 context.Context, _a1 string)) *LabelService_DeleteLabelTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LabelService_DeleteLabelTx_Call) Return(_a0 error) *LabelService_DeleteLabelTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LabelService_DeleteLabelTx_Call) RunAndReturn(run func(context.Context, string) error) *LabelService_DeleteLabelTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLabels provides a mock function with given fields: _a0
+func (_m *LabelService) ListLabels(_a0 context.Context) ([]domains.Label, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []domains.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domains.Label, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domains.Label); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LabelService_ListLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLabels'
+type LabelService_ListLabels_Call struct {
+	*mock.Call
+}
+
+// ListLabels is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *LabelService_Expecter) ListLabels(_a0 interface{}) *LabelService_ListLabels_Call {
+	return &LabelService_ListLabels_Call{Call: _e.mock.On("ListLabels", _a0)}
+}
+
+func (_c *LabelService_ListLabels_Call) Run(run func(_a0 context.Context)) *LabelService_ListLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *LabelService_ListLabels_Call) Return(_a0 []domains.Label, _a1 error) *LabelService_ListLabels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LabelService_ListLabels_Call) RunAndReturn(run func(context.Context) ([]domains.Label, error)) *LabelService_ListLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLabel provides a mock function with given fields: _a0, _a1
+func (_m *LabelService) UpdateLabel(_a0 context.Context, _a1 *domains.UpdateLabelRequest) (*domains.Label, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateLabelRequest) (*domains.Label, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateLabelRequest) *domains.Label); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.UpdateLabelRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LabelService_UpdateLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLabel'
+type LabelService_UpdateLabel_Call struct {
+	*mock.Call
+}
+
+// UpdateLabel is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UpdateLabelRequest
+func (_e *LabelService_Expecter) UpdateLabel(_a0 interface{}, _a1 interface{}) *LabelService_UpdateLabel_Call {
+	return &LabelService_UpdateLabel_Call{Call: _e.mock.On("UpdateLabel", _a0, _a1)}
+}
+
+func (_c *LabelService_UpdateLabel_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateLabelRequest)) *LabelService_UpdateLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UpdateLabelRequest))
+	})
+	return _c
+}
+
+func (_c *LabelService_UpdateLabel_Call) Return(_a0 *domains.Label, _a1 error) *LabelService_UpdateLabel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LabelService_UpdateLabel_Call) RunAndReturn(run func(context.Context, *domains.UpdateLabelRequest) (*domains.Label, error)) *LabelService_UpdateLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewLabelService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLabelService creates a new instance of LabelService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLabelService(t mockConstructorTestingTNewLabelService) *LabelService {
+	mock := &LabelService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
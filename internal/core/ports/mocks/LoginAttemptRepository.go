@@ -0,0 +1,238 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LoginAttemptRepository is an autogenerated mock type for the LoginAttemptRepository type
+type LoginAttemptRepository struct {
+	mock.Mock
+}
+
+type LoginAttemptRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LoginAttemptRepository) EXPECT() *LoginAttemptRepository_Expecter {
+	return &LoginAttemptRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteByKey provides a mock function with given fields: _a0, _a1
+func (_m *LoginAttemptRepository) DeleteByKey(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginAttemptRepository_DeleteByKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByKey'
+type LoginAttemptRepository_DeleteByKey_Call struct {
+	*mock.Call
+}
+
+// DeleteByKey is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *LoginAttemptRepository_Expecter) DeleteByKey(_a0 interface{}, _a1 interface{}) *LoginAttemptRepository_DeleteByKey_Call {
+	return &LoginAttemptRepository_DeleteByKey_Call{Call: _e.mock.On("DeleteByKey", _a0, _a1)}
+}
+
+func (_c *LoginAttemptRepository_DeleteByKey_Call) Run(run func(_a0 context.Context, _a1 string)) *LoginAttemptRepository_DeleteByKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_DeleteByKey_Call) Return(_a0 error) *LoginAttemptRepository_DeleteByKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_DeleteByKey_Call) RunAndReturn(run func(context.Context, string) error) *LoginAttemptRepository_DeleteByKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByKeys provides a mock function with given fields: _a0, _a1
+func (_m *LoginAttemptRepository) GetByKeys(_a0 context.Context, _a1 []string) ([]domains.LoginAttempt, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []domains.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domains.LoginAttempt, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domains.LoginAttempt); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginAttemptRepository_GetByKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByKeys'
+type LoginAttemptRepository_GetByKeys_Call struct {
+	*mock.Call
+}
+
+// GetByKeys is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []string
+func (_e *LoginAttemptRepository_Expecter) GetByKeys(_a0 interface{}, _a1 interface{}) *LoginAttemptRepository_GetByKeys_Call {
+	return &LoginAttemptRepository_GetByKeys_Call{Call: _e.mock.On("GetByKeys", _a0, _a1)}
+}
+
+func (_c *LoginAttemptRepository_GetByKeys_Call) Run(run func(_a0 context.Context, _a1 []string)) *LoginAttemptRepository_GetByKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_GetByKeys_Call) Return(_a0 []domains.LoginAttempt, _a1 error) *LoginAttemptRepository_GetByKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_GetByKeys_Call) RunAndReturn(run func(context.Context, []string) ([]domains.LoginAttempt, error)) *LoginAttemptRepository_GetByKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementFailures provides a mock function with given fields: _a0, _a1, _a2
+func (_m *LoginAttemptRepository) IncrementFailures(_a0 context.Context, _a1 string, _a2 time.Time) (*domains.LoginAttempt, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *domains.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*domains.LoginAttempt, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *domains.LoginAttempt); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginAttemptRepository_IncrementFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementFailures'
+type LoginAttemptRepository_IncrementFailures_Call struct {
+	*mock.Call
+}
+
+// IncrementFailures is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 time.Time
+func (_e *LoginAttemptRepository_Expecter) IncrementFailures(_a0 interface{}, _a1 interface{}, _a2 interface{}) *LoginAttemptRepository_IncrementFailures_Call {
+	return &LoginAttemptRepository_IncrementFailures_Call{Call: _e.mock.On("IncrementFailures", _a0, _a1, _a2)}
+}
+
+func (_c *LoginAttemptRepository_IncrementFailures_Call) Run(run func(_a0 context.Context, _a1 string, _a2 time.Time)) *LoginAttemptRepository_IncrementFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_IncrementFailures_Call) Return(_a0 *domains.LoginAttempt, _a1 error) *LoginAttemptRepository_IncrementFailures_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_IncrementFailures_Call) RunAndReturn(run func(context.Context, string, time.Time) (*domains.LoginAttempt, error)) *LoginAttemptRepository_IncrementFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: _a0, _a1, _a2
+func (_m *LoginAttemptRepository) Lock(_a0 context.Context, _a1 string, _a2 time.Time) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginAttemptRepository_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type LoginAttemptRepository_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 time.Time
+func (_e *LoginAttemptRepository_Expecter) Lock(_a0 interface{}, _a1 interface{}, _a2 interface{}) *LoginAttemptRepository_Lock_Call {
+	return &LoginAttemptRepository_Lock_Call{Call: _e.mock.On("Lock", _a0, _a1, _a2)}
+}
+
+func (_c *LoginAttemptRepository_Lock_Call) Run(run func(_a0 context.Context, _a1 string, _a2 time.Time)) *LoginAttemptRepository_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Lock_Call) Return(_a0 error) *LoginAttemptRepository_Lock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Lock_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *LoginAttemptRepository_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewLoginAttemptRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLoginAttemptRepository(t mockConstructorTestingTNewLoginAttemptRepository) *LoginAttemptRepository {
+	mock := &LoginAttemptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
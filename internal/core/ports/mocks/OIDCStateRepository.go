@@ -0,0 +1,148 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCStateRepository is an autogenerated mock type for the OIDCStateRepository type
+type OIDCStateRepository struct {
+	mock.Mock
+}
+
+type OIDCStateRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCStateRepository) EXPECT() *OIDCStateRepository_Expecter {
+	return &OIDCStateRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: _a0, _a1
+func (_m *OIDCStateRepository) Consume(_a0 context.Context, _a1 string) (*domains.OIDCState, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.OIDCState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.OIDCState, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.OIDCState); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.OIDCState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCStateRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type OIDCStateRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *OIDCStateRepository_Expecter) Consume(_a0 interface{}, _a1 interface{}) *OIDCStateRepository_Consume_Call {
+	return &OIDCStateRepository_Consume_Call{Call: _e.mock.On("Consume", _a0, _a1)}
+}
+
+func (_c *OIDCStateRepository_Consume_Call) Run(run func(_a0 context.Context, _a1 string)) *OIDCStateRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCStateRepository_Consume_Call) Return(_a0 *domains.OIDCState, _a1 error) *OIDCStateRepository_Consume_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCStateRepository_Consume_Call) RunAndReturn(run func(context.Context, string) (*domains.OIDCState, error)) *OIDCStateRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *OIDCStateRepository) Create(_a0 context.Context, _a1 *domains.CreateOIDCStateRequest) (*domains.OIDCState, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.OIDCState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateOIDCStateRequest) (*domains.OIDCState, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateOIDCStateRequest) *domains.OIDCState); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.OIDCState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.CreateOIDCStateRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCStateRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type OIDCStateRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.CreateOIDCStateRequest
+func (_e *OIDCStateRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *OIDCStateRepository_Create_Call {
+	return &OIDCStateRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *OIDCStateRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *domains.CreateOIDCStateRequest)) *OIDCStateRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.CreateOIDCStateRequest))
+	})
+	return _c
+}
+
+func (_c *OIDCStateRepository_Create_Call) Return(_a0 *domains.OIDCState, _a1 error) *OIDCStateRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCStateRepository_Create_Call) RunAndReturn(run func(context.Context, *domains.CreateOIDCStateRequest) (*domains.OIDCState, error)) *OIDCStateRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewOIDCStateRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOIDCStateRepository creates a new instance of OIDCStateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOIDCStateRepository(t mockConstructorTestingTNewOIDCStateRepository) *OIDCStateRepository {
+	mock := &OIDCStateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,289 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefreshTokenRepository is an autogenerated mock type for the RefreshTokenRepository type
+type RefreshTokenRepository struct {
+	mock.Mock
+}
+
+type RefreshTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RefreshTokenRepository) EXPECT() *RefreshTokenRepository_Expecter {
+	return &RefreshTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *RefreshTokenRepository) Create(_a0 context.Context, _a1 *domains.CreateRefreshTokenRequest) (*domains.RefreshToken, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateRefreshTokenRequest) (*domains.RefreshToken, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateRefreshTokenRequest) *domains.RefreshToken); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.CreateRefreshTokenRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type RefreshTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.CreateRefreshTokenRequest
+func (_e *RefreshTokenRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *RefreshTokenRepository_Create_Call {
+	return &RefreshTokenRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *RefreshTokenRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *domains.CreateRefreshTokenRequest)) *RefreshTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.CreateRefreshTokenRequest))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepository_Create_Call) Return(_a0 *domains.RefreshToken, _a1 error) *RefreshTokenRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RefreshTokenRepository_Create_Call) RunAndReturn(run func(context.Context, *domains.CreateRefreshTokenRequest) (*domains.RefreshToken, error)) *RefreshTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTokenHash provides a mock function with given fields: _a0, _a1
+func (_m *RefreshTokenRepository) GetByTokenHash(_a0 context.Context, _a1 string) (*domains.RefreshToken, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.RefreshToken, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.RefreshToken); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokenRepository_GetByTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTokenHash'
+type RefreshTokenRepository_GetByTokenHash_Call struct {
+	*mock.Call
+}
+
+// GetByTokenHash is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *RefreshTokenRepository_Expecter) GetByTokenHash(_a0 interface{}, _a1 interface{}) *RefreshTokenRepository_GetByTokenHash_Call {
+	return &RefreshTokenRepository_GetByTokenHash_Call{Call: _e.mock.On("GetByTokenHash", _a0, _a1)}
+}
+
+func (_c *RefreshTokenRepository_GetByTokenHash_Call) Run(run func(_a0 context.Context, _a1 string)) *RefreshTokenRepository_GetByTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepository_GetByTokenHash_Call) Return(_a0 *domains.RefreshToken, _a1 error) *RefreshTokenRepository_GetByTokenHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RefreshTokenRepository_GetByTokenHash_Call) RunAndReturn(run func(context.Context, string) (*domains.RefreshToken, error)) *RefreshTokenRepository_GetByTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkUsed provides a mock function with given fields: _a0, _a1
+func (_m *RefreshTokenRepository) MarkUsed(_a0 context.Context, _a1 primitive.ObjectID) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (bool, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokenRepository_MarkUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkUsed'
+type RefreshTokenRepository_MarkUsed_Call struct {
+	*mock.Call
+}
+
+// MarkUsed is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+func (_e *RefreshTokenRepository_Expecter) MarkUsed(_a0 interface{}, _a1 interface{}) *RefreshTokenRepository_MarkUsed_Call {
+	return &RefreshTokenRepository_MarkUsed_Call{Call: _e.mock.On("MarkUsed", _a0, _a1)}
+}
+
+func (_c *RefreshTokenRepository_MarkUsed_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID)) *RefreshTokenRepository_MarkUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepository_MarkUsed_Call) Return(_a0 bool, _a1 error) *RefreshTokenRepository_MarkUsed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RefreshTokenRepository_MarkUsed_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) (bool, error)) *RefreshTokenRepository_MarkUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeByUserID provides a mock function with given fields: _a0, _a1
+func (_m *RefreshTokenRepository) RevokeByUserID(_a0 context.Context, _a1 primitive.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokenRepository_RevokeByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeByUserID'
+type RefreshTokenRepository_RevokeByUserID_Call struct {
+	*mock.Call
+}
+
+// RevokeByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+func (_e *RefreshTokenRepository_Expecter) RevokeByUserID(_a0 interface{}, _a1 interface{}) *RefreshTokenRepository_RevokeByUserID_Call {
+	return &RefreshTokenRepository_RevokeByUserID_Call{Call: _e.mock.On("RevokeByUserID", _a0, _a1)}
+}
+
+func (_c *RefreshTokenRepository_RevokeByUserID_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID)) *RefreshTokenRepository_RevokeByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepository_RevokeByUserID_Call) Return(_a0 error) *RefreshTokenRepository_RevokeByUserID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokenRepository_RevokeByUserID_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *RefreshTokenRepository_RevokeByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeFamily provides a mock function with given fields: _a0, _a1
+func (_m *RefreshTokenRepository) RevokeFamily(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokenRepository_RevokeFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeFamily'
+type RefreshTokenRepository_RevokeFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeFamily is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *RefreshTokenRepository_Expecter) RevokeFamily(_a0 interface{}, _a1 interface{}) *RefreshTokenRepository_RevokeFamily_Call {
+	return &RefreshTokenRepository_RevokeFamily_Call{Call: _e.mock.On("RevokeFamily", _a0, _a1)}
+}
+
+func (_c *RefreshTokenRepository_RevokeFamily_Call) Run(run func(_a0 context.Context, _a1 string)) *RefreshTokenRepository_RevokeFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepository_RevokeFamily_Call) Return(_a0 error) *RefreshTokenRepository_RevokeFamily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokenRepository_RevokeFamily_Call) RunAndReturn(run func(context.Context, string) error) *RefreshTokenRepository_RevokeFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRefreshTokenRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRefreshTokenRepository creates a new instance of RefreshTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRefreshTokenRepository(t mockConstructorTestingTNewRefreshTokenRepository) *RefreshTokenRepository {
+	mock := &RefreshTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,134 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RevokedTokenRepository is an autogenerated mock type for the RevokedTokenRepository type
+type RevokedTokenRepository struct {
+	mock.Mock
+}
+
+type RevokedTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RevokedTokenRepository) EXPECT() *RevokedTokenRepository_Expecter {
+	return &RevokedTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *RevokedTokenRepository) Create(_a0 context.Context, _a1 *domains.RevokeTokenRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.RevokeTokenRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokedTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type RevokedTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.RevokeTokenRequest
+func (_e *RevokedTokenRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *RevokedTokenRepository_Create_Call {
+	return &RevokedTokenRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *RevokedTokenRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *domains.RevokeTokenRequest)) *RevokedTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.RevokeTokenRequest))
+	})
+	return _c
+}
+
+func (_c *RevokedTokenRepository_Create_Call) Return(_a0 error) *RevokedTokenRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevokedTokenRepository_Create_Call) RunAndReturn(run func(context.Context, *domains.RevokeTokenRequest) error) *RevokedTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsRevoked provides a mock function with given fields: _a0, _a1
+func (_m *RevokedTokenRepository) IsRevoked(_a0 context.Context, _a1 string) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokedTokenRepository_IsRevoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRevoked'
+type RevokedTokenRepository_IsRevoked_Call struct {
+	*mock.Call
+}
+
+// IsRevoked is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *RevokedTokenRepository_Expecter) IsRevoked(_a0 interface{}, _a1 interface{}) *RevokedTokenRepository_IsRevoked_Call {
+	return &RevokedTokenRepository_IsRevoked_Call{Call: _e.mock.On("IsRevoked", _a0, _a1)}
+}
+
+func (_c *RevokedTokenRepository_IsRevoked_Call) Run(run func(_a0 context.Context, _a1 string)) *RevokedTokenRepository_IsRevoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RevokedTokenRepository_IsRevoked_Call) Return(_a0 bool, _a1 error) *RevokedTokenRepository_IsRevoked_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevokedTokenRepository_IsRevoked_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *RevokedTokenRepository_IsRevoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRevokedTokenRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRevokedTokenRepository creates a new instance of RevokedTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRevokedTokenRepository(t mockConstructorTestingTNewRevokedTokenRepository) *RevokedTokenRepository {
+	mock := &RevokedTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
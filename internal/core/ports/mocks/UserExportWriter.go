@@ -0,0 +1,163 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserExportWriter is an autogenerated mock type for the UserExportWriter type
+type UserExportWriter struct {
+	mock.Mock
+}
+
+type UserExportWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserExportWriter) EXPECT() *UserExportWriter_Expecter {
+	return &UserExportWriter_Expecter{mock: &_m.Mock}
+}
+
+// WriteBlog provides a mock function with given fields: _a0
+func (_m *UserExportWriter) WriteBlog(_a0 domains.Blog) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domains.Blog) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserExportWriter_WriteBlog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteBlog'
+type UserExportWriter_WriteBlog_Call struct {
+	*mock.Call
+}
+
+// WriteBlog is a helper method to define mock.On call
+//   - _a0 domains.Blog
+func (_e *UserExportWriter_Expecter) WriteBlog(_a0 interface{}) *UserExportWriter_WriteBlog_Call {
+	return &UserExportWriter_WriteBlog_Call{Call: _e.mock.On("WriteBlog", _a0)}
+}
+
+func (_c *UserExportWriter_WriteBlog_Call) Run(run func(_a0 domains.Blog)) *UserExportWriter_WriteBlog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domains.Blog))
+	})
+	return _c
+}
+
+func (_c *UserExportWriter_WriteBlog_Call) Return(_a0 error) *UserExportWriter_WriteBlog_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserExportWriter_WriteBlog_Call) RunAndReturn(run func(domains.Blog) error) *UserExportWriter_WriteBlog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteComment provides a mock function with given fields: _a0
+func (_m *UserExportWriter) WriteComment(_a0 domains.Comment) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domains.Comment) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserExportWriter_WriteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteComment'
+type UserExportWriter_WriteComment_Call struct {
+	*mock.Call
+}
+
+// WriteComment is a helper method to define mock.On call
+//   - _a0 domains.Comment
+func (_e *UserExportWriter_Expecter) WriteComment(_a0 interface{}) *UserExportWriter_WriteComment_Call {
+	return &UserExportWriter_WriteComment_Call{Call: _e.mock.On("WriteComment", _a0)}
+}
+
+func (_c *UserExportWriter_WriteComment_Call) Run(run func(_a0 domains.Comment)) *UserExportWriter_WriteComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domains.Comment))
+	})
+	return _c
+}
+
+func (_c *UserExportWriter_WriteComment_Call) Return(_a0 error) *UserExportWriter_WriteComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserExportWriter_WriteComment_Call) RunAndReturn(run func(domains.Comment) error) *UserExportWriter_WriteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteProfile provides a mock function with given fields: _a0
+func (_m *UserExportWriter) WriteProfile(_a0 domains.User) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domains.User) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserExportWriter_WriteProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteProfile'
+type UserExportWriter_WriteProfile_Call struct {
+	*mock.Call
+}
+
+// WriteProfile is a helper method to define mock.On call
+//   - _a0 domains.User
+func (_e *UserExportWriter_Expecter) WriteProfile(_a0 interface{}) *UserExportWriter_WriteProfile_Call {
+	return &UserExportWriter_WriteProfile_Call{Call: _e.mock.On("WriteProfile", _a0)}
+}
+
+func (_c *UserExportWriter_WriteProfile_Call) Run(run func(_a0 domains.User)) *UserExportWriter_WriteProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domains.User))
+	})
+	return _c
+}
+
+func (_c *UserExportWriter_WriteProfile_Call) Return(_a0 error) *UserExportWriter_WriteProfile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserExportWriter_WriteProfile_Call) RunAndReturn(run func(domains.User) error) *UserExportWriter_WriteProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewUserExportWriter interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserExportWriter creates a new instance of UserExportWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserExportWriter(t mockConstructorTestingTNewUserExportWriter) *UserExportWriter {
+	mock := &UserExportWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -8,6 +8,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -24,6 +26,60 @@ func (_m *UserRepository) EXPECT() *UserRepository_Expecter {
 	return &UserRepository_Expecter{mock: &_m.Mock}
 }
 
+// CountSearch provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) CountSearch(_a0 context.Context, _a1 string, _a2 *domains.PaginationOptions) (int64, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domains.PaginationOptions) (int64, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domains.PaginationOptions) int64); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domains.PaginationOptions) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_CountSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountSearch'
+type UserRepository_CountSearch_Call struct {
+	*mock.Call
+}
+
+// CountSearch is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 *domains.PaginationOptions
+func (_e *UserRepository_Expecter) CountSearch(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_CountSearch_Call {
+	return &UserRepository_CountSearch_Call{Call: _e.mock.On("CountSearch", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_CountSearch_Call) Run(run func(_a0 context.Context, _a1 string, _a2 *domains.PaginationOptions)) *UserRepository_CountSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*domains.PaginationOptions))
+	})
+	return _c
+}
+
+func (_c *UserRepository_CountSearch_Call) Return(_a0 int64, _a1 error) *UserRepository_CountSearch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_CountSearch_Call) RunAndReturn(run func(context.Context, string, *domains.PaginationOptions) (int64, error)) *UserRepository_CountSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: _a0, _a1
 func (_m *UserRepository) Create(_a0 context.Context, _a1 *domains.CreateUserRequest) (*domains.User, error) {
 	ret := _m.Called(_a0, _a1)
@@ -79,6 +135,192 @@ func (_c *UserRepository_Create_Call) RunAndReturn(run func(context.Context, *do
 	return _c
 }
 
+// Deactivate provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) Deactivate(_a0 context.Context, _a1 primitive.ObjectID, _a2 time.Time) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_Deactivate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deactivate'
+type UserRepository_Deactivate_Call struct {
+	*mock.Call
+}
+
+// Deactivate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 time.Time
+func (_e *UserRepository_Expecter) Deactivate(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_Deactivate_Call {
+	return &UserRepository_Deactivate_Call{Call: _e.mock.On("Deactivate", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_Deactivate_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 time.Time)) *UserRepository_Deactivate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UserRepository_Deactivate_Call) Return(_a0 error) *UserRepository_Deactivate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_Deactivate_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, time.Time) error) *UserRepository_Deactivate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) Delete(_a0 context.Context, _a1 primitive.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type UserRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+func (_e *UserRepository_Expecter) Delete(_a0 interface{}, _a1 interface{}) *UserRepository_Delete_Call {
+	return &UserRepository_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *UserRepository_Delete_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID)) *UserRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *UserRepository_Delete_Call) Return(_a0 error) *UserRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_Delete_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *UserRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableMFA provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) EnableMFA(_a0 context.Context, _a1 primitive.ObjectID, _a2 []string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, []string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_EnableMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableMFA'
+type UserRepository_EnableMFA_Call struct {
+	*mock.Call
+}
+
+// EnableMFA is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 []string
+func (_e *UserRepository_Expecter) EnableMFA(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_EnableMFA_Call {
+	return &UserRepository_EnableMFA_Call{Call: _e.mock.On("EnableMFA", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_EnableMFA_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 []string)) *UserRepository_EnableMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_EnableMFA_Call) Return(_a0 error) *UserRepository_EnableMFA_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_EnableMFA_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, []string) error) *UserRepository_EnableMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByEmail provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) GetByEmail(_a0 context.Context, _a1 string) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_GetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEmail'
+type UserRepository_GetByEmail_Call struct {
+	*mock.Call
+}
+
+// GetByEmail is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *UserRepository_Expecter) GetByEmail(_a0 interface{}, _a1 interface{}) *UserRepository_GetByEmail_Call {
+	return &UserRepository_GetByEmail_Call{Call: _e.mock.On("GetByEmail", _a0, _a1)}
+}
+
+func (_c *UserRepository_GetByEmail_Call) Run(run func(_a0 context.Context, _a1 string)) *UserRepository_GetByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetByEmail_Call) Return(_a0 *domains.User, _a1 error) *UserRepository_GetByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_GetByEmail_Call) RunAndReturn(run func(context.Context, string) (*domains.User, error)) *UserRepository_GetByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function with given fields: _a0, _a1
 func (_m *UserRepository) GetByID(_a0 context.Context, _a1 primitive.ObjectID) (*domains.User, error) {
 	ret := _m.Called(_a0, _a1)
@@ -134,6 +376,62 @@ func (_c *UserRepository_GetByID_Call) RunAndReturn(run func(context.Context, pr
 	return _c
 }
 
+// GetByOIDCSubject provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) GetByOIDCSubject(_a0 context.Context, _a1 string, _a2 string) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domains.User, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domains.User); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_GetByOIDCSubject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByOIDCSubject'
+type UserRepository_GetByOIDCSubject_Call struct {
+	*mock.Call
+}
+
+// GetByOIDCSubject is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *UserRepository_Expecter) GetByOIDCSubject(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_GetByOIDCSubject_Call {
+	return &UserRepository_GetByOIDCSubject_Call{Call: _e.mock.On("GetByOIDCSubject", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_GetByOIDCSubject_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *UserRepository_GetByOIDCSubject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetByOIDCSubject_Call) Return(_a0 *domains.User, _a1 error) *UserRepository_GetByOIDCSubject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_GetByOIDCSubject_Call) RunAndReturn(run func(context.Context, string, string) (*domains.User, error)) *UserRepository_GetByOIDCSubject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByUsername provides a mock function with given fields: _a0, _a1
 func (_m *UserRepository) GetByUsername(_a0 context.Context, _a1 string) (*domains.User, error) {
 	ret := _m.Called(_a0, _a1)
@@ -189,57 +487,708 @@ func (_c *UserRepository_GetByUsername_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
-// Update provides a mock function with given fields: _a0, _a1
-func (_m *UserRepository) Update(_a0 context.Context, _a1 *domains.UpdateUserRequest) (*domains.User, error) {
+// IncrementTokenVersion provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) IncrementTokenVersion(_a0 context.Context, _a1 primitive.ObjectID) error {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *domains.User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateUserRequest) (*domains.User, error)); ok {
-		return rf(_a0, _a1)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateUserRequest) *domains.User); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domains.User)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domains.UpdateUserRequest) error); ok {
-		r1 = rf(_a0, _a1)
+	return r0
+}
+
+// UserRepository_IncrementTokenVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementTokenVersion'
+type UserRepository_IncrementTokenVersion_Call struct {
+	*mock.Call
+}
+
+// IncrementTokenVersion is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+func (_e *UserRepository_Expecter) IncrementTokenVersion(_a0 interface{}, _a1 interface{}) *UserRepository_IncrementTokenVersion_Call {
+	return &UserRepository_IncrementTokenVersion_Call{Call: _e.mock.On("IncrementTokenVersion", _a0, _a1)}
+}
+
+func (_c *UserRepository_IncrementTokenVersion_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID)) *UserRepository_IncrementTokenVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *UserRepository_IncrementTokenVersion_Call) Return(_a0 error) *UserRepository_IncrementTokenVersion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_IncrementTokenVersion_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *UserRepository_IncrementTokenVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LinkOIDC provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *UserRepository) LinkOIDC(_a0 context.Context, _a1 primitive.ObjectID, _a2 string, _a3 string) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// UserRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type UserRepository_Update_Call struct {
+// UserRepository_LinkOIDC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkOIDC'
+type UserRepository_LinkOIDC_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// LinkOIDC is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *domains.UpdateUserRequest
-func (_e *UserRepository_Expecter) Update(_a0 interface{}, _a1 interface{}) *UserRepository_Update_Call {
-	return &UserRepository_Update_Call{Call: _e.mock.On("Update", _a0, _a1)}
+//   - _a1 primitive.ObjectID
+//   - _a2 string
+//   - _a3 string
+func (_e *UserRepository_Expecter) LinkOIDC(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *UserRepository_LinkOIDC_Call {
+	return &UserRepository_LinkOIDC_Call{Call: _e.mock.On("LinkOIDC", _a0, _a1, _a2, _a3)}
 }
 
-func (_c *UserRepository_Update_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateUserRequest)) *UserRepository_Update_Call {
+func (_c *UserRepository_LinkOIDC_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 string, _a3 string)) *UserRepository_LinkOIDC_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domains.UpdateUserRequest))
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *UserRepository_Update_Call) Return(_a0 *domains.User, _a1 error) *UserRepository_Update_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *UserRepository_LinkOIDC_Call) Return(_a0 error) *UserRepository_LinkOIDC_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *UserRepository_Update_Call) RunAndReturn(run func(context.Context, *domains.UpdateUserRequest) (*domains.User, error)) *UserRepository_Update_Call {
+func (_c *UserRepository_LinkOIDC_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string, string) error) *UserRepository_LinkOIDC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) Search(_a0 context.Context, _a1 string, _a2 *domains.PaginationOptions) ([]domains.User, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domains.PaginationOptions) ([]domains.User, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domains.PaginationOptions) []domains.User); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domains.PaginationOptions) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type UserRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 *domains.PaginationOptions
+func (_e *UserRepository_Expecter) Search(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_Search_Call {
+	return &UserRepository_Search_Call{Call: _e.mock.On("Search", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_Search_Call) Run(run func(_a0 context.Context, _a1 string, _a2 *domains.PaginationOptions)) *UserRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*domains.PaginationOptions))
+	})
+	return _c
+}
+
+func (_c *UserRepository_Search_Call) Return(_a0 []domains.User, _a1 error) *UserRepository_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_Search_Call) RunAndReturn(run func(context.Context, string, *domains.PaginationOptions) ([]domains.User, error)) *UserRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) Update(_a0 context.Context, _a1 *domains.UpdateUserRequest) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateUserRequest) (*domains.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateUserRequest) *domains.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.UpdateUserRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type UserRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UpdateUserRequest
+func (_e *UserRepository_Expecter) Update(_a0 interface{}, _a1 interface{}) *UserRepository_Update_Call {
+	return &UserRepository_Update_Call{Call: _e.mock.On("Update", _a0, _a1)}
+}
+
+func (_c *UserRepository_Update_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateUserRequest)) *UserRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UpdateUserRequest))
+	})
+	return _c
+}
+
+func (_c *UserRepository_Update_Call) Return(_a0 *domains.User, _a1 error) *UserRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_Update_Call) RunAndReturn(run func(context.Context, *domains.UpdateUserRequest) (*domains.User, error)) *UserRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEmail provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) UpdateEmail(_a0 context.Context, _a1 primitive.ObjectID, _a2 string) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) (*domains.User, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) *domains.User); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_UpdateEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEmail'
+type UserRepository_UpdateEmail_Call struct {
+	*mock.Call
+}
+
+// UpdateEmail is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 string
+func (_e *UserRepository_Expecter) UpdateEmail(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_UpdateEmail_Call {
+	return &UserRepository_UpdateEmail_Call{Call: _e.mock.On("UpdateEmail", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_UpdateEmail_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 string)) *UserRepository_UpdateEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateEmail_Call) Return(_a0 *domains.User, _a1 error) *UserRepository_UpdateEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_UpdateEmail_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string) (*domains.User, error)) *UserRepository_UpdateEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLastActiveAt provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) UpdateLastActiveAt(_a0 context.Context, _a1 primitive.ObjectID, _a2 time.Time) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_UpdateLastActiveAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLastActiveAt'
+type UserRepository_UpdateLastActiveAt_Call struct {
+	*mock.Call
+}
+
+// UpdateLastActiveAt is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 time.Time
+func (_e *UserRepository_Expecter) UpdateLastActiveAt(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_UpdateLastActiveAt_Call {
+	return &UserRepository_UpdateLastActiveAt_Call{Call: _e.mock.On("UpdateLastActiveAt", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_UpdateLastActiveAt_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 time.Time)) *UserRepository_UpdateLastActiveAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateLastActiveAt_Call) Return(_a0 error) *UserRepository_UpdateLastActiveAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_UpdateLastActiveAt_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, time.Time) error) *UserRepository_UpdateLastActiveAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePassword provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) UpdatePassword(_a0 context.Context, _a1 primitive.ObjectID, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type UserRepository_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 string
+func (_e *UserRepository_Expecter) UpdatePassword(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_UpdatePassword_Call {
+	return &UserRepository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_UpdatePassword_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 string)) *UserRepository_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdatePassword_Call) Return(_a0 error) *UserRepository_UpdatePassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_UpdatePassword_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string) error) *UserRepository_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfileImage provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *UserRepository) UpdateProfileImage(_a0 context.Context, _a1 primitive.ObjectID, _a2 string, _a3 map[string]string) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string, map[string]string) (*domains.User, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string, map[string]string) *domains.User); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, string, map[string]string) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_UpdateProfileImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfileImage'
+type UserRepository_UpdateProfileImage_Call struct {
+	*mock.Call
+}
+
+// UpdateProfileImage is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 string
+//   - _a3 map[string]string
+func (_e *UserRepository_Expecter) UpdateProfileImage(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *UserRepository_UpdateProfileImage_Call {
+	return &UserRepository_UpdateProfileImage_Call{Call: _e.mock.On("UpdateProfileImage", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *UserRepository_UpdateProfileImage_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 string, _a3 map[string]string)) *UserRepository_UpdateProfileImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string), args[3].(map[string]string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateProfileImage_Call) Return(_a0 *domains.User, _a1 error) *UserRepository_UpdateProfileImage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_UpdateProfileImage_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string, map[string]string) (*domains.User, error)) *UserRepository_UpdateProfileImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) UpdateRole(_a0 context.Context, _a1 *domains.UpdateUserRoleRequest) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateUserRoleRequest) (*domains.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateUserRoleRequest) *domains.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.UpdateUserRoleRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type UserRepository_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UpdateUserRoleRequest
+func (_e *UserRepository_Expecter) UpdateRole(_a0 interface{}, _a1 interface{}) *UserRepository_UpdateRole_Call {
+	return &UserRepository_UpdateRole_Call{Call: _e.mock.On("UpdateRole", _a0, _a1)}
+}
+
+func (_c *UserRepository_UpdateRole_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateUserRoleRequest)) *UserRepository_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UpdateUserRoleRequest))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateRole_Call) Return(_a0 *domains.User, _a1 error) *UserRepository_UpdateRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_UpdateRole_Call) RunAndReturn(run func(context.Context, *domains.UpdateUserRoleRequest) (*domains.User, error)) *UserRepository_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTOTPSecret provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) UpdateTOTPSecret(_a0 context.Context, _a1 primitive.ObjectID, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_UpdateTOTPSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTOTPSecret'
+type UserRepository_UpdateTOTPSecret_Call struct {
+	*mock.Call
+}
+
+// UpdateTOTPSecret is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 string
+func (_e *UserRepository_Expecter) UpdateTOTPSecret(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_UpdateTOTPSecret_Call {
+	return &UserRepository_UpdateTOTPSecret_Call{Call: _e.mock.On("UpdateTOTPSecret", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_UpdateTOTPSecret_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 string)) *UserRepository_UpdateTOTPSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateTOTPSecret_Call) Return(_a0 error) *UserRepository_UpdateTOTPSecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_UpdateTOTPSecret_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string) error) *UserRepository_UpdateTOTPSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUsername provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) UpdateUsername(_a0 context.Context, _a1 primitive.ObjectID, _a2 string) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) (*domains.User, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) *domains.User); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_UpdateUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUsername'
+type UserRepository_UpdateUsername_Call struct {
+	*mock.Call
+}
+
+// UpdateUsername is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 string
+func (_e *UserRepository_Expecter) UpdateUsername(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_UpdateUsername_Call {
+	return &UserRepository_UpdateUsername_Call{Call: _e.mock.On("UpdateUsername", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_UpdateUsername_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 string)) *UserRepository_UpdateUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateUsername_Call) Return(_a0 *domains.User, _a1 error) *UserRepository_UpdateUsername_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_UpdateUsername_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string) (*domains.User, error)) *UserRepository_UpdateUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVerified provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) UpdateVerified(_a0 context.Context, _a1 primitive.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_UpdateVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVerified'
+type UserRepository_UpdateVerified_Call struct {
+	*mock.Call
+}
+
+// UpdateVerified is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+func (_e *UserRepository_Expecter) UpdateVerified(_a0 interface{}, _a1 interface{}) *UserRepository_UpdateVerified_Call {
+	return &UserRepository_UpdateVerified_Call{Call: _e.mock.On("UpdateVerified", _a0, _a1)}
+}
+
+func (_c *UserRepository_UpdateVerified_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID)) *UserRepository_UpdateVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateVerified_Call) Return(_a0 error) *UserRepository_UpdateVerified_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_UpdateVerified_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *UserRepository_UpdateVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseRecoveryCode provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) UseRecoveryCode(_a0 context.Context, _a1 primitive.ObjectID, _a2 string) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) (bool, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type UserRepository_UseRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// UseRecoveryCode is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 string
+func (_e *UserRepository_Expecter) UseRecoveryCode(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_UseRecoveryCode_Call {
+	return &UserRepository_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_UseRecoveryCode_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 string)) *UserRepository_UseRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UseRecoveryCode_Call) Return(_a0 bool, _a1 error) *UserRepository_UseRecoveryCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_UseRecoveryCode_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string) (bool, error)) *UserRepository_UseRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseTOTPCounter provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) UseTOTPCounter(_a0 context.Context, _a1 primitive.ObjectID, _a2 int64) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, int64) (bool, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, int64) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, int64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_UseTOTPCounter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseTOTPCounter'
+type UserRepository_UseTOTPCounter_Call struct {
+	*mock.Call
+}
+
+// UseTOTPCounter is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 int64
+func (_e *UserRepository_Expecter) UseTOTPCounter(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_UseTOTPCounter_Call {
+	return &UserRepository_UseTOTPCounter_Call{Call: _e.mock.On("UseTOTPCounter", _a0, _a1, _a2)}
+}
+
+func (_c *UserRepository_UseTOTPCounter_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 int64)) *UserRepository_UseTOTPCounter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UseTOTPCounter_Call) Return(_a0 bool, _a1 error) *UserRepository_UseTOTPCounter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_UseTOTPCounter_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, int64) (bool, error)) *UserRepository_UseTOTPCounter_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 	domains "robinhood/internal/core/domains"
+	ports "robinhood/internal/core/ports"
+	auth "robinhood/pkg/auth"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -22,24 +24,1332 @@ func (_m *UserService) EXPECT() *UserService_Expecter {
 	return &UserService_Expecter{mock: &_m.Mock}
 }
 
+// Authenticate provides a mock function with given fields: _a0, _a1
+func (_m *UserService) Authenticate(_a0 context.Context, _a1 string) (*auth.JWTCustomClaims, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *auth.JWTCustomClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.JWTCustomClaims, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.JWTCustomClaims); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.JWTCustomClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type UserService_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *UserService_Expecter) Authenticate(_a0 interface{}, _a1 interface{}) *UserService_Authenticate_Call {
+	return &UserService_Authenticate_Call{Call: _e.mock.On("Authenticate", _a0, _a1)}
+}
+
+func (_c *UserService_Authenticate_Call) Run(run func(_a0 context.Context, _a1 string)) *UserService_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_Authenticate_Call) Return(_a0 *auth.JWTCustomClaims, _a1 error) *UserService_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_Authenticate_Call) RunAndReturn(run func(context.Context, string) (*auth.JWTCustomClaims, error)) *UserService_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AuthenticateAPIKey provides a mock function with given fields: _a0, _a1
+func (_m *UserService) AuthenticateAPIKey(_a0 context.Context, _a1 string) (*auth.JWTCustomClaims, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *auth.JWTCustomClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.JWTCustomClaims, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.JWTCustomClaims); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.JWTCustomClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_AuthenticateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticateAPIKey'
+type UserService_AuthenticateAPIKey_Call struct {
+	*mock.Call
+}
+
+// AuthenticateAPIKey is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *UserService_Expecter) AuthenticateAPIKey(_a0 interface{}, _a1 interface{}) *UserService_AuthenticateAPIKey_Call {
+	return &UserService_AuthenticateAPIKey_Call{Call: _e.mock.On("AuthenticateAPIKey", _a0, _a1)}
+}
+
+func (_c *UserService_AuthenticateAPIKey_Call) Run(run func(_a0 context.Context, _a1 string)) *UserService_AuthenticateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_AuthenticateAPIKey_Call) Return(_a0 *auth.JWTCustomClaims, _a1 error) *UserService_AuthenticateAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_AuthenticateAPIKey_Call) RunAndReturn(run func(context.Context, string) (*auth.JWTCustomClaims, error)) *UserService_AuthenticateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeEmail provides a mock function with given fields: _a0, _a1
+func (_m *UserService) ChangeEmail(_a0 context.Context, _a1 *domains.ChangeEmailRequest) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ChangeEmailRequest) (*domains.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ChangeEmailRequest) *domains.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.ChangeEmailRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_ChangeEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeEmail'
+type UserService_ChangeEmail_Call struct {
+	*mock.Call
+}
+
+// ChangeEmail is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.ChangeEmailRequest
+func (_e *UserService_Expecter) ChangeEmail(_a0 interface{}, _a1 interface{}) *UserService_ChangeEmail_Call {
+	return &UserService_ChangeEmail_Call{Call: _e.mock.On("ChangeEmail", _a0, _a1)}
+}
+
+func (_c *UserService_ChangeEmail_Call) Run(run func(_a0 context.Context, _a1 *domains.ChangeEmailRequest)) *UserService_ChangeEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.ChangeEmailRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_ChangeEmail_Call) Return(_a0 *domains.User, _a1 error) *UserService_ChangeEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_ChangeEmail_Call) RunAndReturn(run func(context.Context, *domains.ChangeEmailRequest) (*domains.User, error)) *UserService_ChangeEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangePassword provides a mock function with given fields: _a0, _a1
+func (_m *UserService) ChangePassword(_a0 context.Context, _a1 *domains.ChangePasswordRequest) (*domains.LoginResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ChangePasswordRequest) (*domains.LoginResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ChangePasswordRequest) *domains.LoginResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.ChangePasswordRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type UserService_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.ChangePasswordRequest
+func (_e *UserService_Expecter) ChangePassword(_a0 interface{}, _a1 interface{}) *UserService_ChangePassword_Call {
+	return &UserService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", _a0, _a1)}
+}
+
+func (_c *UserService_ChangePassword_Call) Run(run func(_a0 context.Context, _a1 *domains.ChangePasswordRequest)) *UserService_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.ChangePasswordRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_ChangePassword_Call) Return(_a0 *domains.LoginResponse, _a1 error) *UserService_ChangePassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_ChangePassword_Call) RunAndReturn(run func(context.Context, *domains.ChangePasswordRequest) (*domains.LoginResponse, error)) *UserService_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeUsername provides a mock function with given fields: _a0, _a1
+func (_m *UserService) ChangeUsername(_a0 context.Context, _a1 *domains.ChangeUsernameRequest) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ChangeUsernameRequest) (*domains.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ChangeUsernameRequest) *domains.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.ChangeUsernameRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_ChangeUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeUsername'
+type UserService_ChangeUsername_Call struct {
+	*mock.Call
+}
+
+// ChangeUsername is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.ChangeUsernameRequest
+func (_e *UserService_Expecter) ChangeUsername(_a0 interface{}, _a1 interface{}) *UserService_ChangeUsername_Call {
+	return &UserService_ChangeUsername_Call{Call: _e.mock.On("ChangeUsername", _a0, _a1)}
+}
+
+func (_c *UserService_ChangeUsername_Call) Run(run func(_a0 context.Context, _a1 *domains.ChangeUsernameRequest)) *UserService_ChangeUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.ChangeUsernameRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_ChangeUsername_Call) Return(_a0 *domains.User, _a1 error) *UserService_ChangeUsername_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_ChangeUsername_Call) RunAndReturn(run func(context.Context, *domains.ChangeUsernameRequest) (*domains.User, error)) *UserService_ChangeUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmTOTP provides a mock function with given fields: _a0, _a1
+func (_m *UserService) ConfirmTOTP(_a0 context.Context, _a1 *domains.ConfirmTOTPRequest) (*domains.ConfirmTOTPResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.ConfirmTOTPResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ConfirmTOTPRequest) (*domains.ConfirmTOTPResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ConfirmTOTPRequest) *domains.ConfirmTOTPResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.ConfirmTOTPResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.ConfirmTOTPRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_ConfirmTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmTOTP'
+type UserService_ConfirmTOTP_Call struct {
+	*mock.Call
+}
+
+// ConfirmTOTP is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.ConfirmTOTPRequest
+func (_e *UserService_Expecter) ConfirmTOTP(_a0 interface{}, _a1 interface{}) *UserService_ConfirmTOTP_Call {
+	return &UserService_ConfirmTOTP_Call{Call: _e.mock.On("ConfirmTOTP", _a0, _a1)}
+}
+
+func (_c *UserService_ConfirmTOTP_Call) Run(run func(_a0 context.Context, _a1 *domains.ConfirmTOTPRequest)) *UserService_ConfirmTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.ConfirmTOTPRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_ConfirmTOTP_Call) Return(_a0 *domains.ConfirmTOTPResponse, _a1 error) *UserService_ConfirmTOTP_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_ConfirmTOTP_Call) RunAndReturn(run func(context.Context, *domains.ConfirmTOTPRequest) (*domains.ConfirmTOTPResponse, error)) *UserService_ConfirmTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAPIKey provides a mock function with given fields: _a0, _a1
+func (_m *UserService) CreateAPIKey(_a0 context.Context, _a1 *domains.CreateAPIKeyRequest) (*domains.CreateAPIKeyResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.CreateAPIKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateAPIKeyRequest) (*domains.CreateAPIKeyResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateAPIKeyRequest) *domains.CreateAPIKeyResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.CreateAPIKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.CreateAPIKeyRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_CreateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIKey'
+type UserService_CreateAPIKey_Call struct {
+	*mock.Call
+}
+
+// CreateAPIKey is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.CreateAPIKeyRequest
+func (_e *UserService_Expecter) CreateAPIKey(_a0 interface{}, _a1 interface{}) *UserService_CreateAPIKey_Call {
+	return &UserService_CreateAPIKey_Call{Call: _e.mock.On("CreateAPIKey", _a0, _a1)}
+}
+
+func (_c *UserService_CreateAPIKey_Call) Run(run func(_a0 context.Context, _a1 *domains.CreateAPIKeyRequest)) *UserService_CreateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.CreateAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_CreateAPIKey_Call) Return(_a0 *domains.CreateAPIKeyResponse, _a1 error) *UserService_CreateAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_CreateAPIKey_Call) RunAndReturn(run func(context.Context, *domains.CreateAPIKeyRequest) (*domains.CreateAPIKeyResponse, error)) *UserService_CreateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Deactivate provides a mock function with given fields: _a0, _a1
+func (_m *UserService) Deactivate(_a0 context.Context, _a1 *domains.DeactivateUserRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.DeactivateUserRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_Deactivate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deactivate'
+type UserService_Deactivate_Call struct {
+	*mock.Call
+}
+
+// Deactivate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.DeactivateUserRequest
+func (_e *UserService_Expecter) Deactivate(_a0 interface{}, _a1 interface{}) *UserService_Deactivate_Call {
+	return &UserService_Deactivate_Call{Call: _e.mock.On("Deactivate", _a0, _a1)}
+}
+
+func (_c *UserService_Deactivate_Call) Run(run func(_a0 context.Context, _a1 *domains.DeactivateUserRequest)) *UserService_Deactivate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.DeactivateUserRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_Deactivate_Call) Return(_a0 error) *UserService_Deactivate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_Deactivate_Call) RunAndReturn(run func(context.Context, *domains.DeactivateUserRequest) error) *UserService_Deactivate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *UserService) Delete(_a0 context.Context, _a1 *domains.DeleteUserRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.DeleteUserRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type UserService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.DeleteUserRequest
+func (_e *UserService_Expecter) Delete(_a0 interface{}, _a1 interface{}) *UserService_Delete_Call {
+	return &UserService_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *UserService_Delete_Call) Run(run func(_a0 context.Context, _a1 *domains.DeleteUserRequest)) *UserService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.DeleteUserRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_Delete_Call) Return(_a0 error) *UserService_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_Delete_Call) RunAndReturn(run func(context.Context, *domains.DeleteUserRequest) error) *UserService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnrollTOTP provides a mock function with given fields: _a0, _a1
+func (_m *UserService) EnrollTOTP(_a0 context.Context, _a1 string) (*domains.EnrollTOTPResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.EnrollTOTPResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.EnrollTOTPResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.EnrollTOTPResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.EnrollTOTPResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_EnrollTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollTOTP'
+type UserService_EnrollTOTP_Call struct {
+	*mock.Call
+}
+
+// EnrollTOTP is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *UserService_Expecter) EnrollTOTP(_a0 interface{}, _a1 interface{}) *UserService_EnrollTOTP_Call {
+	return &UserService_EnrollTOTP_Call{Call: _e.mock.On("EnrollTOTP", _a0, _a1)}
+}
+
+func (_c *UserService_EnrollTOTP_Call) Run(run func(_a0 context.Context, _a1 string)) *UserService_EnrollTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_EnrollTOTP_Call) Return(_a0 *domains.EnrollTOTPResponse, _a1 error) *UserService_EnrollTOTP_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_EnrollTOTP_Call) RunAndReturn(run func(context.Context, string) (*domains.EnrollTOTPResponse, error)) *UserService_EnrollTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserService) Export(_a0 context.Context, _a1 string, _a2 ports.UserExportWriter) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ports.UserExportWriter) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type UserService_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 ports.UserExportWriter
+func (_e *UserService_Expecter) Export(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserService_Export_Call {
+	return &UserService_Export_Call{Call: _e.mock.On("Export", _a0, _a1, _a2)}
+}
+
+func (_c *UserService_Export_Call) Run(run func(_a0 context.Context, _a1 string, _a2 ports.UserExportWriter)) *UserService_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(ports.UserExportWriter))
+	})
+	return _c
+}
+
+func (_c *UserService_Export_Call) Return(_a0 error) *UserService_Export_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_Export_Call) RunAndReturn(run func(context.Context, string, ports.UserExportWriter) error) *UserService_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForgotPassword provides a mock function with given fields: _a0, _a1
+func (_m *UserService) ForgotPassword(_a0 context.Context, _a1 *domains.ForgotPasswordRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ForgotPasswordRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_ForgotPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgotPassword'
+type UserService_ForgotPassword_Call struct {
+	*mock.Call
+}
+
+// ForgotPassword is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.ForgotPasswordRequest
+func (_e *UserService_Expecter) ForgotPassword(_a0 interface{}, _a1 interface{}) *UserService_ForgotPassword_Call {
+	return &UserService_ForgotPassword_Call{Call: _e.mock.On("ForgotPassword", _a0, _a1)}
+}
+
+func (_c *UserService_ForgotPassword_Call) Run(run func(_a0 context.Context, _a1 *domains.ForgotPasswordRequest)) *UserService_ForgotPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.ForgotPasswordRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_ForgotPassword_Call) Return(_a0 error) *UserService_ForgotPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_ForgotPassword_Call) RunAndReturn(run func(context.Context, *domains.ForgotPasswordRequest) error) *UserService_ForgotPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function with given fields: _a0, _a1
+func (_m *UserService) GetUserByID(_a0 context.Context, _a1 string) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type UserService_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *UserService_Expecter) GetUserByID(_a0 interface{}, _a1 interface{}) *UserService_GetUserByID_Call {
+	return &UserService_GetUserByID_Call{Call: _e.mock.On("GetUserByID", _a0, _a1)}
+}
+
+func (_c *UserService_GetUserByID_Call) Run(run func(_a0 context.Context, _a1 string)) *UserService_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_GetUserByID_Call) Return(_a0 *domains.User, _a1 error) *UserService_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_GetUserByID_Call) RunAndReturn(run func(context.Context, string) (*domains.User, error)) *UserService_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPIKeys provides a mock function with given fields: _a0, _a1
+func (_m *UserService) ListAPIKeys(_a0 context.Context, _a1 string) ([]domains.APIKey, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []domains.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.APIKey, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.APIKey); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_ListAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPIKeys'
+type UserService_ListAPIKeys_Call struct {
+	*mock.Call
+}
+
+// ListAPIKeys is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *UserService_Expecter) ListAPIKeys(_a0 interface{}, _a1 interface{}) *UserService_ListAPIKeys_Call {
+	return &UserService_ListAPIKeys_Call{Call: _e.mock.On("ListAPIKeys", _a0, _a1)}
+}
+
+func (_c *UserService_ListAPIKeys_Call) Run(run func(_a0 context.Context, _a1 string)) *UserService_ListAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_ListAPIKeys_Call) Return(_a0 []domains.APIKey, _a1 error) *UserService_ListAPIKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_ListAPIKeys_Call) RunAndReturn(run func(context.Context, string) ([]domains.APIKey, error)) *UserService_ListAPIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function with given fields: _a0, _a1
 func (_m *UserService) Login(_a0 context.Context, _a1 *domains.LoginRequest) (*domains.LoginResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *domains.LoginResponse
+	var r0 *domains.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.LoginRequest) (*domains.LoginResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.LoginRequest) *domains.LoginResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.LoginRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type UserService_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.LoginRequest
+func (_e *UserService_Expecter) Login(_a0 interface{}, _a1 interface{}) *UserService_Login_Call {
+	return &UserService_Login_Call{Call: _e.mock.On("Login", _a0, _a1)}
+}
+
+func (_c *UserService_Login_Call) Run(run func(_a0 context.Context, _a1 *domains.LoginRequest)) *UserService_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.LoginRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_Login_Call) Return(_a0 *domains.LoginResponse, _a1 error) *UserService_Login_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_Login_Call) RunAndReturn(run func(context.Context, *domains.LoginRequest) (*domains.LoginResponse, error)) *UserService_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginMFA provides a mock function with given fields: _a0, _a1
+func (_m *UserService) LoginMFA(_a0 context.Context, _a1 *domains.LoginMFARequest) (*domains.LoginResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.LoginMFARequest) (*domains.LoginResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.LoginMFARequest) *domains.LoginResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.LoginMFARequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_LoginMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginMFA'
+type UserService_LoginMFA_Call struct {
+	*mock.Call
+}
+
+// LoginMFA is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.LoginMFARequest
+func (_e *UserService_Expecter) LoginMFA(_a0 interface{}, _a1 interface{}) *UserService_LoginMFA_Call {
+	return &UserService_LoginMFA_Call{Call: _e.mock.On("LoginMFA", _a0, _a1)}
+}
+
+func (_c *UserService_LoginMFA_Call) Run(run func(_a0 context.Context, _a1 *domains.LoginMFARequest)) *UserService_LoginMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.LoginMFARequest))
+	})
+	return _c
+}
+
+func (_c *UserService_LoginMFA_Call) Return(_a0 *domains.LoginResponse, _a1 error) *UserService_LoginMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_LoginMFA_Call) RunAndReturn(run func(context.Context, *domains.LoginMFARequest) (*domains.LoginResponse, error)) *UserService_LoginMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function with given fields: _a0, _a1
+func (_m *UserService) Logout(_a0 context.Context, _a1 *domains.LogoutRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.LogoutRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type UserService_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.LogoutRequest
+func (_e *UserService_Expecter) Logout(_a0 interface{}, _a1 interface{}) *UserService_Logout_Call {
+	return &UserService_Logout_Call{Call: _e.mock.On("Logout", _a0, _a1)}
+}
+
+func (_c *UserService_Logout_Call) Run(run func(_a0 context.Context, _a1 *domains.LogoutRequest)) *UserService_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.LogoutRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_Logout_Call) Return(_a0 error) *UserService_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_Logout_Call) RunAndReturn(run func(context.Context, *domains.LogoutRequest) error) *UserService_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogoutAll provides a mock function with given fields: _a0, _a1
+func (_m *UserService) LogoutAll(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_LogoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutAll'
+type UserService_LogoutAll_Call struct {
+	*mock.Call
+}
+
+// LogoutAll is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *UserService_Expecter) LogoutAll(_a0 interface{}, _a1 interface{}) *UserService_LogoutAll_Call {
+	return &UserService_LogoutAll_Call{Call: _e.mock.On("LogoutAll", _a0, _a1)}
+}
+
+func (_c *UserService_LogoutAll_Call) Run(run func(_a0 context.Context, _a1 string)) *UserService_LogoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_LogoutAll_Call) Return(_a0 error) *UserService_LogoutAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_LogoutAll_Call) RunAndReturn(run func(context.Context, string) error) *UserService_LogoutAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OIDCCallback provides a mock function with given fields: _a0, _a1
+func (_m *UserService) OIDCCallback(_a0 context.Context, _a1 *domains.OIDCCallbackRequest) (*domains.LoginResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.OIDCCallbackRequest) (*domains.LoginResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.OIDCCallbackRequest) *domains.LoginResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.OIDCCallbackRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_OIDCCallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OIDCCallback'
+type UserService_OIDCCallback_Call struct {
+	*mock.Call
+}
+
+// OIDCCallback is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.OIDCCallbackRequest
+func (_e *UserService_Expecter) OIDCCallback(_a0 interface{}, _a1 interface{}) *UserService_OIDCCallback_Call {
+	return &UserService_OIDCCallback_Call{Call: _e.mock.On("OIDCCallback", _a0, _a1)}
+}
+
+func (_c *UserService_OIDCCallback_Call) Run(run func(_a0 context.Context, _a1 *domains.OIDCCallbackRequest)) *UserService_OIDCCallback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.OIDCCallbackRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_OIDCCallback_Call) Return(_a0 *domains.LoginResponse, _a1 error) *UserService_OIDCCallback_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_OIDCCallback_Call) RunAndReturn(run func(context.Context, *domains.OIDCCallbackRequest) (*domains.LoginResponse, error)) *UserService_OIDCCallback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OIDCLogin provides a mock function with given fields: _a0
+func (_m *UserService) OIDCLogin(_a0 context.Context) (*domains.OIDCLoginResponse, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *domains.OIDCLoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domains.OIDCLoginResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domains.OIDCLoginResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.OIDCLoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_OIDCLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OIDCLogin'
+type UserService_OIDCLogin_Call struct {
+	*mock.Call
+}
+
+// OIDCLogin is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *UserService_Expecter) OIDCLogin(_a0 interface{}) *UserService_OIDCLogin_Call {
+	return &UserService_OIDCLogin_Call{Call: _e.mock.On("OIDCLogin", _a0)}
+}
+
+func (_c *UserService_OIDCLogin_Call) Run(run func(_a0 context.Context)) *UserService_OIDCLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UserService_OIDCLogin_Call) Return(_a0 *domains.OIDCLoginResponse, _a1 error) *UserService_OIDCLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_OIDCLogin_Call) RunAndReturn(run func(context.Context) (*domains.OIDCLoginResponse, error)) *UserService_OIDCLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProfileImageURL provides a mock function with given fields: _a0, _a1
+func (_m *UserService) ProfileImageURL(_a0 context.Context, _a1 *domains.ProfileImageURLRequest) (string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ProfileImageURLRequest) (string, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ProfileImageURLRequest) string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.ProfileImageURLRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_ProfileImageURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProfileImageURL'
+type UserService_ProfileImageURL_Call struct {
+	*mock.Call
+}
+
+// ProfileImageURL is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.ProfileImageURLRequest
+func (_e *UserService_Expecter) ProfileImageURL(_a0 interface{}, _a1 interface{}) *UserService_ProfileImageURL_Call {
+	return &UserService_ProfileImageURL_Call{Call: _e.mock.On("ProfileImageURL", _a0, _a1)}
+}
+
+func (_c *UserService_ProfileImageURL_Call) Run(run func(_a0 context.Context, _a1 *domains.ProfileImageURLRequest)) *UserService_ProfileImageURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.ProfileImageURLRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_ProfileImageURL_Call) Return(_a0 string, _a1 error) *UserService_ProfileImageURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_ProfileImageURL_Call) RunAndReturn(run func(context.Context, *domains.ProfileImageURLRequest) (string, error)) *UserService_ProfileImageURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshToken provides a mock function with given fields: _a0, _a1
+func (_m *UserService) RefreshToken(_a0 context.Context, _a1 *domains.RefreshTokenRequest) (*domains.LoginResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.RefreshTokenRequest) (*domains.LoginResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.RefreshTokenRequest) *domains.LoginResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.RefreshTokenRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
+type UserService_RefreshToken_Call struct {
+	*mock.Call
+}
+
+// RefreshToken is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.RefreshTokenRequest
+func (_e *UserService_Expecter) RefreshToken(_a0 interface{}, _a1 interface{}) *UserService_RefreshToken_Call {
+	return &UserService_RefreshToken_Call{Call: _e.mock.On("RefreshToken", _a0, _a1)}
+}
+
+func (_c *UserService_RefreshToken_Call) Run(run func(_a0 context.Context, _a1 *domains.RefreshTokenRequest)) *UserService_RefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.RefreshTokenRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_RefreshToken_Call) Return(_a0 *domains.LoginResponse, _a1 error) *UserService_RefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_RefreshToken_Call) RunAndReturn(run func(context.Context, *domains.RefreshTokenRequest) (*domains.LoginResponse, error)) *UserService_RefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: _a0, _a1
+func (_m *UserService) Register(_a0 context.Context, _a1 *domains.RegisterRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.RegisterRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type UserService_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.RegisterRequest
+func (_e *UserService_Expecter) Register(_a0 interface{}, _a1 interface{}) *UserService_Register_Call {
+	return &UserService_Register_Call{Call: _e.mock.On("Register", _a0, _a1)}
+}
+
+func (_c *UserService_Register_Call) Run(run func(_a0 context.Context, _a1 *domains.RegisterRequest)) *UserService_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.RegisterRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_Register_Call) Return(_a0 error) *UserService_Register_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_Register_Call) RunAndReturn(run func(context.Context, *domains.RegisterRequest) error) *UserService_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendVerification provides a mock function with given fields: _a0, _a1
+func (_m *UserService) ResendVerification(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_ResendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerification'
+type UserService_ResendVerification_Call struct {
+	*mock.Call
+}
+
+// ResendVerification is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *UserService_Expecter) ResendVerification(_a0 interface{}, _a1 interface{}) *UserService_ResendVerification_Call {
+	return &UserService_ResendVerification_Call{Call: _e.mock.On("ResendVerification", _a0, _a1)}
+}
+
+func (_c *UserService_ResendVerification_Call) Run(run func(_a0 context.Context, _a1 string)) *UserService_ResendVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_ResendVerification_Call) Return(_a0 error) *UserService_ResendVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_ResendVerification_Call) RunAndReturn(run func(context.Context, string) error) *UserService_ResendVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: _a0, _a1
+func (_m *UserService) ResetPassword(_a0 context.Context, _a1 *domains.ResetPasswordRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ResetPasswordRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type UserService_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.ResetPasswordRequest
+func (_e *UserService_Expecter) ResetPassword(_a0 interface{}, _a1 interface{}) *UserService_ResetPassword_Call {
+	return &UserService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", _a0, _a1)}
+}
+
+func (_c *UserService_ResetPassword_Call) Run(run func(_a0 context.Context, _a1 *domains.ResetPasswordRequest)) *UserService_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.ResetPasswordRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_ResetPassword_Call) Return(_a0 error) *UserService_ResetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_ResetPassword_Call) RunAndReturn(run func(context.Context, *domains.ResetPasswordRequest) error) *UserService_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAPIKey provides a mock function with given fields: _a0, _a1
+func (_m *UserService) RevokeAPIKey(_a0 context.Context, _a1 *domains.RevokeAPIKeyRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.RevokeAPIKeyRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_RevokeAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIKey'
+type UserService_RevokeAPIKey_Call struct {
+	*mock.Call
+}
+
+// RevokeAPIKey is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.RevokeAPIKeyRequest
+func (_e *UserService_Expecter) RevokeAPIKey(_a0 interface{}, _a1 interface{}) *UserService_RevokeAPIKey_Call {
+	return &UserService_RevokeAPIKey_Call{Call: _e.mock.On("RevokeAPIKey", _a0, _a1)}
+}
+
+func (_c *UserService_RevokeAPIKey_Call) Run(run func(_a0 context.Context, _a1 *domains.RevokeAPIKeyRequest)) *UserService_RevokeAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.RevokeAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_RevokeAPIKey_Call) Return(_a0 error) *UserService_RevokeAPIKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_RevokeAPIKey_Call) RunAndReturn(run func(context.Context, *domains.RevokeAPIKeyRequest) error) *UserService_RevokeAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchUser provides a mock function with given fields: _a0, _a1
+func (_m *UserService) SearchUser(_a0 context.Context, _a1 *domains.SearchUserRequest) (*domains.SearchUserResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.SearchUserResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.LoginRequest) (*domains.LoginResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.SearchUserRequest) (*domains.SearchUserResponse, error)); ok {
 		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.LoginRequest) *domains.LoginResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.SearchUserRequest) *domains.SearchUserResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domains.LoginResponse)
+			r0 = ret.Get(0).(*domains.SearchUserResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domains.LoginRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.SearchUserRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -48,41 +1358,41 @@ func (_m *UserService) Login(_a0 context.Context, _a1 *domains.LoginRequest) (*d
 	return r0, r1
 }
 
-// UserService_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
-type UserService_Login_Call struct {
+// UserService_SearchUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchUser'
+type UserService_SearchUser_Call struct {
 	*mock.Call
 }
 
-// Login is a helper method to define mock.On call
+// SearchUser is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *domains.LoginRequest
-func (_e *UserService_Expecter) Login(_a0 interface{}, _a1 interface{}) *UserService_Login_Call {
-	return &UserService_Login_Call{Call: _e.mock.On("Login", _a0, _a1)}
+//   - _a1 *domains.SearchUserRequest
+func (_e *UserService_Expecter) SearchUser(_a0 interface{}, _a1 interface{}) *UserService_SearchUser_Call {
+	return &UserService_SearchUser_Call{Call: _e.mock.On("SearchUser", _a0, _a1)}
 }
 
-func (_c *UserService_Login_Call) Run(run func(_a0 context.Context, _a1 *domains.LoginRequest)) *UserService_Login_Call {
+func (_c *UserService_SearchUser_Call) Run(run func(_a0 context.Context, _a1 *domains.SearchUserRequest)) *UserService_SearchUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domains.LoginRequest))
+		run(args[0].(context.Context), args[1].(*domains.SearchUserRequest))
 	})
 	return _c
 }
 
-func (_c *UserService_Login_Call) Return(_a0 *domains.LoginResponse, _a1 error) *UserService_Login_Call {
+func (_c *UserService_SearchUser_Call) Return(_a0 *domains.SearchUserResponse, _a1 error) *UserService_SearchUser_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserService_Login_Call) RunAndReturn(run func(context.Context, *domains.LoginRequest) (*domains.LoginResponse, error)) *UserService_Login_Call {
+func (_c *UserService_SearchUser_Call) RunAndReturn(run func(context.Context, *domains.SearchUserRequest) (*domains.SearchUserResponse, error)) *UserService_SearchUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Register provides a mock function with given fields: _a0, _a1
-func (_m *UserService) Register(_a0 context.Context, _a1 *domains.RegisterRequest) error {
+// Unlock provides a mock function with given fields: _a0, _a1
+func (_m *UserService) Unlock(_a0 context.Context, _a1 *domains.UnlockUserRequest) error {
 	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domains.RegisterRequest) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UnlockUserRequest) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
@@ -91,31 +1401,31 @@ func (_m *UserService) Register(_a0 context.Context, _a1 *domains.RegisterReques
 	return r0
 }
 
-// UserService_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
-type UserService_Register_Call struct {
+// UserService_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type UserService_Unlock_Call struct {
 	*mock.Call
 }
 
-// Register is a helper method to define mock.On call
+// Unlock is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *domains.RegisterRequest
-func (_e *UserService_Expecter) Register(_a0 interface{}, _a1 interface{}) *UserService_Register_Call {
-	return &UserService_Register_Call{Call: _e.mock.On("Register", _a0, _a1)}
+//   - _a1 *domains.UnlockUserRequest
+func (_e *UserService_Expecter) Unlock(_a0 interface{}, _a1 interface{}) *UserService_Unlock_Call {
+	return &UserService_Unlock_Call{Call: _e.mock.On("Unlock", _a0, _a1)}
 }
 
-func (_c *UserService_Register_Call) Run(run func(_a0 context.Context, _a1 *domains.RegisterRequest)) *UserService_Register_Call {
+func (_c *UserService_Unlock_Call) Run(run func(_a0 context.Context, _a1 *domains.UnlockUserRequest)) *UserService_Unlock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domains.RegisterRequest))
+		run(args[0].(context.Context), args[1].(*domains.UnlockUserRequest))
 	})
 	return _c
 }
 
-func (_c *UserService_Register_Call) Return(_a0 error) *UserService_Register_Call {
+func (_c *UserService_Unlock_Call) Return(_a0 error) *UserService_Unlock_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *UserService_Register_Call) RunAndReturn(run func(context.Context, *domains.RegisterRequest) error) *UserService_Register_Call {
+func (_c *UserService_Unlock_Call) RunAndReturn(run func(context.Context, *domains.UnlockUserRequest) error) *UserService_Unlock_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -175,6 +1485,159 @@ func (_c *UserService_Update_Call) RunAndReturn(run func(context.Context, *domai
 	return _c
 }
 
+// UpdateRole provides a mock function with given fields: _a0, _a1
+func (_m *UserService) UpdateRole(_a0 context.Context, _a1 *domains.UpdateUserRoleRequest) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateUserRoleRequest) (*domains.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UpdateUserRoleRequest) *domains.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.UpdateUserRoleRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type UserService_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UpdateUserRoleRequest
+func (_e *UserService_Expecter) UpdateRole(_a0 interface{}, _a1 interface{}) *UserService_UpdateRole_Call {
+	return &UserService_UpdateRole_Call{Call: _e.mock.On("UpdateRole", _a0, _a1)}
+}
+
+func (_c *UserService_UpdateRole_Call) Run(run func(_a0 context.Context, _a1 *domains.UpdateUserRoleRequest)) *UserService_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UpdateUserRoleRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_UpdateRole_Call) Return(_a0 *domains.User, _a1 error) *UserService_UpdateRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_UpdateRole_Call) RunAndReturn(run func(context.Context, *domains.UpdateUserRoleRequest) (*domains.User, error)) *UserService_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadProfileImage provides a mock function with given fields: _a0, _a1
+func (_m *UserService) UploadProfileImage(_a0 context.Context, _a1 *domains.UploadProfileImageRequest) (*domains.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UploadProfileImageRequest) (*domains.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.UploadProfileImageRequest) *domains.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.UploadProfileImageRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_UploadProfileImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadProfileImage'
+type UserService_UploadProfileImage_Call struct {
+	*mock.Call
+}
+
+// UploadProfileImage is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.UploadProfileImageRequest
+func (_e *UserService_Expecter) UploadProfileImage(_a0 interface{}, _a1 interface{}) *UserService_UploadProfileImage_Call {
+	return &UserService_UploadProfileImage_Call{Call: _e.mock.On("UploadProfileImage", _a0, _a1)}
+}
+
+func (_c *UserService_UploadProfileImage_Call) Run(run func(_a0 context.Context, _a1 *domains.UploadProfileImageRequest)) *UserService_UploadProfileImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.UploadProfileImageRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_UploadProfileImage_Call) Return(_a0 *domains.User, _a1 error) *UserService_UploadProfileImage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_UploadProfileImage_Call) RunAndReturn(run func(context.Context, *domains.UploadProfileImageRequest) (*domains.User, error)) *UserService_UploadProfileImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function with given fields: _a0, _a1
+func (_m *UserService) VerifyEmail(_a0 context.Context, _a1 *domains.VerifyEmailRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.VerifyEmailRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type UserService_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.VerifyEmailRequest
+func (_e *UserService_Expecter) VerifyEmail(_a0 interface{}, _a1 interface{}) *UserService_VerifyEmail_Call {
+	return &UserService_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", _a0, _a1)}
+}
+
+func (_c *UserService_VerifyEmail_Call) Run(run func(_a0 context.Context, _a1 *domains.VerifyEmailRequest)) *UserService_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.VerifyEmailRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_VerifyEmail_Call) Return(_a0 error) *UserService_VerifyEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_VerifyEmail_Call) RunAndReturn(run func(context.Context, *domains.VerifyEmailRequest) error) *UserService_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewUserService interface {
 	mock.TestingT
 	Cleanup(func())
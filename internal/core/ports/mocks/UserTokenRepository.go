@@ -0,0 +1,304 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domains "robinhood/internal/core/domains"
+
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserTokenRepository is an autogenerated mock type for the UserTokenRepository type
+type UserTokenRepository struct {
+	mock.Mock
+}
+
+type UserTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserTokenRepository) EXPECT() *UserTokenRepository_Expecter {
+	return &UserTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *UserTokenRepository) Create(_a0 context.Context, _a1 *domains.CreateUserTokenRequest) (*domains.UserToken, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *domains.UserToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateUserTokenRequest) (*domains.UserToken, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.CreateUserTokenRequest) *domains.UserToken); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.UserToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.CreateUserTokenRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type UserTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *domains.CreateUserTokenRequest
+func (_e *UserTokenRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *UserTokenRepository_Create_Call {
+	return &UserTokenRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *UserTokenRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *domains.CreateUserTokenRequest)) *UserTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domains.CreateUserTokenRequest))
+	})
+	return _c
+}
+
+func (_c *UserTokenRepository_Create_Call) Return(_a0 *domains.UserToken, _a1 error) *UserTokenRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserTokenRepository_Create_Call) RunAndReturn(run func(context.Context, *domains.CreateUserTokenRequest) (*domains.UserToken, error)) *UserTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUserID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserTokenRepository) DeleteByUserID(_a0 context.Context, _a1 primitive.ObjectID, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserTokenRepository_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type UserTokenRepository_DeleteByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 string
+func (_e *UserTokenRepository_Expecter) DeleteByUserID(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserTokenRepository_DeleteByUserID_Call {
+	return &UserTokenRepository_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", _a0, _a1, _a2)}
+}
+
+func (_c *UserTokenRepository_DeleteByUserID_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 string)) *UserTokenRepository_DeleteByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserTokenRepository_DeleteByUserID_Call) Return(_a0 error) *UserTokenRepository_DeleteByUserID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserTokenRepository_DeleteByUserID_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string) error) *UserTokenRepository_DeleteByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTokenHash provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserTokenRepository) GetByTokenHash(_a0 context.Context, _a1 string, _a2 string) (*domains.UserToken, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *domains.UserToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domains.UserToken, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domains.UserToken); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.UserToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserTokenRepository_GetByTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTokenHash'
+type UserTokenRepository_GetByTokenHash_Call struct {
+	*mock.Call
+}
+
+// GetByTokenHash is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *UserTokenRepository_Expecter) GetByTokenHash(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserTokenRepository_GetByTokenHash_Call {
+	return &UserTokenRepository_GetByTokenHash_Call{Call: _e.mock.On("GetByTokenHash", _a0, _a1, _a2)}
+}
+
+func (_c *UserTokenRepository_GetByTokenHash_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *UserTokenRepository_GetByTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserTokenRepository_GetByTokenHash_Call) Return(_a0 *domains.UserToken, _a1 error) *UserTokenRepository_GetByTokenHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserTokenRepository_GetByTokenHash_Call) RunAndReturn(run func(context.Context, string, string) (*domains.UserToken, error)) *UserTokenRepository_GetByTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestByUserID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserTokenRepository) GetLatestByUserID(_a0 context.Context, _a1 primitive.ObjectID, _a2 string) (*domains.UserToken, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *domains.UserToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) (*domains.UserToken, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) *domains.UserToken); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.UserToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserTokenRepository_GetLatestByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestByUserID'
+type UserTokenRepository_GetLatestByUserID_Call struct {
+	*mock.Call
+}
+
+// GetLatestByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+//   - _a2 string
+func (_e *UserTokenRepository_Expecter) GetLatestByUserID(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserTokenRepository_GetLatestByUserID_Call {
+	return &UserTokenRepository_GetLatestByUserID_Call{Call: _e.mock.On("GetLatestByUserID", _a0, _a1, _a2)}
+}
+
+func (_c *UserTokenRepository_GetLatestByUserID_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID, _a2 string)) *UserTokenRepository_GetLatestByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserTokenRepository_GetLatestByUserID_Call) Return(_a0 *domains.UserToken, _a1 error) *UserTokenRepository_GetLatestByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserTokenRepository_GetLatestByUserID_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string) (*domains.UserToken, error)) *UserTokenRepository_GetLatestByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkUsed provides a mock function with given fields: _a0, _a1
+func (_m *UserTokenRepository) MarkUsed(_a0 context.Context, _a1 primitive.ObjectID) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (bool, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserTokenRepository_MarkUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkUsed'
+type UserTokenRepository_MarkUsed_Call struct {
+	*mock.Call
+}
+
+// MarkUsed is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 primitive.ObjectID
+func (_e *UserTokenRepository_Expecter) MarkUsed(_a0 interface{}, _a1 interface{}) *UserTokenRepository_MarkUsed_Call {
+	return &UserTokenRepository_MarkUsed_Call{Call: _e.mock.On("MarkUsed", _a0, _a1)}
+}
+
+func (_c *UserTokenRepository_MarkUsed_Call) Run(run func(_a0 context.Context, _a1 primitive.ObjectID)) *UserTokenRepository_MarkUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *UserTokenRepository_MarkUsed_Call) Return(_a0 bool, _a1 error) *UserTokenRepository_MarkUsed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserTokenRepository_MarkUsed_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) (bool, error)) *UserTokenRepository_MarkUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewUserTokenRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserTokenRepository creates a new instance of UserTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserTokenRepository(t mockConstructorTestingTNewUserTokenRepository) *UserTokenRepository {
+	mock := &UserTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
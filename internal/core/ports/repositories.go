@@ -3,29 +3,128 @@ package ports
 import (
 	"context"
 	"robinhood/internal/core/domains"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type BlogRepository interface {
 	Create(context.Context, *domains.CreateBlogRequest) (*domains.Blog, error)
+	GetByID(context.Context, string) (*domains.Blog, error)
+	GetArchivedByID(context.Context, string) (*domains.Blog, error)
 	CreateTx(context.Context, *domains.CreateBlogRequest, domains.CreateBlogFn) (*domains.PopulatedBlog, error)
 	GetPopulatedBlogByID(context.Context, string) (*domains.PopulatedBlog, error)
-	List(context.Context, *domains.PaginationOptions) ([]domains.PopulatedBlog, error)
-	Count(context.Context, *domains.PaginationOptions) (int64, error)
-	UpdateStatus(context.Context, *domains.UpdateBlogStatusRequest) error
+	List(context.Context, *domains.BlogFilter, *domains.BlogSort, *domains.PaginationOptions) ([]domains.PopulatedBlog, error)
+	Count(context.Context, *domains.BlogFilter, *domains.PaginationOptions) (int64, error)
+	UpdateStatus(context.Context, *domains.UpdateBlogStatusRequest) (*domains.Blog, error)
+	UpdateStatusTx(context.Context, *domains.UpdateBlogStatusRequest, domains.UpdateBlogStatusFn) error
+	Edit(context.Context, *domains.EditBlogRequest) error
+	SetDue(context.Context, *domains.SetBlogDueRequest) error
+	ListForReminder(context.Context, *domains.ListBlogReminderRequest) ([]domains.Blog, error)
+	MarkReminded(context.Context, string, string) (bool, error)
+	AttachLabel(context.Context, *domains.AttachLabelRequest) error
+	DetachLabel(context.Context, *domains.DetachLabelRequest) error
+	RemoveLabel(context.Context, string) error
+	Assign(context.Context, *domains.AssignBlogRequest) error
+	Unassign(context.Context, *domains.UnassignBlogRequest) error
 	Archive(context.Context, *domains.ArchiveBlogRequest) error
+	Restore(context.Context, *domains.RestoreBlogRequest) error
+	ListArchivedIDs(context.Context, *domains.PurgeArchivedBlogsRequest) ([]string, error)
+	DeleteByIDs(context.Context, []string) error
+	PurgeTx(context.Context, *domains.PurgeArchivedBlogsRequest, domains.PurgeArchivedBlogsFn) (int64, error)
+	EachByAuthorID(context.Context, string, func(domains.Blog) error) error
+	AnonymizeAuthor(context.Context, string) error
+	UnassignUser(context.Context, string) error
+}
+
+type BlogHistoryRepository interface {
+	Create(context.Context, *domains.CreateBlogHistoryRequest) (*domains.BlogHistory, error)
+	ListByBlogID(context.Context, string) ([]domains.PopulatedBlogHistory, error)
+	DeleteByBlogIDs(context.Context, []string) error
+}
+
+type LabelRepository interface {
+	Create(context.Context, *domains.CreateLabelRequest) (*domains.Label, error)
+	GetByID(context.Context, string) (*domains.Label, error)
+	List(context.Context) ([]domains.Label, error)
+	Update(context.Context, *domains.UpdateLabelRequest) (*domains.Label, error)
+	Delete(context.Context, string) error
+	DeleteTx(context.Context, string, domains.DeleteLabelFn) error
 }
 
 type CommentRepository interface {
 	Create(context.Context, *domains.CreateCommentRequest) (*domains.Comment, error)
 	CreateTx(context.Context, *domains.CreateCommentRequest, domains.CreateCommentFn) (*domains.PopulatedComment, error)
 	List(context.Context, string) ([]domains.PopulatedComment, error)
+	EachByAuthorID(context.Context, string, func(domains.Comment) error) error
+	DeleteByBlogIDs(context.Context, []string) error
+	AnonymizeAuthor(context.Context, string) error
 }
 
 type UserRepository interface {
 	GetByID(context.Context, primitive.ObjectID) (*domains.User, error)
 	GetByUsername(context.Context, string) (*domains.User, error)
+	GetByEmail(context.Context, string) (*domains.User, error)
+	Search(context.Context, string, *domains.PaginationOptions) ([]domains.User, error)
+	CountSearch(context.Context, string, *domains.PaginationOptions) (int64, error)
 	Create(context.Context, *domains.CreateUserRequest) (*domains.User, error)
 	Update(context.Context, *domains.UpdateUserRequest) (*domains.User, error)
+	UpdateProfileImage(context.Context, primitive.ObjectID, string, map[string]string) (*domains.User, error)
+	UpdateLastActiveAt(context.Context, primitive.ObjectID, time.Time) error
+	IncrementTokenVersion(context.Context, primitive.ObjectID) error
+	UpdateRole(context.Context, *domains.UpdateUserRoleRequest) (*domains.User, error)
+	UpdatePassword(context.Context, primitive.ObjectID, string) error
+	UpdateEmail(context.Context, primitive.ObjectID, string) (*domains.User, error)
+	UpdateUsername(context.Context, primitive.ObjectID, string) (*domains.User, error)
+	UpdateVerified(context.Context, primitive.ObjectID) error
+	UpdateTOTPSecret(context.Context, primitive.ObjectID, string) error
+	EnableMFA(context.Context, primitive.ObjectID, []string) error
+	UseTOTPCounter(context.Context, primitive.ObjectID, int64) (bool, error)
+	UseRecoveryCode(context.Context, primitive.ObjectID, string) (bool, error)
+	GetByOIDCSubject(context.Context, string, string) (*domains.User, error)
+	LinkOIDC(context.Context, primitive.ObjectID, string, string) error
+	Deactivate(context.Context, primitive.ObjectID, time.Time) error
+	Delete(context.Context, primitive.ObjectID) error
+}
+
+type RefreshTokenRepository interface {
+	Create(context.Context, *domains.CreateRefreshTokenRequest) (*domains.RefreshToken, error)
+	GetByTokenHash(context.Context, string) (*domains.RefreshToken, error)
+	MarkUsed(context.Context, primitive.ObjectID) (bool, error)
+	RevokeFamily(context.Context, string) error
+	RevokeByUserID(context.Context, primitive.ObjectID) error
+}
+
+type RevokedTokenRepository interface {
+	Create(context.Context, *domains.RevokeTokenRequest) error
+	IsRevoked(context.Context, string) (bool, error)
+}
+
+type LoginAttemptRepository interface {
+	GetByKeys(context.Context, []string) ([]domains.LoginAttempt, error)
+	IncrementFailures(context.Context, string, time.Time) (*domains.LoginAttempt, error)
+	Lock(context.Context, string, time.Time) error
+	DeleteByKey(context.Context, string) error
+}
+
+type APIKeyRepository interface {
+	Create(context.Context, *domains.StoreAPIKeyRequest) (*domains.APIKey, error)
+	GetByKeyHash(context.Context, string) (*domains.APIKey, error)
+	ListByUserID(context.Context, primitive.ObjectID) ([]domains.APIKey, error)
+	UpdateLastUsedAt(context.Context, primitive.ObjectID, time.Time) error
+	Delete(context.Context, primitive.ObjectID, primitive.ObjectID) (bool, error)
+	DeleteByUserID(context.Context, primitive.ObjectID) error
+}
+
+type OIDCStateRepository interface {
+	Create(context.Context, *domains.CreateOIDCStateRequest) (*domains.OIDCState, error)
+	Consume(context.Context, string) (*domains.OIDCState, error)
+}
+
+type UserTokenRepository interface {
+	Create(context.Context, *domains.CreateUserTokenRequest) (*domains.UserToken, error)
+	GetByTokenHash(context.Context, string, string) (*domains.UserToken, error)
+	GetLatestByUserID(context.Context, primitive.ObjectID, string) (*domains.UserToken, error)
+	MarkUsed(context.Context, primitive.ObjectID) (bool, error)
+	DeleteByUserID(context.Context, primitive.ObjectID, string) error
 }
@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	"robinhood/internal/core/domains"
+	"robinhood/pkg/auth"
 )
 
 type BlogService interface {
@@ -11,7 +12,28 @@ type BlogService interface {
 	GetBlogByID(context.Context, string) (*domains.PopulatedBlog, error)
 	ListBlog(context.Context, *domains.ListBlogRequest) (*domains.ListBlogResponse, error)
 	UpdateBlogStatus(context.Context, *domains.UpdateBlogStatusRequest) error
+	UpdateBlogStatusTx(context.Context, *domains.UpdateBlogStatusRequest) error
+	ListBlogHistory(context.Context, string) ([]domains.PopulatedBlogHistory, error)
+	GetWorkflow(context.Context) *domains.Workflow
+	EditBlog(context.Context, *domains.EditBlogRequest) (*domains.PopulatedBlog, error)
+	SetBlogDue(context.Context, *domains.SetBlogDueRequest) (*domains.PopulatedBlog, error)
+	SendDueReminders(context.Context) (int64, error)
+	AttachLabel(context.Context, *domains.AttachLabelRequest) (*domains.PopulatedBlog, error)
+	DetachLabel(context.Context, *domains.DetachLabelRequest) (*domains.PopulatedBlog, error)
+	AssignBlog(context.Context, *domains.AssignBlogRequest) (*domains.PopulatedBlog, error)
+	UnassignBlog(context.Context, *domains.UnassignBlogRequest) (*domains.PopulatedBlog, error)
 	ArchiveBlog(context.Context, *domains.ArchiveBlogRequest) error
+	RestoreBlog(context.Context, *domains.RestoreBlogRequest) (*domains.PopulatedBlog, error)
+	PurgeArchivedBlogs(context.Context) (int64, error)
+	PurgeArchivedBlogsTx(context.Context, *domains.PurgeArchivedBlogsRequest) (int64, error)
+}
+
+type LabelService interface {
+	CreateLabel(context.Context, *domains.CreateLabelRequest) (*domains.Label, error)
+	ListLabels(context.Context) ([]domains.Label, error)
+	UpdateLabel(context.Context, *domains.UpdateLabelRequest) (*domains.Label, error)
+	DeleteLabel(context.Context, string) error
+	DeleteLabelTx(context.Context, string) error
 }
 
 type CommentService interface {
@@ -23,5 +45,42 @@ type CommentService interface {
 type UserService interface {
 	Register(context.Context, *domains.RegisterRequest) error
 	Login(context.Context, *domains.LoginRequest) (*domains.LoginResponse, error)
+	LoginMFA(context.Context, *domains.LoginMFARequest) (*domains.LoginResponse, error)
+	OIDCLogin(context.Context) (*domains.OIDCLoginResponse, error)
+	OIDCCallback(context.Context, *domains.OIDCCallbackRequest) (*domains.LoginResponse, error)
+	EnrollTOTP(context.Context, string) (*domains.EnrollTOTPResponse, error)
+	ConfirmTOTP(context.Context, *domains.ConfirmTOTPRequest) (*domains.ConfirmTOTPResponse, error)
+	RefreshToken(context.Context, *domains.RefreshTokenRequest) (*domains.LoginResponse, error)
+	Logout(context.Context, *domains.LogoutRequest) error
+	LogoutAll(context.Context, string) error
+	ForgotPassword(context.Context, *domains.ForgotPasswordRequest) error
+	ResetPassword(context.Context, *domains.ResetPasswordRequest) error
+	VerifyEmail(context.Context, *domains.VerifyEmailRequest) error
+	ResendVerification(context.Context, string) error
+	GetUserByID(context.Context, string) (*domains.User, error)
+	SearchUser(context.Context, *domains.SearchUserRequest) (*domains.SearchUserResponse, error)
 	Update(context.Context, *domains.UpdateUserRequest) (*domains.User, error)
+	UploadProfileImage(context.Context, *domains.UploadProfileImageRequest) (*domains.User, error)
+	ProfileImageURL(context.Context, *domains.ProfileImageURLRequest) (string, error)
+	ChangePassword(context.Context, *domains.ChangePasswordRequest) (*domains.LoginResponse, error)
+	ChangeEmail(context.Context, *domains.ChangeEmailRequest) (*domains.User, error)
+	ChangeUsername(context.Context, *domains.ChangeUsernameRequest) (*domains.User, error)
+	Deactivate(context.Context, *domains.DeactivateUserRequest) error
+	Delete(context.Context, *domains.DeleteUserRequest) error
+	Export(context.Context, string, UserExportWriter) error
+	CreateAPIKey(context.Context, *domains.CreateAPIKeyRequest) (*domains.CreateAPIKeyResponse, error)
+	ListAPIKeys(context.Context, string) ([]domains.APIKey, error)
+	RevokeAPIKey(context.Context, *domains.RevokeAPIKeyRequest) error
+	Unlock(context.Context, *domains.UnlockUserRequest) error
+	UpdateRole(context.Context, *domains.UpdateUserRoleRequest) (*domains.User, error)
+	Authenticate(context.Context, string) (*auth.JWTCustomClaims, error)
+	AuthenticateAPIKey(context.Context, string) (*auth.JWTCustomClaims, error)
+}
+
+// UserExportWriter receives the data of an account export one item at a time,
+// the profile first, then the blogs and then the comments.
+type UserExportWriter interface {
+	WriteProfile(domains.User) error
+	WriteBlog(domains.Blog) error
+	WriteComment(domains.Comment) error
 }
@@ -2,28 +2,47 @@ package blogsvc
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"robinhood/config"
 	"robinhood/internal/core/constants"
 	"robinhood/internal/core/domains"
 	"robinhood/internal/core/ports"
 	"robinhood/internal/errmsg"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type blogService struct {
-	br ports.BlogRepository
-	ur ports.UserRepository
+	br       ports.BlogRepository
+	bhr      ports.BlogHistoryRepository
+	cr       ports.CommentRepository
+	ur       ports.UserRepository
+	lr       ports.LabelRepository
+	mailer   ports.Mailer
+	workflow *domains.Workflow
 }
 
-func New(br ports.BlogRepository, ur ports.UserRepository) ports.BlogService {
-	return &blogService{br: br, ur: ur}
+func New(br ports.BlogRepository, bhr ports.BlogHistoryRepository, cr ports.CommentRepository, ur ports.UserRepository, lr ports.LabelRepository, mailer ports.Mailer, workflow *domains.Workflow) ports.BlogService {
+	return &blogService{br: br, bhr: bhr, cr: cr, ur: ur, lr: lr, mailer: mailer, workflow: workflow}
 }
 
 func (s *blogService) CreateBlog(ctx context.Context, req *domains.CreateBlogRequest) (*domains.PopulatedBlog, error) {
+	if req.Priority == "" {
+		req.Priority = constants.PRIORITY_MEDIUM
+	}
+	if _, ok := domains.ParsePriority(req.Priority); !ok {
+		return nil, errmsg.BlogInvalidPriority
+	}
 	return s.br.CreateTx(ctx, req, s.CreateBlogTx)
 }
 
 func (s *blogService) CreateBlogTx(ctx context.Context, req *domains.CreateBlogRequest) (*domains.PopulatedBlog, error) {
 	// create blog
+	req.Status = s.workflow.InitialStatus
 	blog, err := s.br.Create(ctx, req)
 	if err != nil {
 		log.Printf("[blogService::CreateBlogTx::Create] error => %+v", err)
@@ -48,6 +67,8 @@ func (s *blogService) CreateBlogTx(ctx context.Context, req *domains.CreateBlogR
 			ProfileImage: author.ProfileImage,
 		},
 		Status:     blog.Status,
+		Priority:   blog.Priority,
+		DueAt:      blog.DueAt,
 		IsArchived: blog.IsArchived,
 		CreatedAt:  blog.CreatedAt,
 	}, nil
@@ -70,7 +91,17 @@ func (s *blogService) ListBlog(ctx context.Context, req *domains.ListBlogRequest
 		req.Page = 1
 	}
 
-	blogs, err := s.br.List(ctx, &domains.PaginationOptions{
+	sort, err := blogSort(req)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := s.blogFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	blogs, err := s.br.List(ctx, filter, sort, &domains.PaginationOptions{
 		Offset: int64((req.Page - 1) * req.Limit),
 		Limit:  int64(req.Limit),
 	})
@@ -80,7 +111,7 @@ func (s *blogService) ListBlog(ctx context.Context, req *domains.ListBlogRequest
 	}
 
 	// count + 1 to check if there is next page
-	count, err := s.br.Count(ctx, &domains.PaginationOptions{
+	count, err := s.br.Count(ctx, filter, &domains.PaginationOptions{
 		Offset: int64((req.Page - 1) * req.Limit),
 		Limit:  int64(req.Limit + 1),
 	})
@@ -103,21 +134,574 @@ func (s *blogService) ListBlog(ctx context.Context, req *domains.ListBlogRequest
 }
 
 func (s *blogService) UpdateBlogStatus(ctx context.Context, req *domains.UpdateBlogStatusRequest) error {
-	// check status is valid
-	switch req.Status {
-	case constants.TO_DO:
-	case constants.IN_PROGRESS:
-	case constants.DONE:
-	default:
-		return errmsg.BlogInvalidStatus
+	blog, err := s.br.GetByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::UpdateBlogStatus::GetByID] error => %+v", err)
+		return errmsg.BlogGetFailed
+	}
+
+	if blog == nil {
+		return errmsg.BlogNotFound
+	}
+
+	if !s.canChangeStatus(blog, req) {
+		return errmsg.Forbidden
+	}
+
+	if err := s.checkTransition(blog.Status, req); err != nil {
+		return err
+	}
+
+	return s.br.UpdateStatusTx(ctx, req, s.UpdateBlogStatusTx)
+}
+
+// UpdateBlogStatusTx changes the status and records the change in the history.
+func (s *blogService) UpdateBlogStatusTx(ctx context.Context, req *domains.UpdateBlogStatusRequest) error {
+	blog, err := s.br.UpdateStatus(ctx, req)
+	if err != nil {
+		log.Printf("[blogService::UpdateBlogStatusTx::UpdateStatus] error => %+v", err)
+		return errmsg.BlogUpdateFailed
+	}
+
+	// moving a blog to its own status changes nothing
+	if blog.Status == req.Status {
+		return nil
+	}
+
+	// the status may have changed since it was checked, the transaction is
+	// rolled back then
+	if !s.canChangeStatus(blog, req) {
+		return errmsg.Forbidden
+	}
+	if err := s.checkTransition(blog.Status, req); err != nil {
+		return err
+	}
+
+	if _, err := s.bhr.Create(ctx, &domains.CreateBlogHistoryRequest{
+		BlogId:  req.BlogId,
+		From:    blog.Status,
+		To:      req.Status,
+		ActorId: req.UserId,
+	}); err != nil {
+		log.Printf("[blogService::UpdateBlogStatusTx::Create] error => %+v", err)
+		return errmsg.BlogUpdateFailed
+	}
+
+	return nil
+}
+
+func (s *blogService) ListBlogHistory(ctx context.Context, blogId string) ([]domains.PopulatedBlogHistory, error) {
+	blog, err := s.br.GetByID(ctx, blogId)
+	if err != nil {
+		log.Printf("[blogService::ListBlogHistory::GetByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	if blog == nil {
+		return nil, errmsg.BlogNotFound
+	}
+
+	history, err := s.bhr.ListByBlogID(ctx, blogId)
+	if err != nil {
+		log.Printf("[blogService::ListBlogHistory::ListByBlogID] error => %+v", err)
+		return nil, errmsg.BlogHistoryListFailed
+	}
+
+	return history, nil
+}
+
+func (s *blogService) EditBlog(ctx context.Context, req *domains.EditBlogRequest) (*domains.PopulatedBlog, error) {
+	if req.Title == nil && req.Content == nil && req.Priority == nil {
+		return nil, errmsg.BlogEditEmpty
+	}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" || utf8.RuneCountInString(title) > constants.BLOG_TITLE_MAX_LENGTH {
+			return nil, errmsg.BlogInvalidTitle
+		}
+		req.Title = &title
+	}
+
+	if req.Content != nil {
+		if strings.TrimSpace(*req.Content) == "" || utf8.RuneCountInString(*req.Content) > constants.BLOG_CONTENT_MAX_LENGTH {
+			return nil, errmsg.BlogInvalidContent
+		}
+	}
+
+	if req.Priority != nil {
+		if _, ok := domains.ParsePriority(*req.Priority); !ok {
+			return nil, errmsg.BlogInvalidPriority
+		}
+	}
+
+	blog, err := s.br.GetByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::EditBlog::GetByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	if blog == nil {
+		return nil, errmsg.BlogNotFound
+	}
+
+	// unlike the status, only the author can change what the blog says
+	if blog.AuthorId.Hex() != req.UserId {
+		return nil, errmsg.Forbidden
+	}
+
+	if err := s.br.Edit(ctx, req); err != nil {
+		log.Printf("[blogService::EditBlog::Edit] error => %+v", err)
+		return nil, errmsg.BlogUpdateFailed
+	}
+
+	populated, err := s.br.GetPopulatedBlogByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::EditBlog::GetPopulatedBlogByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	return populated, nil
+}
+
+func (s *blogService) SetBlogDue(ctx context.Context, req *domains.SetBlogDueRequest) (*domains.PopulatedBlog, error) {
+	if _, err := s.authorize(ctx, req.BlogId, req.UserId, req.Role); err != nil {
+		return nil, err
+	}
+
+	if err := s.br.SetDue(ctx, req); err != nil {
+		log.Printf("[blogService::SetBlogDue::SetDue] error => %+v", err)
+		return nil, errmsg.BlogUpdateFailed
+	}
+
+	populated, err := s.br.GetPopulatedBlogByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::SetBlogDue::GetPopulatedBlogByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	return populated, nil
+}
+
+// SendDueReminders emails the assignees, or the author when there is none,
+// of the blogs which are due soon and of the blogs which became overdue.
+// Each reminder is sent once per due date.
+func (s *blogService) SendDueReminders(ctx context.Context) (int64, error) {
+	now := time.Now().UTC()
+	reqs := []*domains.ListBlogReminderRequest{}
+	if lead := config.Get().Blog.DueReminderMinutes; lead > 0 {
+		reqs = append(reqs, &domains.ListBlogReminderRequest{
+			Kind:      constants.BLOG_REMINDER_DUE_SOON,
+			DueAfter:  now,
+			DueBefore: now.Add(time.Duration(lead) * time.Minute),
+		})
+	}
+	reqs = append(reqs, &domains.ListBlogReminderRequest{
+		Kind:      constants.BLOG_REMINDER_OVERDUE,
+		DueBefore: now,
+	})
+
+	var total int64
+	for _, req := range reqs {
+		req.DoneStatuses = s.workflow.DoneStatuses
+		req.Limit = constants.BLOG_REMINDER_BATCH_SIZE
+		blogs, err := s.br.ListForReminder(ctx, req)
+		if err != nil {
+			log.Printf("[blogService::SendDueReminders::ListForReminder] error => %+v", err)
+			return total, errmsg.BlogReminderFailed
+		}
+
+		for i := range blogs {
+			// claim the reminder first so it isn't sent twice by another run
+			claimed, err := s.br.MarkReminded(ctx, blogs[i].ID.Hex(), req.Kind)
+			if err != nil {
+				log.Printf("[blogService::SendDueReminders::MarkReminded] error => %+v", err)
+				return total, errmsg.BlogReminderFailed
+			}
+			if !claimed {
+				continue
+			}
+			s.remind(ctx, &blogs[i], req.Kind)
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// remind sends the reminder to each recipient, a failed email is only logged
+// as the reminder is already marked as sent.
+func (s *blogService) remind(ctx context.Context, blog *domains.Blog, kind string) {
+	ids := blog.AssigneeIds
+	if len(ids) == 0 {
+		ids = []primitive.ObjectID{blog.AuthorId}
+	}
+
+	subject := fmt.Sprintf("%q is due soon", blog.Title)
+	if kind == constants.BLOG_REMINDER_OVERDUE {
+		subject = fmt.Sprintf("%q is overdue", blog.Title)
+	}
+
+	for _, id := range ids {
+		user, err := s.ur.GetByID(ctx, id)
+		if err != nil {
+			log.Printf("[blogService::remind::GetByID] error => %+v", err)
+			continue
+		}
+		if user == nil || user.Deactivated {
+			continue
+		}
+
+		body := fmt.Sprintf("Hi %s,\n\n%q is due at %s and it is still %s.\n", user.Username, blog.Title, blog.DueAt.UTC().Format(time.RFC1123), blog.Status)
+		if err := s.mailer.Send(ctx, &domains.Mail{
+			To:      user.Email,
+			Subject: subject,
+			Body:    body,
+		}); err != nil {
+			log.Printf("[blogService::remind::Send] error => %+v", err)
+		}
+	}
+}
+
+func (s *blogService) AttachLabel(ctx context.Context, req *domains.AttachLabelRequest) (*domains.PopulatedBlog, error) {
+	blog, err := s.authorize(ctx, req.BlogId, req.UserId, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	label, err := s.lr.GetByID(ctx, req.LabelId)
+	if err != nil {
+		log.Printf("[blogService::AttachLabel::GetByID] error => %+v", err)
+		return nil, errmsg.LabelGetFailed
+	}
+
+	if label == nil {
+		return nil, errmsg.LabelNotFound
+	}
+
+	if !hasID(blog.LabelIds, label.ID) {
+		if len(blog.LabelIds) >= constants.BLOG_MAX_LABELS {
+			return nil, errmsg.BlogTooManyLabels
+		}
+
+		if err := s.br.AttachLabel(ctx, req); err != nil {
+			log.Printf("[blogService::AttachLabel::AttachLabel] error => %+v", err)
+			return nil, errmsg.BlogUpdateFailed
+		}
+	}
+
+	populated, err := s.br.GetPopulatedBlogByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::AttachLabel::GetPopulatedBlogByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	return populated, nil
+}
+
+func (s *blogService) DetachLabel(ctx context.Context, req *domains.DetachLabelRequest) (*domains.PopulatedBlog, error) {
+	if _, err := s.authorize(ctx, req.BlogId, req.UserId, req.Role); err != nil {
+		return nil, err
+	}
+
+	if err := s.br.DetachLabel(ctx, req); err != nil {
+		log.Printf("[blogService::DetachLabel::DetachLabel] error => %+v", err)
+		return nil, errmsg.BlogUpdateFailed
+	}
+
+	populated, err := s.br.GetPopulatedBlogByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::DetachLabel::GetPopulatedBlogByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	return populated, nil
+}
+
+func (s *blogService) AssignBlog(ctx context.Context, req *domains.AssignBlogRequest) (*domains.PopulatedBlog, error) {
+	blog, err := s.authorize(ctx, req.BlogId, req.UserId, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	aid, err := primitive.ObjectIDFromHex(req.AssigneeId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	assignee, err := s.ur.GetByID(ctx, aid)
+	if err != nil {
+		log.Printf("[blogService::AssignBlog::GetByID] error => %+v", err)
+		return nil, errmsg.BlogAssignFailed
+	}
+
+	if assignee == nil || assignee.Deactivated {
+		return nil, errmsg.UserNotFound
+	}
+
+	if !hasID(blog.AssigneeIds, aid) {
+		if len(blog.AssigneeIds) >= constants.BLOG_MAX_ASSIGNEES {
+			return nil, errmsg.BlogTooManyAssignees
+		}
+
+		if err := s.br.Assign(ctx, req); err != nil {
+			log.Printf("[blogService::AssignBlog::Assign] error => %+v", err)
+			return nil, errmsg.BlogAssignFailed
+		}
 	}
-	return s.br.UpdateStatus(ctx, req)
+
+	populated, err := s.br.GetPopulatedBlogByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::AssignBlog::GetPopulatedBlogByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	return populated, nil
+}
+
+func (s *blogService) UnassignBlog(ctx context.Context, req *domains.UnassignBlogRequest) (*domains.PopulatedBlog, error) {
+	blog, err := s.br.GetByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::UnassignBlog::GetByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	if blog == nil {
+		return nil, errmsg.BlogNotFound
+	}
+
+	// an assignee can always step down
+	if req.AssigneeId != req.UserId && !canMutate(blog, req.UserId, req.Role) {
+		return nil, errmsg.Forbidden
+	}
+
+	if err := s.br.Unassign(ctx, req); err != nil {
+		log.Printf("[blogService::UnassignBlog::Unassign] error => %+v", err)
+		return nil, errmsg.BlogAssignFailed
+	}
+
+	populated, err := s.br.GetPopulatedBlogByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::UnassignBlog::GetPopulatedBlogByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	return populated, nil
 }
 
 func (s *blogService) ArchiveBlog(ctx context.Context, req *domains.ArchiveBlogRequest) error {
+	if _, err := s.authorize(ctx, req.BlogId, req.UserId, req.Role); err != nil {
+		return err
+	}
+
 	if err := s.br.Archive(ctx, req); err != nil {
 		log.Printf("[blogService::ArchiveBlog] error => %+v", err)
 		return errmsg.BlogArchiveFailed
 	}
 	return nil
 }
+
+func (s *blogService) RestoreBlog(ctx context.Context, req *domains.RestoreBlogRequest) (*domains.PopulatedBlog, error) {
+	blog, err := s.br.GetArchivedByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::RestoreBlog::GetArchivedByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	if blog == nil {
+		return nil, errmsg.BlogNotFound
+	}
+
+	if !canMutate(blog, req.UserId, req.Role) {
+		return nil, errmsg.Forbidden
+	}
+
+	if err := s.br.Restore(ctx, req); err != nil {
+		log.Printf("[blogService::RestoreBlog::Restore] error => %+v", err)
+		return nil, errmsg.BlogRestoreFailed
+	}
+
+	populated, err := s.br.GetPopulatedBlogByID(ctx, req.BlogId)
+	if err != nil {
+		log.Printf("[blogService::RestoreBlog::GetPopulatedBlogByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	return populated, nil
+}
+
+// PurgeArchivedBlogs deletes the blogs archived longer than the retention
+// with their comments and history, a batch at a time.
+func (s *blogService) PurgeArchivedBlogs(ctx context.Context) (int64, error) {
+	days := config.Get().Blog.ArchiveRetentionDays
+	if days == 0 {
+		return 0, nil
+	}
+
+	req := &domains.PurgeArchivedBlogsRequest{
+		ArchivedBefore: time.Now().UTC().AddDate(0, 0, -int(days)),
+		Limit:          constants.BLOG_PURGE_BATCH_SIZE,
+	}
+	var total int64
+	for {
+		n, err := s.br.PurgeTx(ctx, req, s.PurgeArchivedBlogsTx)
+		if err != nil {
+			log.Printf("[blogService::PurgeArchivedBlogs::PurgeTx] error => %+v", err)
+			return total, errmsg.BlogPurgeFailed
+		}
+		total += n
+		if n < req.Limit {
+			return total, nil
+		}
+	}
+}
+
+// PurgeArchivedBlogsTx deletes a batch, the blogs are looked up in the
+// transaction so one restored meanwhile is kept with its comments.
+func (s *blogService) PurgeArchivedBlogsTx(ctx context.Context, req *domains.PurgeArchivedBlogsRequest) (int64, error) {
+	ids, err := s.br.ListArchivedIDs(ctx, req)
+	if err != nil {
+		log.Printf("[blogService::PurgeArchivedBlogsTx::ListArchivedIDs] error => %+v", err)
+		return 0, errmsg.BlogPurgeFailed
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := s.cr.DeleteByBlogIDs(ctx, ids); err != nil {
+		log.Printf("[blogService::PurgeArchivedBlogsTx::DeleteCommentsByBlogIDs] error => %+v", err)
+		return 0, errmsg.BlogPurgeFailed
+	}
+
+	if err := s.bhr.DeleteByBlogIDs(ctx, ids); err != nil {
+		log.Printf("[blogService::PurgeArchivedBlogsTx::DeleteHistoryByBlogIDs] error => %+v", err)
+		return 0, errmsg.BlogPurgeFailed
+	}
+
+	if err := s.br.DeleteByIDs(ctx, ids); err != nil {
+		log.Printf("[blogService::PurgeArchivedBlogsTx::DeleteByIDs] error => %+v", err)
+		return 0, errmsg.BlogPurgeFailed
+	}
+
+	return int64(len(ids)), nil
+}
+
+// authorize checks that the blog can be mutated by the user.
+func (s *blogService) authorize(ctx context.Context, blogId string, userId string, role string) (*domains.Blog, error) {
+	blog, err := s.br.GetByID(ctx, blogId)
+	if err != nil {
+		log.Printf("[blogService::authorize::GetByID] error => %+v", err)
+		return nil, errmsg.BlogGetFailed
+	}
+
+	if blog == nil {
+		return nil, errmsg.BlogNotFound
+	}
+
+	if !canMutate(blog, userId, role) {
+		return nil, errmsg.Forbidden
+	}
+
+	return blog, nil
+}
+
+func hasID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// canMutate tells whether the user is the author of the blog or an admin.
+func canMutate(blog *domains.Blog, userId string, role string) bool {
+	return role == constants.ROLE_ADMIN || blog.AuthorId.Hex() == userId
+}
+
+// canChangeStatus tells whether the user can move the blog to the status, a
+// transition which names the role of the user lets it move any blog.
+func (s *blogService) canChangeStatus(blog *domains.Blog, req *domains.UpdateBlogStatusRequest) bool {
+	return canMutate(blog, req.UserId, req.Role) || s.workflow.GrantsTransition(blog.Status, req.Status, req.Role)
+}
+
+// checkTransition checks that the workflow lets the role move the blog to
+// the status, staying in the same status is always fine.
+func (s *blogService) checkTransition(from string, req *domains.UpdateBlogStatusRequest) error {
+	if from == req.Status && s.workflow.HasStatus(req.Status) {
+		return nil
+	}
+	if !s.workflow.CanTransition(from, req.Status, req.Role) {
+		return errmsg.NewBlogInvalidTransitionError(from, req.Status, s.workflow.NextStatuses(from, req.Role))
+	}
+	return nil
+}
+
+func (s *blogService) GetWorkflow(ctx context.Context) *domains.Workflow {
+	return s.workflow
+}
+
+// blogSort reads the sort of the list, the latest created (or archived)
+// blogs come first by default.
+func blogSort(req *domains.ListBlogRequest) (*domains.BlogSort, error) {
+	if req.Sort == "" {
+		if req.Archived {
+			return &domains.BlogSort{Field: "archivedAt", Desc: true}, nil
+		}
+		return &domains.BlogSort{Field: "createdAt", Desc: true}, nil
+	}
+
+	sort := &domains.BlogSort{Field: strings.TrimPrefix(req.Sort, "-")}
+	sort.Desc = sort.Field != req.Sort
+	for _, field := range constants.BLOG_SORT_FIELDS {
+		if field == sort.Field {
+			return sort, nil
+		}
+	}
+	return nil, errmsg.BlogInvalidSort
+}
+
+// blogFilter checks the filters of the list, the same filter is used for
+// listing and counting the blogs.
+func (s *blogService) blogFilter(req *domains.ListBlogRequest) (*domains.BlogFilter, error) {
+	filter := &domains.BlogFilter{
+		Archived:   req.Archived,
+		AssigneeId: req.AssigneeId,
+		AuthorId:   req.AuthorId,
+	}
+	if len(req.LabelIds) > 0 {
+		filter.LabelIds = req.LabelIds
+		filter.AllLabels = req.AllLabels
+	}
+	if req.Overdue {
+		filter.OverdueAt = time.Now().UTC()
+		filter.DoneStatuses = s.workflow.DoneStatuses
+	}
+
+	for _, status := range req.Statuses {
+		if !s.workflow.HasStatus(status) {
+			return nil, errmsg.BlogUnknownStatus
+		}
+	}
+	if len(req.Statuses) > 0 {
+		filter.Statuses = req.Statuses
+	}
+
+	if !req.CreatedFrom.IsZero() && !req.CreatedTo.IsZero() && !req.CreatedTo.After(req.CreatedFrom) {
+		return nil, errmsg.BlogInvalidDateRange
+	}
+	if !req.CreatedFrom.IsZero() {
+		filter.CreatedFrom = req.CreatedFrom.UTC()
+	}
+	if !req.CreatedTo.IsZero() {
+		filter.CreatedTo = req.CreatedTo.UTC()
+	}
+
+	filter.Title = strings.TrimSpace(req.Title)
+	if utf8.RuneCountInString(filter.Title) > constants.BLOG_TITLE_MAX_LENGTH {
+		return nil, errmsg.BlogInvalidTitle
+	}
+
+	return filter, nil
+}
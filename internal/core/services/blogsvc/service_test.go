@@ -3,12 +3,14 @@ package blogsvc_test
 import (
 	"context"
 	"errors"
+	"robinhood/config"
 	"robinhood/internal/core/constants"
 	"robinhood/internal/core/domains"
 	"robinhood/internal/core/ports"
 	"robinhood/internal/core/ports/mocks"
 	"robinhood/internal/core/services/blogsvc"
 	"robinhood/internal/errmsg"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,9 +20,13 @@ import (
 )
 
 type testModule struct {
-	br  *mocks.BlogRepository
-	ur  *mocks.UserRepository
-	svc ports.BlogService
+	br     *mocks.BlogRepository
+	bhr    *mocks.BlogHistoryRepository
+	cr     *mocks.CommentRepository
+	ur     *mocks.UserRepository
+	lr     *mocks.LabelRepository
+	mailer *mocks.Mailer
+	svc    ports.BlogService
 }
 
 type test struct {
@@ -35,13 +41,25 @@ var (
 	date = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
 )
 
+func init() {
+	config.New()
+}
+
 func new(t *testing.T) *testModule {
 	br := mocks.NewBlogRepository(t)
+	bhr := mocks.NewBlogHistoryRepository(t)
+	cr := mocks.NewCommentRepository(t)
 	ur := mocks.NewUserRepository(t)
+	lr := mocks.NewLabelRepository(t)
+	mailer := mocks.NewMailer(t)
 	return &testModule{
-		br:  br,
-		ur:  ur,
-		svc: blogsvc.New(br, ur),
+		br:     br,
+		bhr:    bhr,
+		cr:     cr,
+		ur:     ur,
+		lr:     lr,
+		mailer: mailer,
+		svc:    blogsvc.New(br, bhr, cr, ur, lr, mailer, domains.DefaultWorkflow()),
 	}
 }
 
@@ -54,6 +72,18 @@ func TestCreateBlog(t *testing.T) {
 	}
 
 	tests := []test{
+		{
+			name: "should return error when priority is unknown",
+			args: []interface{}{
+				ctx,
+				&domains.CreateBlogRequest{Title: "title", Content: "content", AuthorId: "author_id", Priority: "critical"},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogInvalidPriority, err)
+			},
+		},
 		{
 			name: "success",
 			args: []interface{}{
@@ -65,6 +95,7 @@ func TestCreateBlog(t *testing.T) {
 			},
 			assertFn: func() {
 				assert.NoError(t, err)
+				assert.Equal(t, constants.PRIORITY_MEDIUM, mockReq.Priority)
 			},
 		},
 	}
@@ -152,6 +183,7 @@ func TestCreateBlogTx(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Nil(t, err)
 				assert.NotNil(t, result)
+				assert.Equal(t, constants.TO_DO, mockReq.Status)
 			},
 		},
 	}
@@ -224,6 +256,7 @@ func TestListBlog(t *testing.T) {
 		Page:  1,
 		Limit: 10,
 	}
+	latest := &domains.BlogSort{Field: "createdAt", Desc: true}
 
 	tests := []test{
 		{
@@ -237,7 +270,7 @@ func TestListBlog(t *testing.T) {
 					Offset: int64((mockReq.Page - 1) * mockReq.Limit),
 					Limit:  int64(mockReq.Limit),
 				}
-				tm.br.On("List", ctx, listReq).Return(nil, errors.New("error"))
+				tm.br.On("List", ctx, &domains.BlogFilter{}, latest, listReq).Return(nil, errors.New("error"))
 			},
 			assertFn: func() {
 				assert.Error(t, err)
@@ -264,8 +297,8 @@ func TestListBlog(t *testing.T) {
 					Offset: int64((mockReq.Page - 1) * mockReq.Limit),
 					Limit:  int64(mockReq.Limit + 1),
 				}
-				tm.br.On("List", ctx, listReq).Return(blogs, nil)
-				tm.br.On("Count", ctx, countReq).Return(int64(0), errors.New("error"))
+				tm.br.On("List", ctx, &domains.BlogFilter{}, latest, listReq).Return(blogs, nil)
+				tm.br.On("Count", ctx, &domains.BlogFilter{}, countReq).Return(int64(0), errors.New("error"))
 			},
 			assertFn: func() {
 				assert.Error(t, err)
@@ -292,8 +325,8 @@ func TestListBlog(t *testing.T) {
 					Offset: int64((mockReq.Page - 1) * mockReq.Limit),
 					Limit:  int64(mockReq.Limit + 1),
 				}
-				tm.br.On("List", ctx, listReq).Return(blogs, nil)
-				tm.br.On("Count", ctx, countReq).Return(int64(3), nil)
+				tm.br.On("List", ctx, &domains.BlogFilter{}, latest, listReq).Return(blogs, nil)
+				tm.br.On("Count", ctx, &domains.BlogFilter{}, countReq).Return(int64(3), nil)
 			},
 			assertFn: func() {
 				assert.NoError(t, err)
@@ -302,6 +335,178 @@ func TestListBlog(t *testing.T) {
 				assert.Equal(t, result.HasNext, true)
 			},
 		},
+		{
+			name: "should list blog of the assignee",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{AssigneeId: "assignee_id"},
+			},
+			mockFn: func(tm *testModule) {
+				filter := &domains.BlogFilter{AssigneeId: "assignee_id"}
+				tm.br.On("List", ctx, filter, latest, &domains.PaginationOptions{Offset: 0, Limit: 10}).Return([]domains.PopulatedBlog{}, nil)
+				tm.br.On("Count", ctx, filter, &domains.PaginationOptions{Offset: 0, Limit: 11}).Return(int64(0), nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Empty(t, result.Data)
+			},
+		},
+		{
+			name: "should list blog having all of the labels",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{LabelIds: []string{"label_a", "label_b"}, AllLabels: true},
+			},
+			mockFn: func(tm *testModule) {
+				filter := &domains.BlogFilter{LabelIds: []string{"label_a", "label_b"}, AllLabels: true}
+				tm.br.On("List", ctx, filter, latest, &domains.PaginationOptions{Offset: 0, Limit: 10}).Return([]domains.PopulatedBlog{}, nil)
+				tm.br.On("Count", ctx, filter, &domains.PaginationOptions{Offset: 0, Limit: 11}).Return(int64(0), nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should list overdue blog which is not done",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{Overdue: true},
+			},
+			mockFn: func(tm *testModule) {
+				filter := mock.MatchedBy(func(f *domains.BlogFilter) bool {
+					return !f.OverdueAt.IsZero() && time.Since(f.OverdueAt) < time.Minute && len(f.DoneStatuses) == 1 && f.DoneStatuses[0] == constants.DONE
+				})
+				tm.br.On("List", ctx, filter, latest, &domains.PaginationOptions{Offset: 0, Limit: 10}).Return([]domains.PopulatedBlog{}, nil)
+				tm.br.On("Count", ctx, filter, &domains.PaginationOptions{Offset: 0, Limit: 11}).Return(int64(0), nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should return error when sort field is unknown",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{Sort: "-author"},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogInvalidSort, err)
+			},
+		},
+		{
+			name: "should return error when status is not in the workflow",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{Statuses: []string{constants.TO_DO, "BLOCKED"}},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogUnknownStatus, err)
+			},
+		},
+		{
+			name: "should return error when created date range ends before it starts",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{CreatedFrom: date, CreatedTo: date.AddDate(0, 0, -1)},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogInvalidDateRange, err)
+			},
+		},
+		{
+			name: "should return error when title filter is too long",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{Title: strings.Repeat("a", constants.BLOG_TITLE_MAX_LENGTH+1)},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogInvalidTitle, err)
+			},
+		},
+		{
+			name: "should list and count blog with the same filters",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{
+					Statuses:    []string{constants.TO_DO, constants.IN_PROGRESS},
+					AuthorId:    "author_id",
+					CreatedFrom: date,
+					CreatedTo:   date.AddDate(0, 1, 0),
+					Title:       "  release (v2)  ",
+				},
+			},
+			mockFn: func(tm *testModule) {
+				filter := &domains.BlogFilter{
+					Statuses:    []string{constants.TO_DO, constants.IN_PROGRESS},
+					AuthorId:    "author_id",
+					CreatedFrom: date,
+					CreatedTo:   date.AddDate(0, 1, 0),
+					Title:       "release (v2)",
+				}
+				tm.br.On("List", ctx, filter, latest, &domains.PaginationOptions{Offset: 0, Limit: 10}).Return([]domains.PopulatedBlog{{ID: primitive.NewObjectID()}}, nil)
+				tm.br.On("Count", ctx, filter, &domains.PaginationOptions{Offset: 0, Limit: 11}).Return(int64(1), nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Len(t, result.Data, 1)
+				assert.False(t, result.HasNext)
+			},
+		},
+		{
+			name: "should list blog by the highest priority first",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{Sort: "-priority"},
+			},
+			mockFn: func(tm *testModule) {
+				sort := &domains.BlogSort{Field: "priority", Desc: true}
+				tm.br.On("List", ctx, &domains.BlogFilter{}, sort, &domains.PaginationOptions{Offset: 0, Limit: 10}).Return([]domains.PopulatedBlog{}, nil)
+				tm.br.On("Count", ctx, &domains.BlogFilter{}, &domains.PaginationOptions{Offset: 0, Limit: 11}).Return(int64(0), nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should list blog by title ascending",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{Sort: "title"},
+			},
+			mockFn: func(tm *testModule) {
+				sort := &domains.BlogSort{Field: "title", Desc: false}
+				tm.br.On("List", ctx, &domains.BlogFilter{}, sort, &domains.PaginationOptions{Offset: 0, Limit: 10}).Return([]domains.PopulatedBlog{}, nil)
+				tm.br.On("Count", ctx, &domains.BlogFilter{}, &domains.PaginationOptions{Offset: 0, Limit: 11}).Return(int64(0), nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should list archived blog when archived is set",
+			args: []interface{}{
+				ctx,
+				&domains.ListBlogRequest{Archived: true},
+			},
+			mockFn: func(tm *testModule) {
+				filter := &domains.BlogFilter{Archived: true}
+				tm.br.On("List", ctx, filter, &domains.BlogSort{Field: "archivedAt", Desc: true}, &domains.PaginationOptions{Offset: 0, Limit: 10}).Return([]domains.PopulatedBlog{{ID: primitive.NewObjectID()}}, nil)
+				tm.br.On("Count", ctx, filter, &domains.PaginationOptions{Offset: 0, Limit: 11}).Return(int64(1), nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Len(t, result.Data, 1)
+				assert.False(t, result.HasNext)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -316,21 +521,69 @@ func TestListBlog(t *testing.T) {
 
 func TestUpdateBlogStatus(t *testing.T) {
 	var err error
+	authorId := primitive.NewObjectID()
+	blog := &domains.Blog{
+		ID:       primitive.NewObjectID(),
+		AuthorId: authorId,
+		Status:   constants.TO_DO,
+	}
 
 	tests := []test{
 		{
-			name: "should return error when status is invalid",
+			name: "should return error when get blog failed",
 			args: []interface{}{
 				ctx,
 				&domains.UpdateBlogStatusRequest{
 					BlogId: "blog_id",
-					Status: "some text",
+					Status: constants.IN_PROGRESS,
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.EqualError(t, err, errmsg.BlogGetFailed.Error())
+			},
+		},
+		{
+			name: "should return error when blog not found",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.IN_PROGRESS,
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.EqualError(t, err, errmsg.BlogNotFound.Error())
+			},
+		},
+		{
+			name: "should return forbidden when user is not the author",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.IN_PROGRESS,
+					UserId: primitive.NewObjectID().Hex(),
+					Role:   constants.ROLE_EDITOR,
 				},
 			},
-			mockFn: nil,
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
 			assertFn: func() {
 				assert.Error(t, err)
-				assert.EqualError(t, err, errmsg.BlogInvalidStatus.Error())
+				assert.Equal(t, errmsg.Forbidden, err)
 			},
 		},
 		{
@@ -340,18 +593,23 @@ func TestUpdateBlogStatus(t *testing.T) {
 				&domains.UpdateBlogStatusRequest{
 					BlogId: "blog_id",
 					Status: constants.IN_PROGRESS,
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
 				},
 			},
 			mockFn: func(tm *testModule) {
 				req := &domains.UpdateBlogStatusRequest{
 					BlogId: "blog_id",
 					Status: constants.IN_PROGRESS,
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
 				}
-				tm.br.On("UpdateStatus", ctx, req).Return(errors.New("error"))
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("UpdateStatusTx", ctx, req, mock.Anything).Return(errmsg.BlogUpdateFailed)
 			},
 			assertFn: func() {
 				assert.Error(t, err)
-				assert.EqualError(t, err, "error")
+				assert.Equal(t, errmsg.BlogUpdateFailed, err)
 			},
 		},
 		{
@@ -360,21 +618,172 @@ func TestUpdateBlogStatus(t *testing.T) {
 				ctx,
 				&domains.UpdateBlogStatusRequest{
 					BlogId: "blog_id",
-					Status: constants.DONE,
+					Status: constants.IN_PROGRESS,
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
 				},
 			},
 			mockFn: func(tm *testModule) {
 				req := &domains.UpdateBlogStatusRequest{
 					BlogId: "blog_id",
-					Status: constants.DONE,
+					Status: constants.IN_PROGRESS,
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
+				}
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("UpdateStatusTx", ctx, req, mock.Anything).Return(nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "should let admin update blog status of other author",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.IN_PROGRESS,
+					UserId: "admin_id",
+					Role:   constants.ROLE_ADMIN,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				req := &domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.IN_PROGRESS,
+					UserId: "admin_id",
+					Role:   constants.ROLE_ADMIN,
 				}
-				tm.br.On("UpdateStatus", ctx, req).Return(nil)
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("UpdateStatusTx", ctx, req, mock.Anything).Return(nil)
 			},
 			assertFn: func() {
 				assert.NoError(t, err)
 				assert.Nil(t, err)
 			},
 		},
+		{
+			name: "should return error when status is not in the workflow",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: "some text",
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				var transition *errmsg.BlogInvalidTransitionError
+				assert.ErrorAs(t, err, &transition)
+				assert.Equal(t, errmsg.BlogInvalidTransition.Code, transition.Code)
+				assert.Equal(t, []string{constants.IN_PROGRESS}, transition.Allowed)
+			},
+		},
+		{
+			name: "should return allowed statuses when transition is not in the workflow",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.DONE,
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				var transition *errmsg.BlogInvalidTransitionError
+				assert.ErrorAs(t, err, &transition)
+				assert.Equal(t, constants.TO_DO, transition.From)
+				assert.Equal(t, constants.DONE, transition.To)
+				assert.Equal(t, []string{constants.IN_PROGRESS}, transition.Allowed)
+			},
+		},
+		{
+			name: "should return error when role can't make the transition",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.IN_PROGRESS,
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(&domains.Blog{AuthorId: authorId, Status: constants.DONE}, nil)
+			},
+			assertFn: func() {
+				var transition *errmsg.BlogInvalidTransitionError
+				assert.ErrorAs(t, err, &transition)
+				assert.Empty(t, transition.Allowed)
+			},
+		},
+		{
+			name: "should let editor reopen a done blog",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.IN_PROGRESS,
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_EDITOR,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(&domains.Blog{AuthorId: authorId, Status: constants.DONE}, nil)
+				tm.br.On("UpdateStatusTx", ctx, mock.Anything, mock.Anything).Return(nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should let editor who is not the author reopen a done blog",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.IN_PROGRESS,
+					UserId: primitive.NewObjectID().Hex(),
+					Role:   constants.ROLE_EDITOR,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(&domains.Blog{AuthorId: authorId, Status: constants.DONE}, nil)
+				tm.br.On("UpdateStatusTx", ctx, mock.Anything, mock.Anything).Return(nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should return forbidden when member who is not the author reopens a done blog",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.IN_PROGRESS,
+					UserId: primitive.NewObjectID().Hex(),
+					Role:   constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(&domains.Blog{AuthorId: authorId, Status: constants.DONE}, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.Forbidden, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -389,20 +798,1274 @@ func TestUpdateBlogStatus(t *testing.T) {
 	}
 }
 
-func TestArchiveBlog(t *testing.T) {
+func TestUpdateBlogStatusTx(t *testing.T) {
 	var err error
-	mockReq := &domains.ArchiveBlogRequest{
+	authorId := primitive.NewObjectID()
+	mockReq := &domains.UpdateBlogStatusRequest{
 		BlogId: "blog_id",
+		Status: constants.DONE,
+		UserId: authorId.Hex(),
+		Role:   constants.ROLE_MEMBER,
 	}
 
 	tests := []test{
 		{
-			name: "should return error when archive blog failed",
+			name: "should return error when update blog status failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("UpdateStatus", ctx, mockReq).Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogUpdateFailed, err)
+			},
+		},
+		{
+			name: "should return error when record history failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("UpdateStatus", ctx, mockReq).Return(&domains.Blog{AuthorId: authorId, Status: constants.IN_PROGRESS}, nil)
+				tm.bhr.On("Create", ctx, mock.Anything).Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogUpdateFailed, err)
+			},
+		},
+		{
+			name: "should return error when status changed before the transition",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("UpdateStatus", ctx, mockReq).Return(&domains.Blog{AuthorId: authorId, Status: constants.TO_DO}, nil)
+			},
+			assertFn: func() {
+				var transition *errmsg.BlogInvalidTransitionError
+				assert.ErrorAs(t, err, &transition)
+				assert.Equal(t, constants.TO_DO, transition.From)
+			},
+		},
+		{
+			name: "should return forbidden when editor reopened a blog which is not done anymore",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateBlogStatusRequest{
+					BlogId: "blog_id",
+					Status: constants.IN_PROGRESS,
+					UserId: primitive.NewObjectID().Hex(),
+					Role:   constants.ROLE_EDITOR,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("UpdateStatus", ctx, mock.Anything).Return(&domains.Blog{AuthorId: authorId, Status: constants.TO_DO}, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.Forbidden, err)
+			},
+		},
+		{
+			name: "should not record history when status is the same",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("UpdateStatus", ctx, mockReq).Return(&domains.Blog{AuthorId: authorId, Status: constants.DONE}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should update blog status and record history success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("UpdateStatus", ctx, mockReq).Return(&domains.Blog{AuthorId: authorId, Status: constants.IN_PROGRESS}, nil)
+				tm.bhr.On("Create", ctx, &domains.CreateBlogHistoryRequest{
+					BlogId:  "blog_id",
+					From:    constants.IN_PROGRESS,
+					To:      constants.DONE,
+					ActorId: authorId.Hex(),
+				}).Return(&domains.BlogHistory{}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			err = tm.svc.UpdateBlogStatusTx(tt.args[0].(context.Context), tt.args[1].(*domains.UpdateBlogStatusRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestListBlogHistory(t *testing.T) {
+	var err error
+	var result []domains.PopulatedBlogHistory
+	blog := &domains.Blog{
+		ID:       primitive.NewObjectID(),
+		AuthorId: primitive.NewObjectID(),
+	}
+	history := []domains.PopulatedBlogHistory{
+		{ID: primitive.NewObjectID(), BlogId: blog.ID, From: constants.TO_DO, To: constants.IN_PROGRESS, CreatedAt: date},
+	}
+
+	tests := []test{
+		{
+			name: "should return error when get blog failed",
+			args: []interface{}{
+				ctx,
+				"blog_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogGetFailed, err)
+			},
+		},
+		{
+			name: "should return error when blog not found",
+			args: []interface{}{
+				ctx,
+				"blog_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogNotFound, err)
+			},
+		},
+		{
+			name: "should return error when list history failed",
+			args: []interface{}{
+				ctx,
+				"blog_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.bhr.On("ListByBlogID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogHistoryListFailed, err)
+			},
+		},
+		{
+			name: "should list blog history success",
+			args: []interface{}{
+				ctx,
+				"blog_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.bhr.On("ListByBlogID", ctx, "blog_id").Return(history, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, history, result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.ListBlogHistory(tt.args[0].(context.Context), tt.args[1].(string))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestEditBlog(t *testing.T) {
+	var err error
+	var result *domains.PopulatedBlog
+	authorId := primitive.NewObjectID()
+	blog := &domains.Blog{
+		ID:       primitive.NewObjectID(),
+		AuthorId: authorId,
+	}
+	title := "new title"
+	content := "new content"
+	mockReq := &domains.EditBlogRequest{
+		BlogId:  "blog_id",
+		Title:   &title,
+		Content: &content,
+		UserId:  authorId.Hex(),
+	}
+	populated := &domains.PopulatedBlog{
+		ID:        blog.ID,
+		Title:     title,
+		Content:   content,
+		UpdatedAt: date,
+		UpdatedBy: authorId,
+	}
+	ptr := func(s string) *string { return &s }
+
+	tests := []test{
+		{
+			name: "should return error when nothing is edited",
+			args: []interface{}{
+				ctx,
+				&domains.EditBlogRequest{BlogId: "blog_id", UserId: authorId.Hex()},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogEditEmpty, err)
+			},
+		},
+		{
+			name: "should return error when title is blank",
+			args: []interface{}{
+				ctx,
+				&domains.EditBlogRequest{BlogId: "blog_id", Title: ptr("   "), UserId: authorId.Hex()},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogInvalidTitle, err)
+			},
+		},
+		{
+			name: "should return error when title is too long",
+			args: []interface{}{
+				ctx,
+				&domains.EditBlogRequest{BlogId: "blog_id", Title: ptr(strings.Repeat("a", constants.BLOG_TITLE_MAX_LENGTH+1)), UserId: authorId.Hex()},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogInvalidTitle, err)
+			},
+		},
+		{
+			name: "should return error when content is blank",
+			args: []interface{}{
+				ctx,
+				&domains.EditBlogRequest{BlogId: "blog_id", Content: ptr("\n"), UserId: authorId.Hex()},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogInvalidContent, err)
+			},
+		},
+		{
+			name: "should return error when content is too long",
+			args: []interface{}{
+				ctx,
+				&domains.EditBlogRequest{BlogId: "blog_id", Content: ptr(strings.Repeat("ก", constants.BLOG_CONTENT_MAX_LENGTH+1)), UserId: authorId.Hex()},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogInvalidContent, err)
+			},
+		},
+		{
+			name: "should return error when priority is unknown",
+			args: []interface{}{
+				ctx,
+				&domains.EditBlogRequest{BlogId: "blog_id", Priority: ptr("critical"), UserId: authorId.Hex()},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogInvalidPriority, err)
+			},
+		},
+		{
+			name: "should return error when get blog failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogGetFailed, err)
+			},
+		},
+		{
+			name: "should return error when blog not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogNotFound, err)
+			},
+		},
+		{
+			name: "should return forbidden when user is not the author",
+			args: []interface{}{
+				ctx,
+				&domains.EditBlogRequest{BlogId: "blog_id", Title: &title, UserId: primitive.NewObjectID().Hex()},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.Forbidden, err)
+			},
+		},
+		{
+			name: "should return error when edit failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Edit", ctx, mockReq).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogUpdateFailed, err)
+			},
+		},
+		{
+			name: "should return error when get populated blog failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Edit", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogGetFailed, err)
+			},
+		},
+		{
+			name: "should edit only the title and trim it",
+			args: []interface{}{
+				ctx,
+				&domains.EditBlogRequest{BlogId: "blog_id", Title: ptr("  new title  "), UserId: authorId.Hex()},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Edit", ctx, mock.MatchedBy(func(req *domains.EditBlogRequest) bool {
+					return req.Title != nil && *req.Title == title && req.Content == nil
+				})).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(populated, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, populated, result)
+			},
+		},
+		{
+			name: "should edit only the priority",
+			args: []interface{}{
+				ctx,
+				&domains.EditBlogRequest{BlogId: "blog_id", Priority: ptr(constants.PRIORITY_URGENT), UserId: authorId.Hex()},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Edit", ctx, mock.MatchedBy(func(req *domains.EditBlogRequest) bool {
+					return req.Title == nil && req.Content == nil && *req.Priority == constants.PRIORITY_URGENT
+				})).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{Priority: 4}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, constants.PRIORITY_URGENT, result.Priority.String())
+			},
+		},
+		{
+			name: "should edit blog success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Edit", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(populated, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, populated, result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.EditBlog(tt.args[0].(context.Context), tt.args[1].(*domains.EditBlogRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestSetBlogDue(t *testing.T) {
+	var result *domains.PopulatedBlog
+	var err error
+	authorId := primitive.NewObjectID()
+	blog := &domains.Blog{
+		ID:       primitive.NewObjectID(),
+		AuthorId: authorId,
+	}
+	mockReq := &domains.SetBlogDueRequest{
+		BlogId: "blog_id",
+		DueAt:  &date,
+		UserId: authorId.Hex(),
+		Role:   constants.ROLE_MEMBER,
+	}
+
+	tests := []test{
+		{
+			name: "should return forbidden when user is not the author",
+			args: []interface{}{
+				ctx,
+				&domains.SetBlogDueRequest{
+					BlogId: "blog_id",
+					DueAt:  &date,
+					UserId: primitive.NewObjectID().Hex(),
+					Role:   constants.ROLE_EDITOR,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.Forbidden, err)
+			},
+		},
+		{
+			name: "should return error when set due date failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("SetDue", ctx, mockReq).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogUpdateFailed, err)
+			},
+		},
+		{
+			name: "should return error when get blog failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("SetDue", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogGetFailed, err)
+			},
+		},
+		{
+			name: "should set due date success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("SetDue", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{DueAt: date}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, date, result.DueAt)
+			},
+		},
+		{
+			name: "should remove due date success",
+			args: []interface{}{
+				ctx,
+				&domains.SetBlogDueRequest{
+					BlogId: "blog_id",
+					UserId: authorId.Hex(),
+					Role:   constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("SetDue", ctx, mock.MatchedBy(func(req *domains.SetBlogDueRequest) bool {
+					return req.DueAt == nil
+				})).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.True(t, result.DueAt.IsZero())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.SetBlogDue(tt.args[0].(context.Context), tt.args[1].(*domains.SetBlogDueRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestSendDueReminders(t *testing.T) {
+	var result int64
+	var err error
+	authorId := primitive.NewObjectID()
+	assigneeId := primitive.NewObjectID()
+	dueSoon := mock.MatchedBy(func(req *domains.ListBlogReminderRequest) bool {
+		return req.Kind == constants.BLOG_REMINDER_DUE_SOON && req.DueBefore.Sub(req.DueAfter) == time.Duration(config.Get().Blog.DueReminderMinutes)*time.Minute
+	})
+	overdue := mock.MatchedBy(func(req *domains.ListBlogReminderRequest) bool {
+		return req.Kind == constants.BLOG_REMINDER_OVERDUE && req.DueAfter.IsZero() && req.Limit == constants.BLOG_REMINDER_BATCH_SIZE
+	})
+	author := &domains.User{ID: authorId, Username: "author", Email: "author@mail.com"}
+	assignee := &domains.User{ID: assigneeId, Username: "assignee", Email: "assignee@mail.com"}
+
+	tests := []test{
+		{
+			name: "should return error when list blogs due soon failed",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("ListForReminder", ctx, dueSoon).Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogReminderFailed, err)
+			},
+		},
+		{
+			name: "should return error when mark reminded failed",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				blog := domains.Blog{ID: primitive.NewObjectID(), AuthorId: authorId}
+				tm.br.On("ListForReminder", ctx, dueSoon).Return([]domains.Blog{blog}, nil)
+				tm.br.On("MarkReminded", ctx, blog.ID.Hex(), constants.BLOG_REMINDER_DUE_SOON).Return(false, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogReminderFailed, err)
+			},
+		},
+		{
+			name: "should not send reminder which another run has sent",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				blog := domains.Blog{ID: primitive.NewObjectID(), AuthorId: authorId}
+				tm.br.On("ListForReminder", ctx, dueSoon).Return([]domains.Blog{blog}, nil)
+				tm.br.On("MarkReminded", ctx, blog.ID.Hex(), constants.BLOG_REMINDER_DUE_SOON).Return(false, nil)
+				tm.br.On("ListForReminder", ctx, overdue).Return([]domains.Blog{}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "should remind the author when blog has no assignee",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				blog := domains.Blog{ID: primitive.NewObjectID(), Title: "title", AuthorId: authorId, DueAt: date}
+				tm.br.On("ListForReminder", ctx, dueSoon).Return([]domains.Blog{blog}, nil)
+				tm.br.On("MarkReminded", ctx, blog.ID.Hex(), constants.BLOG_REMINDER_DUE_SOON).Return(true, nil)
+				tm.ur.On("GetByID", ctx, authorId).Return(author, nil)
+				tm.mailer.On("Send", ctx, mock.MatchedBy(func(mail *domains.Mail) bool {
+					return mail.To == author.Email && mail.Subject == `"title" is due soon`
+				})).Return(nil)
+				tm.br.On("ListForReminder", ctx, overdue).Return([]domains.Blog{}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "should remind the assignees when blog is overdue",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				deactivatedId := primitive.NewObjectID()
+				blog := domains.Blog{ID: primitive.NewObjectID(), Title: "title", AuthorId: authorId, AssigneeIds: []primitive.ObjectID{assigneeId, deactivatedId}, DueAt: date}
+				tm.br.On("ListForReminder", ctx, dueSoon).Return([]domains.Blog{}, nil)
+				tm.br.On("ListForReminder", ctx, overdue).Return([]domains.Blog{blog}, nil)
+				tm.br.On("MarkReminded", ctx, blog.ID.Hex(), constants.BLOG_REMINDER_OVERDUE).Return(true, nil)
+				tm.ur.On("GetByID", ctx, assigneeId).Return(assignee, nil)
+				tm.ur.On("GetByID", ctx, deactivatedId).Return(&domains.User{ID: deactivatedId, Deactivated: true}, nil)
+				tm.mailer.On("Send", ctx, mock.MatchedBy(func(mail *domains.Mail) bool {
+					return mail.To == assignee.Email && mail.Subject == `"title" is overdue`
+				})).Return(nil).Once()
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "should count reminder when send email failed",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				blog := domains.Blog{ID: primitive.NewObjectID(), AuthorId: authorId, DueAt: date}
+				tm.br.On("ListForReminder", ctx, dueSoon).Return([]domains.Blog{}, nil)
+				tm.br.On("ListForReminder", ctx, overdue).Return([]domains.Blog{blog}, nil)
+				tm.br.On("MarkReminded", ctx, blog.ID.Hex(), constants.BLOG_REMINDER_OVERDUE).Return(true, nil)
+				tm.ur.On("GetByID", ctx, authorId).Return(author, nil)
+				tm.mailer.On("Send", ctx, mock.Anything).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.SendDueReminders(tt.args[0].(context.Context))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestAttachLabel(t *testing.T) {
+	var result *domains.PopulatedBlog
+	var err error
+	authorId := primitive.NewObjectID()
+	label := &domains.Label{ID: primitive.NewObjectID(), Name: "bug", Color: "#d73a4a"}
+	blog := &domains.Blog{
+		ID:       primitive.NewObjectID(),
+		AuthorId: authorId,
+	}
+	mockReq := &domains.AttachLabelRequest{
+		BlogId:  "blog_id",
+		LabelId: "label_id",
+		UserId:  authorId.Hex(),
+		Role:    constants.ROLE_MEMBER,
+	}
+
+	tests := []test{
+		{
+			name: "should return error when blog not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogNotFound, err)
+			},
+		},
+		{
+			name: "should return forbidden when user is not the author",
+			args: []interface{}{
+				ctx,
+				&domains.AttachLabelRequest{
+					BlogId:  "blog_id",
+					LabelId: "label_id",
+					UserId:  primitive.NewObjectID().Hex(),
+					Role:    constants.ROLE_EDITOR,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.Forbidden, err)
+			},
+		},
+		{
+			name: "should return error when get label failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.lr.On("GetByID", ctx, "label_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.LabelGetFailed, err)
+			},
+		},
+		{
+			name: "should return error when label not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.lr.On("GetByID", ctx, "label_id").Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.LabelNotFound, err)
+			},
+		},
+		{
+			name: "should return error when blog has too many labels",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				full := *blog
+				full.LabelIds = make([]primitive.ObjectID, constants.BLOG_MAX_LABELS)
+				for i := range full.LabelIds {
+					full.LabelIds[i] = primitive.NewObjectID()
+				}
+				tm.br.On("GetByID", ctx, "blog_id").Return(&full, nil)
+				tm.lr.On("GetByID", ctx, "label_id").Return(label, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogTooManyLabels, err)
+			},
+		},
+		{
+			name: "should return error when attach label failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.lr.On("GetByID", ctx, "label_id").Return(label, nil)
+				tm.br.On("AttachLabel", ctx, mockReq).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogUpdateFailed, err)
+			},
+		},
+		{
+			name: "should not attach label twice",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				labeled := *blog
+				labeled.LabelIds = []primitive.ObjectID{label.ID}
+				tm.br.On("GetByID", ctx, "blog_id").Return(&labeled, nil)
+				tm.lr.On("GetByID", ctx, "label_id").Return(label, nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{Labels: []domains.Label{*label}}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Len(t, result.Labels, 1)
+			},
+		},
+		{
+			name: "should attach label success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.lr.On("GetByID", ctx, "label_id").Return(label, nil)
+				tm.br.On("AttachLabel", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{Labels: []domains.Label{*label}}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, "bug", result.Labels[0].Name)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.AttachLabel(tt.args[0].(context.Context), tt.args[1].(*domains.AttachLabelRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestDetachLabel(t *testing.T) {
+	var result *domains.PopulatedBlog
+	var err error
+	authorId := primitive.NewObjectID()
+	blog := &domains.Blog{
+		ID:       primitive.NewObjectID(),
+		AuthorId: authorId,
+	}
+	mockReq := &domains.DetachLabelRequest{
+		BlogId:  "blog_id",
+		LabelId: "label_id",
+		UserId:  primitive.NewObjectID().Hex(),
+		Role:    constants.ROLE_ADMIN,
+	}
+
+	tests := []test{
+		{
+			name: "should return error when get blog failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogGetFailed, err)
+			},
+		},
+		{
+			name: "should return error when detach label failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("DetachLabel", ctx, mockReq).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogUpdateFailed, err)
+			},
+		},
+		{
+			name: "should let admin detach label",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("DetachLabel", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Empty(t, result.Labels)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.DetachLabel(tt.args[0].(context.Context), tt.args[1].(*domains.DetachLabelRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestAssignBlog(t *testing.T) {
+	var result *domains.PopulatedBlog
+	var err error
+	authorId := primitive.NewObjectID()
+	assigneeId := primitive.NewObjectID()
+	blog := &domains.Blog{
+		ID:       primitive.NewObjectID(),
+		AuthorId: authorId,
+	}
+	mockReq := &domains.AssignBlogRequest{
+		BlogId:     "blog_id",
+		AssigneeId: assigneeId.Hex(),
+		UserId:     authorId.Hex(),
+		Role:       constants.ROLE_MEMBER,
+	}
+	assignee := &domains.User{ID: assigneeId, Username: "assignee"}
+
+	tests := []test{
+		{
+			name: "should return error when blog not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogNotFound, err)
+			},
+		},
+		{
+			name: "should return forbidden when user is not the author",
+			args: []interface{}{
+				ctx,
+				&domains.AssignBlogRequest{
+					BlogId:     "blog_id",
+					AssigneeId: assigneeId.Hex(),
+					UserId:     primitive.NewObjectID().Hex(),
+					Role:       constants.ROLE_EDITOR,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.Forbidden, err)
+			},
+		},
+		{
+			name: "should return error when assignee id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.AssignBlogRequest{
+					BlogId:     "blog_id",
+					AssigneeId: "invalid",
+					UserId:     authorId.Hex(),
+					Role:       constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "should return error when get assignee failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.ur.On("GetByID", ctx, assigneeId).Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogAssignFailed, err)
+			},
+		},
+		{
+			name: "should return error when assignee is deactivated",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.ur.On("GetByID", ctx, assigneeId).Return(&domains.User{ID: assigneeId, Deactivated: true}, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "should return error when blog has too many assignees",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				full := &domains.Blog{AuthorId: authorId}
+				for i := 0; i < constants.BLOG_MAX_ASSIGNEES; i++ {
+					full.AssigneeIds = append(full.AssigneeIds, primitive.NewObjectID())
+				}
+				tm.br.On("GetByID", ctx, "blog_id").Return(full, nil)
+				tm.ur.On("GetByID", ctx, assigneeId).Return(assignee, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogTooManyAssignees, err)
+			},
+		},
+		{
+			name: "should return error when assign failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.ur.On("GetByID", ctx, assigneeId).Return(assignee, nil)
+				tm.br.On("Assign", ctx, mockReq).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogAssignFailed, err)
+			},
+		},
+		{
+			name: "should not assign again when user is already assigned",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				assigned := &domains.Blog{AuthorId: authorId, AssigneeIds: []primitive.ObjectID{assigneeId}}
+				tm.br.On("GetByID", ctx, "blog_id").Return(assigned, nil)
+				tm.ur.On("GetByID", ctx, assigneeId).Return(assignee, nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{Assignees: []domains.User{*assignee}}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Len(t, result.Assignees, 1)
+			},
+		},
+		{
+			name: "should assign blog success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.ur.On("GetByID", ctx, assigneeId).Return(assignee, nil)
+				tm.br.On("Assign", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{Assignees: []domains.User{*assignee}}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, assigneeId, result.Assignees[0].ID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.AssignBlog(tt.args[0].(context.Context), tt.args[1].(*domains.AssignBlogRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestUnassignBlog(t *testing.T) {
+	var result *domains.PopulatedBlog
+	var err error
+	authorId := primitive.NewObjectID()
+	assigneeId := primitive.NewObjectID()
+	blog := &domains.Blog{
+		ID:          primitive.NewObjectID(),
+		AuthorId:    authorId,
+		AssigneeIds: []primitive.ObjectID{assigneeId},
+	}
+	mockReq := &domains.UnassignBlogRequest{
+		BlogId:     "blog_id",
+		AssigneeId: assigneeId.Hex(),
+		UserId:     authorId.Hex(),
+		Role:       constants.ROLE_MEMBER,
+	}
+
+	tests := []test{
+		{
+			name: "should return error when get blog failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogGetFailed, err)
+			},
+		},
+		{
+			name: "should return error when blog not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogNotFound, err)
+			},
+		},
+		{
+			name: "should return forbidden when user is neither the author nor the assignee",
+			args: []interface{}{
+				ctx,
+				&domains.UnassignBlogRequest{
+					BlogId:     "blog_id",
+					AssigneeId: assigneeId.Hex(),
+					UserId:     primitive.NewObjectID().Hex(),
+					Role:       constants.ROLE_EDITOR,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.Forbidden, err)
+			},
+		},
+		{
+			name: "should return error when unassign failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Unassign", ctx, mockReq).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogAssignFailed, err)
+			},
+		},
+		{
+			name: "should let assignee unassign itself",
+			args: []interface{}{
+				ctx,
+				&domains.UnassignBlogRequest{
+					BlogId:     "blog_id",
+					AssigneeId: assigneeId.Hex(),
+					UserId:     assigneeId.Hex(),
+					Role:       constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Unassign", ctx, mock.Anything).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Empty(t, result.Assignees)
+			},
+		},
+		{
+			name: "should unassign blog success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Unassign", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.UnassignBlog(tt.args[0].(context.Context), tt.args[1].(*domains.UnassignBlogRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestArchiveBlog(t *testing.T) {
+	var err error
+	authorId := primitive.NewObjectID()
+	blog := &domains.Blog{
+		ID:       primitive.NewObjectID(),
+		AuthorId: authorId,
+	}
+	mockReq := &domains.ArchiveBlogRequest{
+		BlogId: "blog_id",
+		UserId: authorId.Hex(),
+		Role:   constants.ROLE_MEMBER,
+	}
+
+	tests := []test{
+		{
+			name: "should return forbidden when user is not the author",
+			args: []interface{}{
+				ctx,
+				&domains.ArchiveBlogRequest{
+					BlogId: "blog_id",
+					UserId: primitive.NewObjectID().Hex(),
+					Role:   constants.ROLE_MEMBER,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.Forbidden, err)
+			},
+		},
+		{
+			name: "should return error when archive blog failed",
 			args: []interface{}{
 				ctx,
 				mockReq,
 			},
 			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
 				tm.br.On("Archive", ctx, mockReq).Return(errors.New("error"))
 			},
 			assertFn: func() {
@@ -417,6 +2080,7 @@ func TestArchiveBlog(t *testing.T) {
 				mockReq,
 			},
 			mockFn: func(tm *testModule) {
+				tm.br.On("GetByID", ctx, "blog_id").Return(blog, nil)
 				tm.br.On("Archive", ctx, mockReq).Return(nil)
 			},
 			assertFn: func() {
@@ -435,3 +2099,330 @@ func TestArchiveBlog(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreBlog(t *testing.T) {
+	var result *domains.PopulatedBlog
+	var err error
+	authorId := primitive.NewObjectID()
+	blog := &domains.Blog{
+		ID:         primitive.NewObjectID(),
+		AuthorId:   authorId,
+		IsArchived: true,
+		ArchivedAt: date,
+		ArchivedBy: authorId,
+	}
+	mockReq := &domains.RestoreBlogRequest{
+		BlogId: "blog_id",
+		UserId: authorId.Hex(),
+		Role:   constants.ROLE_MEMBER,
+	}
+
+	tests := []test{
+		{
+			name: "should return error when get archived blog failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetArchivedByID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogGetFailed, err)
+			},
+		},
+		{
+			name: "should return error when blog is not archived",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetArchivedByID", ctx, "blog_id").Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogNotFound, err)
+			},
+		},
+		{
+			name: "should return forbidden when user is not the author",
+			args: []interface{}{
+				ctx,
+				&domains.RestoreBlogRequest{
+					BlogId: "blog_id",
+					UserId: primitive.NewObjectID().Hex(),
+					Role:   constants.ROLE_EDITOR,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetArchivedByID", ctx, "blog_id").Return(blog, nil)
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.Forbidden, err)
+			},
+		},
+		{
+			name: "should return error when restore blog failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetArchivedByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Restore", ctx, mockReq).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogRestoreFailed, err)
+			},
+		},
+		{
+			name: "should return error when get restored blog failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetArchivedByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Restore", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogGetFailed, err)
+			},
+		},
+		{
+			name: "should let admin restore blog of other author",
+			args: []interface{}{
+				ctx,
+				&domains.RestoreBlogRequest{
+					BlogId: "blog_id",
+					UserId: "admin_id",
+					Role:   constants.ROLE_ADMIN,
+				},
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetArchivedByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Restore", ctx, mock.Anything).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{ID: blog.ID}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, blog.ID, result.ID)
+			},
+		},
+		{
+			name: "should restore blog success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("GetArchivedByID", ctx, "blog_id").Return(blog, nil)
+				tm.br.On("Restore", ctx, mockReq).Return(nil)
+				tm.br.On("GetPopulatedBlogByID", ctx, "blog_id").Return(&domains.PopulatedBlog{ID: blog.ID}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.False(t, result.IsArchived)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.RestoreBlog(tt.args[0].(context.Context), tt.args[1].(*domains.RestoreBlogRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestPurgeArchivedBlogs(t *testing.T) {
+	var result int64
+	var err error
+	retention := time.Duration(config.Get().Blog.ArchiveRetentionDays) * 24 * time.Hour
+	purgeReq := mock.MatchedBy(func(req *domains.PurgeArchivedBlogsRequest) bool {
+		before := time.Now().UTC().Add(-retention)
+		return req.Limit == constants.BLOG_PURGE_BATCH_SIZE && before.Sub(req.ArchivedBefore) < time.Minute && !req.ArchivedBefore.After(before)
+	})
+
+	tests := []test{
+		{
+			name: "should return error when purge failed",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("PurgeTx", ctx, purgeReq, mock.Anything).Return(int64(0), errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogPurgeFailed, err)
+			},
+		},
+		{
+			name: "should stop when a batch is not full",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("PurgeTx", ctx, purgeReq, mock.Anything).Return(int64(3), nil).Once()
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(3), result)
+			},
+		},
+		{
+			name: "should purge the next batch when a batch is full",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("PurgeTx", ctx, purgeReq, mock.Anything).Return(int64(constants.BLOG_PURGE_BATCH_SIZE), nil).Once()
+				tm.br.On("PurgeTx", ctx, purgeReq, mock.Anything).Return(int64(0), nil).Once()
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(constants.BLOG_PURGE_BATCH_SIZE), result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.PurgeArchivedBlogs(tt.args[0].(context.Context))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestPurgeArchivedBlogsTx(t *testing.T) {
+	var result int64
+	var err error
+	mockReq := &domains.PurgeArchivedBlogsRequest{
+		ArchivedBefore: date,
+		Limit:          constants.BLOG_PURGE_BATCH_SIZE,
+	}
+	ids := []string{"blog_id_1", "blog_id_2"}
+
+	tests := []test{
+		{
+			name: "should return error when list archived blog failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("ListArchivedIDs", ctx, mockReq).Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogPurgeFailed, err)
+			},
+		},
+		{
+			name: "should do nothing when no blog is past the retention",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("ListArchivedIDs", ctx, mockReq).Return([]string{}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "should return error when delete comments failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("ListArchivedIDs", ctx, mockReq).Return(ids, nil)
+				tm.cr.On("DeleteByBlogIDs", ctx, ids).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogPurgeFailed, err)
+			},
+		},
+		{
+			name: "should return error when delete history failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("ListArchivedIDs", ctx, mockReq).Return(ids, nil)
+				tm.cr.On("DeleteByBlogIDs", ctx, ids).Return(nil)
+				tm.bhr.On("DeleteByBlogIDs", ctx, ids).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogPurgeFailed, err)
+			},
+		},
+		{
+			name: "should return error when delete blogs failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("ListArchivedIDs", ctx, mockReq).Return(ids, nil)
+				tm.cr.On("DeleteByBlogIDs", ctx, ids).Return(nil)
+				tm.bhr.On("DeleteByBlogIDs", ctx, ids).Return(nil)
+				tm.br.On("DeleteByIDs", ctx, ids).Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.BlogPurgeFailed, err)
+			},
+		},
+		{
+			name: "should delete blogs with their comments and history success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(tm *testModule) {
+				tm.br.On("ListArchivedIDs", ctx, mockReq).Return(ids, nil)
+				tm.cr.On("DeleteByBlogIDs", ctx, ids).Return(nil)
+				tm.bhr.On("DeleteByBlogIDs", ctx, ids).Return(nil)
+				tm.br.On("DeleteByIDs", ctx, ids).Return(nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(2), result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.PurgeArchivedBlogsTx(tt.args[0].(context.Context), tt.args[1].(*domains.PurgeArchivedBlogsRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestGetWorkflow(t *testing.T) {
+	tm := new(t)
+	workflow := tm.svc.GetWorkflow(ctx)
+	assert.Equal(t, domains.DefaultWorkflow(), workflow)
+	assert.NoError(t, workflow.Validate())
+}
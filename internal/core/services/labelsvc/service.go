@@ -0,0 +1,144 @@
+package labelsvc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"robinhood/internal/core/constants"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"robinhood/internal/errmsg"
+	"strings"
+	"unicode/utf8"
+)
+
+type labelService struct {
+	lr ports.LabelRepository
+	br ports.BlogRepository
+}
+
+func New(lr ports.LabelRepository, br ports.BlogRepository) ports.LabelService {
+	return &labelService{lr: lr, br: br}
+}
+
+func (s *labelService) CreateLabel(ctx context.Context, req *domains.CreateLabelRequest) (*domains.Label, error) {
+	name, err := labelName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	color, err := labelColor(req.Color)
+	if err != nil {
+		return nil, err
+	}
+	req.Name, req.Color = name, color
+
+	label, err := s.lr.Create(ctx, req)
+	if err != nil {
+		if errors.Is(err, domains.ErrLabelNameTaken) {
+			return nil, errmsg.LabelExisted
+		}
+		log.Printf("[labelService::CreateLabel::Create] error => %+v", err)
+		return nil, errmsg.LabelCreateFailed
+	}
+
+	return label, nil
+}
+
+func (s *labelService) ListLabels(ctx context.Context) ([]domains.Label, error) {
+	labels, err := s.lr.List(ctx)
+	if err != nil {
+		log.Printf("[labelService::ListLabels::List] error => %+v", err)
+		return nil, errmsg.LabelListFailed
+	}
+	return labels, nil
+}
+
+func (s *labelService) UpdateLabel(ctx context.Context, req *domains.UpdateLabelRequest) (*domains.Label, error) {
+	if req.Name == nil && req.Color == nil {
+		return nil, errmsg.LabelUpdateEmpty
+	}
+
+	if req.Name != nil {
+		name, err := labelName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		req.Name = &name
+	}
+
+	if req.Color != nil {
+		color, err := labelColor(*req.Color)
+		if err != nil {
+			return nil, err
+		}
+		req.Color = &color
+	}
+
+	label, err := s.lr.Update(ctx, req)
+	if err != nil {
+		if errors.Is(err, domains.ErrLabelNameTaken) {
+			return nil, errmsg.LabelExisted
+		}
+		log.Printf("[labelService::UpdateLabel::Update] error => %+v", err)
+		return nil, errmsg.LabelUpdateFailed
+	}
+
+	if label == nil {
+		return nil, errmsg.LabelNotFound
+	}
+
+	return label, nil
+}
+
+// DeleteLabel deletes the label and detaches it from the blogs.
+func (s *labelService) DeleteLabel(ctx context.Context, id string) error {
+	label, err := s.lr.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("[labelService::DeleteLabel::GetByID] error => %+v", err)
+		return errmsg.LabelGetFailed
+	}
+
+	if label == nil {
+		return errmsg.LabelNotFound
+	}
+
+	if err := s.lr.DeleteTx(ctx, id, s.DeleteLabelTx); err != nil {
+		log.Printf("[labelService::DeleteLabel::DeleteTx] error => %+v", err)
+		return errmsg.LabelDeleteFailed
+	}
+
+	return nil
+}
+
+// DeleteLabelTx deletes the label and detaches it from the blogs together,
+// so no blog keeps a label which doesn't exist.
+func (s *labelService) DeleteLabelTx(ctx context.Context, id string) error {
+	if err := s.lr.Delete(ctx, id); err != nil {
+		log.Printf("[labelService::DeleteLabelTx::Delete] error => %+v", err)
+		return errmsg.LabelDeleteFailed
+	}
+
+	// a label left on a blog is not populated anymore, detaching it keeps the
+	// limit of labels per blog right
+	if err := s.br.RemoveLabel(ctx, id); err != nil {
+		log.Printf("[labelService::DeleteLabelTx::RemoveLabel] error => %+v", err)
+		return errmsg.LabelDeleteFailed
+	}
+
+	return nil
+}
+
+func labelName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > constants.LABEL_NAME_MAX_LENGTH {
+		return "", errmsg.LabelInvalidName
+	}
+	return name, nil
+}
+
+func labelColor(color string) (string, error) {
+	if !constants.LABEL_COLOR.MatchString(color) {
+		return "", errmsg.LabelInvalidColor
+	}
+	return strings.ToLower(color), nil
+}
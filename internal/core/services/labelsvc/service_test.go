@@ -0,0 +1,405 @@
+package labelsvc_test
+
+import (
+	"context"
+	"errors"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"robinhood/internal/core/ports/mocks"
+	"robinhood/internal/core/services/labelsvc"
+	"robinhood/internal/errmsg"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type testModule struct {
+	lr  *mocks.LabelRepository
+	br  *mocks.BlogRepository
+	svc ports.LabelService
+}
+
+type test struct {
+	name     string
+	args     []interface{}
+	mockFn   func(*testModule)
+	assertFn func()
+}
+
+var (
+	ctx = context.TODO()
+)
+
+func new(t *testing.T) *testModule {
+	lr := mocks.NewLabelRepository(t)
+	br := mocks.NewBlogRepository(t)
+	return &testModule{
+		lr:  lr,
+		br:  br,
+		svc: labelsvc.New(lr, br),
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestCreateLabel(t *testing.T) {
+	var result *domains.Label
+	var err error
+
+	tests := []test{
+		{
+			name: "should return error when name is empty",
+			args: []interface{}{
+				ctx,
+				&domains.CreateLabelRequest{Name: "  ", Color: "#d73a4a"},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelInvalidName, err)
+			},
+		},
+		{
+			name: "should return error when name is too long",
+			args: []interface{}{
+				ctx,
+				&domains.CreateLabelRequest{Name: strings.Repeat("a", 31), Color: "#d73a4a"},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelInvalidName, err)
+			},
+		},
+		{
+			name: "should return error when color is not a hex color",
+			args: []interface{}{
+				ctx,
+				&domains.CreateLabelRequest{Name: "bug", Color: "red"},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelInvalidColor, err)
+			},
+		},
+		{
+			name: "should return error when name is taken",
+			args: []interface{}{
+				ctx,
+				&domains.CreateLabelRequest{Name: "bug", Color: "#d73a4a"},
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("Create", ctx, mock.Anything).Return(nil, domains.ErrLabelNameTaken)
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelExisted, err)
+			},
+		},
+		{
+			name: "should return error when create label failed",
+			args: []interface{}{
+				ctx,
+				&domains.CreateLabelRequest{Name: "bug", Color: "#d73a4a"},
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("Create", ctx, mock.Anything).Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelCreateFailed, err)
+			},
+		},
+		{
+			name: "should create label with trimmed name and lowercase color",
+			args: []interface{}{
+				ctx,
+				&domains.CreateLabelRequest{Name: " bug ", Color: "#D73A4A"},
+			},
+			mockFn: func(tm *testModule) {
+				req := mock.MatchedBy(func(req *domains.CreateLabelRequest) bool {
+					return req.Name == "bug" && req.Color == "#d73a4a"
+				})
+				tm.lr.On("Create", ctx, req).Return(&domains.Label{ID: primitive.NewObjectID(), Name: "bug", Color: "#d73a4a"}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, "bug", result.Name)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.CreateLabel(tt.args[0].(context.Context), tt.args[1].(*domains.CreateLabelRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestListLabels(t *testing.T) {
+	var result []domains.Label
+	var err error
+
+	tests := []test{
+		{
+			name: "should return error when list label failed",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("List", ctx).Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelListFailed, err)
+			},
+		},
+		{
+			name: "should list label success",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("List", ctx).Return([]domains.Label{{Name: "bug"}, {Name: "feature"}}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Len(t, result, 2)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.ListLabels(tt.args[0].(context.Context))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestUpdateLabel(t *testing.T) {
+	var result *domains.Label
+	var err error
+
+	tests := []test{
+		{
+			name: "should return error when nothing is updated",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateLabelRequest{LabelId: "label_id"},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelUpdateEmpty, err)
+			},
+		},
+		{
+			name: "should return error when color is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateLabelRequest{LabelId: "label_id", Color: strPtr("#12345")},
+			},
+			mockFn: func(tm *testModule) {},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelInvalidColor, err)
+			},
+		},
+		{
+			name: "should return error when name is taken",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateLabelRequest{LabelId: "label_id", Name: strPtr("bug")},
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("Update", ctx, mock.Anything).Return(nil, domains.ErrLabelNameTaken)
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelExisted, err)
+			},
+		},
+		{
+			name: "should return error when update label failed",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateLabelRequest{LabelId: "label_id", Name: strPtr("bug")},
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("Update", ctx, mock.Anything).Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelUpdateFailed, err)
+			},
+		},
+		{
+			name: "should return error when label not found",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateLabelRequest{LabelId: "label_id", Name: strPtr("bug")},
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("Update", ctx, mock.Anything).Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelNotFound, err)
+			},
+		},
+		{
+			name: "should update only the color",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateLabelRequest{LabelId: "label_id", Color: strPtr("#1F883D")},
+			},
+			mockFn: func(tm *testModule) {
+				req := mock.MatchedBy(func(req *domains.UpdateLabelRequest) bool {
+					return req.Name == nil && req.Color != nil && *req.Color == "#1f883d"
+				})
+				tm.lr.On("Update", ctx, req).Return(&domains.Label{Name: "bug", Color: "#1f883d"}, nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+				assert.Equal(t, "#1f883d", result.Color)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			result, err = tm.svc.UpdateLabel(tt.args[0].(context.Context), tt.args[1].(*domains.UpdateLabelRequest))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestDeleteLabel(t *testing.T) {
+	var err error
+
+	tests := []test{
+		{
+			name: "should return error when get label failed",
+			args: []interface{}{
+				ctx,
+				"label_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("GetByID", ctx, "label_id").Return(nil, errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelGetFailed, err)
+			},
+		},
+		{
+			name: "should return error when label not found",
+			args: []interface{}{
+				ctx,
+				"label_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("GetByID", ctx, "label_id").Return(nil, nil)
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelNotFound, err)
+			},
+		},
+		{
+			name: "should return error when delete transaction failed",
+			args: []interface{}{
+				ctx,
+				"label_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("GetByID", ctx, "label_id").Return(&domains.Label{}, nil)
+				tm.lr.On("DeleteTx", ctx, "label_id", mock.AnythingOfType("domains.DeleteLabelFn")).Return(errmsg.LabelDeleteFailed)
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelDeleteFailed, err)
+			},
+		},
+		{
+			name: "should delete label in a transaction",
+			args: []interface{}{
+				ctx,
+				"label_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("GetByID", ctx, "label_id").Return(&domains.Label{}, nil)
+				tm.lr.On("DeleteTx", ctx, "label_id", mock.AnythingOfType("domains.DeleteLabelFn")).Return(nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			err = tm.svc.DeleteLabel(tt.args[0].(context.Context), tt.args[1].(string))
+			tt.assertFn()
+		})
+	}
+}
+
+func TestDeleteLabelTx(t *testing.T) {
+	var err error
+
+	tests := []test{
+		{
+			name: "should return error when delete label failed",
+			args: []interface{}{
+				ctx,
+				"label_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("Delete", ctx, "label_id").Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelDeleteFailed, err)
+			},
+		},
+		{
+			name: "should return error when detach label from blogs failed",
+			args: []interface{}{
+				ctx,
+				"label_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("Delete", ctx, "label_id").Return(nil)
+				tm.br.On("RemoveLabel", ctx, "label_id").Return(errors.New("error"))
+			},
+			assertFn: func() {
+				assert.Equal(t, errmsg.LabelDeleteFailed, err)
+			},
+		},
+		{
+			name: "should delete label and detach it from blogs",
+			args: []interface{}{
+				ctx,
+				"label_id",
+			},
+			mockFn: func(tm *testModule) {
+				tm.lr.On("Delete", ctx, "label_id").Return(nil)
+				tm.br.On("RemoveLabel", ctx, "label_id").Return(nil)
+			},
+			assertFn: func() {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := new(t)
+			tt.mockFn(tm)
+			err = tm.svc.DeleteLabelTx(tt.args[0].(context.Context), tt.args[1].(string))
+			tt.assertFn()
+		})
+	}
+}
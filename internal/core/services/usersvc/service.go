@@ -1,21 +1,75 @@
 package usersvc
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
 	"log"
+	"net/http"
+	"net/url"
+	"robinhood/config"
+	"robinhood/internal/core/constants"
 	"robinhood/internal/core/domains"
 	"robinhood/internal/core/ports"
 	"robinhood/internal/errmsg"
 	"robinhood/pkg/auth"
 	"robinhood/pkg/utils"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// lastActiveResolution is how often the user's last activity is written back,
+// so that an authenticated request doesn't always cost a write.
+const lastActiveResolution = time.Minute
+
+// usernames provisioned from an identity provider follow the rules of a registration
+const (
+	minUsernameLength    = 3
+	maxUsernameLength    = 20
+	oidcUsernameAttempts = 3
 )
 
 type userService struct {
-	ur ports.UserRepository
+	ur     ports.UserRepository
+	br     ports.BlogRepository
+	cr     ports.CommentRepository
+	rtr    ports.RefreshTokenRepository
+	rvr    ports.RevokedTokenRepository
+	utr    ports.UserTokenRepository
+	lar    ports.LoginAttemptRepository
+	akr    ports.APIKeyRepository
+	osr    ports.OIDCStateRepository
+	mailer ports.Mailer
+	idp    ports.IdentityProvider
+	blobs  ports.BlobStore
 }
 
-func New(ur ports.UserRepository) ports.UserService {
-	return &userService{ur: ur}
+func New(
+	ur ports.UserRepository,
+	br ports.BlogRepository,
+	cr ports.CommentRepository,
+	rtr ports.RefreshTokenRepository,
+	rvr ports.RevokedTokenRepository,
+	utr ports.UserTokenRepository,
+	lar ports.LoginAttemptRepository,
+	akr ports.APIKeyRepository,
+	osr ports.OIDCStateRepository,
+	mailer ports.Mailer,
+	idp ports.IdentityProvider,
+	blobs ports.BlobStore,
+) ports.UserService {
+	return &userService{ur: ur, br: br, cr: cr, rtr: rtr, rvr: rvr, utr: utr, lar: lar, akr: akr, osr: osr, mailer: mailer, idp: idp, blobs: blobs}
 }
 
 func (s *userService) Register(ctx context.Context, req *domains.RegisterRequest) error {
@@ -35,46 +89,1638 @@ func (s *userService) Register(ctx context.Context, req *domains.RegisterRequest
 		return errmsg.UserRegisterFailed
 	}
 
-	if _, err := s.ur.Create(ctx, &domains.CreateUserRequest{
+	user, err = s.ur.Create(ctx, &domains.CreateUserRequest{
 		Username: req.Username,
 		Password: hashedPassword,
 		Email:    req.Email,
-	}); err != nil {
+		Role:     constants.DEFAULT_ROLE,
+	})
+	if err != nil {
+		// the unique indexes settle concurrent registrations of the same username or email
+		if taken := takenError(err); taken != nil {
+			return taken
+		}
 		log.Printf("[userService::Register::Create] error => %+v", err)
 		return errmsg.UserRegisterFailed
 	}
 
+	// the account is already created, the user can ask for another email
+	if err := s.sendVerification(ctx, user); err != nil {
+		log.Printf("[userService::Register::sendVerification] error => %+v", err)
+	}
+
 	return nil
 }
 
 func (s *userService) Login(ctx context.Context, req *domains.LoginRequest) (*domains.LoginResponse, error) {
+	limits := loginLimits(req.Username, req.IP)
+
+	// a locked username or ip is refused before the password is evaluated
+	if err := s.checkLoginLock(ctx, limits); err != nil {
+		return nil, err
+	}
+
 	user, err := s.ur.GetByUsername(ctx, req.Username)
 	if err != nil {
 		log.Printf("[userService::Login::GetByUsername] error => %+v", err)
 		return nil, errmsg.UserLoginFailed
 	}
 
-	if user == nil {
+	// unknown usernames are counted too so they can't be told apart
+	if user == nil || !utils.CheckPasswordHash(req.Password, user.Password) {
+		s.recordLoginFailure(ctx, limits)
 		return nil, errmsg.UsernameOrPasswordIncorrect
 	}
 
-	// check password
-	if !utils.CheckPasswordHash(req.Password, user.Password) {
-		return nil, errmsg.UsernameOrPasswordIncorrect
+	if user.Deactivated {
+		return nil, errmsg.UserDeactivated
 	}
 
-	// generate custom claims JWT token
-	token, err := auth.GenerateToken(user.ID.Hex())
+	// the password is only the first factor, the tokens are issued by LoginMFA
+	if user.MFAEnabled {
+		return s.createMFAChallenge(ctx, user)
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+func (s *userService) LoginMFA(ctx context.Context, req *domains.LoginMFARequest) (*domains.LoginResponse, error) {
+	ut, err := s.utr.GetByTokenHash(ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, utils.HashToken(req.MFAToken))
 	if err != nil {
-		log.Printf("[userService::Login::GenerateToken] error => %+v", err)
-		return nil, errmsg.UserLoginFailed
+		log.Printf("[userService::LoginMFA::GetByTokenHash] error => %+v", err)
+		return nil, errmsg.MFAFailed
 	}
 
-	return &domains.LoginResponse{
-		Token: token,
+	if ut == nil || ut.UsedAt != nil || time.Now().UTC().After(ut.ExpiresAt) {
+		return nil, errmsg.MFAChallengeInvalid
+	}
+
+	user, err := s.ur.GetByID(ctx, ut.UserId)
+	if err != nil {
+		log.Printf("[userService::LoginMFA::GetByID] error => %+v", err)
+		return nil, errmsg.MFAFailed
+	}
+
+	if user == nil || !user.MFAEnabled {
+		return nil, errmsg.MFAChallengeInvalid
+	}
+
+	// a wrong code counts as a failed login like a wrong password
+	limits := loginLimits(user.Username, req.IP)
+	if err := s.checkLoginLock(ctx, limits); err != nil {
+		return nil, err
+	}
+
+	valid, err := s.verifyMFACode(ctx, user, req.Code)
+	if err != nil {
+		log.Printf("[userService::LoginMFA::verifyMFACode] error => %+v", err)
+		return nil, errmsg.MFAFailed
+	}
+
+	if !valid {
+		s.recordLoginFailure(ctx, limits)
+		return nil, errmsg.MFACodeInvalid
+	}
+
+	used, err := s.utr.MarkUsed(ctx, ut.ID)
+	if err != nil {
+		log.Printf("[userService::LoginMFA::MarkUsed] error => %+v", err)
+		return nil, errmsg.MFAFailed
+	}
+
+	if !used {
+		return nil, errmsg.MFAChallengeInvalid
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+func (s *userService) OIDCLogin(ctx context.Context) (*domains.OIDCLoginResponse, error) {
+	if s.idp == nil {
+		return nil, errmsg.OIDCNotConfigured
+	}
+
+	// state ties the callback to this login, nonce ties the id token to it and
+	// the code verifier proves to the provider that we asked for the code
+	secrets := make([]string, 3)
+	for i := range secrets {
+		secret, err := utils.GenerateRandomString(32)
+		if err != nil {
+			log.Printf("[userService::OIDCLogin::GenerateRandomString] error => %+v", err)
+			return nil, errmsg.OIDCLoginFailed
+		}
+		secrets[i] = secret
+	}
+	state, nonce, verifier := secrets[0], secrets[1], secrets[2]
+
+	if _, err := s.osr.Create(ctx, &domains.CreateOIDCStateRequest{
+		StateHash:    utils.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().UTC().Add(time.Duration(config.Get().OIDC.StateExpiresMinutes) * time.Minute),
+	}); err != nil {
+		log.Printf("[userService::OIDCLogin::Create] error => %+v", err)
+		return nil, errmsg.OIDCLoginFailed
+	}
+
+	u, err := s.idp.AuthCodeURL(ctx, &domains.AuthCodeURLRequest{
+		State:         state,
+		Nonce:         nonce,
+		CodeChallenge: auth.CodeChallengeS256(verifier),
+	})
+	if err != nil {
+		log.Printf("[userService::OIDCLogin::AuthCodeURL] error => %+v", err)
+		return nil, errmsg.OIDCLoginFailed
+	}
+
+	return &domains.OIDCLoginResponse{URL: u}, nil
+}
+
+func (s *userService) OIDCCallback(ctx context.Context, req *domains.OIDCCallbackRequest) (*domains.LoginResponse, error) {
+	if s.idp == nil {
+		return nil, errmsg.OIDCNotConfigured
+	}
+
+	st, err := s.osr.Consume(ctx, utils.HashToken(req.State))
+	if err != nil {
+		log.Printf("[userService::OIDCCallback::Consume] error => %+v", err)
+		return nil, errmsg.OIDCLoginFailed
+	}
+
+	if st == nil || time.Now().After(st.ExpiresAt) {
+		return nil, errmsg.OIDCStateInvalid
+	}
+
+	identity, err := s.idp.Exchange(ctx, &domains.ExchangeCodeRequest{
+		Code:         req.Code,
+		CodeVerifier: st.CodeVerifier,
+		Nonce:        st.Nonce,
+	})
+	if err != nil {
+		log.Printf("[userService::OIDCCallback::Exchange] error => %+v", err)
+		return nil, errmsg.OIDCLoginFailed
+	}
+
+	user, err := s.oidcUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Deactivated {
+		return nil, errmsg.UserDeactivated
+	}
+
+	// the identity provider is in charge of the second factor
+	return s.completeLogin(ctx, user)
+}
+
+func (s *userService) EnrollTOTP(ctx context.Context, userId string) (*domains.EnrollTOTPResponse, error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::EnrollTOTP::GetByID] error => %+v", err)
+		return nil, errmsg.MFAFailed
+	}
+
+	if user == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	if user.MFAEnabled {
+		return nil, errmsg.MFAAlreadyEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		log.Printf("[userService::EnrollTOTP::GenerateTOTPSecret] error => %+v", err)
+		return nil, errmsg.MFAFailed
+	}
+
+	// the secret stays pending until a code of it is confirmed
+	if err := s.ur.UpdateTOTPSecret(ctx, uid, secret); err != nil {
+		log.Printf("[userService::EnrollTOTP::UpdateTOTPSecret] error => %+v", err)
+		return nil, errmsg.MFAFailed
+	}
+
+	return &domains.EnrollTOTPResponse{
+		Secret: secret,
+		URI:    auth.TOTPURI(config.Get().User.MFAIssuer, user.Username, secret),
+	}, nil
+}
+
+func (s *userService) ConfirmTOTP(ctx context.Context, req *domains.ConfirmTOTPRequest) (*domains.ConfirmTOTPResponse, error) {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::ConfirmTOTP::GetByID] error => %+v", err)
+		return nil, errmsg.MFAFailed
+	}
+
+	if user == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	if user.MFAEnabled {
+		return nil, errmsg.MFAAlreadyEnabled
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, errmsg.MFANotEnrolled
+	}
+
+	counter, ok := auth.ValidateTOTP(user.TOTPSecret, strings.TrimSpace(req.Code), time.Now())
+	if !ok {
+		return nil, errmsg.MFACodeInvalid
+	}
+
+	// recovery codes are only shown now, just their hashes are stored
+	n := int(config.Get().User.MFARecoveryCodes)
+	codes := make([]string, n)
+	hashes := make([]string, n)
+	for i := range codes {
+		raw, err := utils.GenerateRandomString(6)
+		if err != nil {
+			log.Printf("[userService::ConfirmTOTP::GenerateRandomString] error => %+v", err)
+			return nil, errmsg.MFAFailed
+		}
+		codes[i] = fmt.Sprintf("%s-%s-%s", raw[:4], raw[4:8], raw[8:])
+		hashes[i] = utils.HashToken(normalizeRecoveryCode(codes[i]))
+	}
+
+	if err := s.ur.EnableMFA(ctx, uid, hashes); err != nil {
+		log.Printf("[userService::ConfirmTOTP::EnableMFA] error => %+v", err)
+		return nil, errmsg.MFAFailed
+	}
+
+	// the confirmed code can't be used again to login
+	if _, err := s.ur.UseTOTPCounter(ctx, uid, counter); err != nil {
+		log.Printf("[userService::ConfirmTOTP::UseTOTPCounter] error => %+v", err)
+	}
+
+	return &domains.ConfirmTOTPResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (s *userService) RefreshToken(ctx context.Context, req *domains.RefreshTokenRequest) (*domains.LoginResponse, error) {
+	rt, err := s.rtr.GetByTokenHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		log.Printf("[userService::RefreshToken::GetByTokenHash] error => %+v", err)
+		return nil, errmsg.RefreshTokenFailed
+	}
+
+	if rt == nil || rt.RevokedAt != nil {
+		return nil, errmsg.RefreshTokenInvalid
+	}
+
+	if time.Now().UTC().After(rt.ExpiresAt) {
+		return nil, errmsg.RefreshTokenExpired
+	}
+
+	// a refresh token can only be used once, using it again means it was leaked
+	// so the whole family is revoked and the user has to login again
+	rotated := false
+	if rt.UsedAt == nil {
+		rotated, err = s.rtr.MarkUsed(ctx, rt.ID)
+		if err != nil {
+			log.Printf("[userService::RefreshToken::MarkUsed] error => %+v", err)
+			return nil, errmsg.RefreshTokenFailed
+		}
+	}
+	if !rotated {
+		if err := s.rtr.RevokeFamily(ctx, rt.FamilyId); err != nil {
+			log.Printf("[userService::RefreshToken::RevokeFamily] error => %+v", err)
+		}
+		return nil, errmsg.RefreshTokenReused
+	}
+
+	user, err := s.ur.GetByID(ctx, rt.UserId)
+	if err != nil {
+		log.Printf("[userService::RefreshToken::GetByID] error => %+v", err)
+		return nil, errmsg.RefreshTokenFailed
+	}
+
+	if user == nil {
+		return nil, errmsg.RefreshTokenInvalid
+	}
+
+	// refreshing doesn't count as activity, an idle session stays logged off
+	if window := auth.AutoLogoffDuration(); window > 0 && time.Since(user.LastActiveAt) > window {
+		return nil, errmsg.TokenIdleExpired
+	}
+
+	res, err := s.issueTokens(ctx, user, rt.FamilyId)
+	if err != nil {
+		log.Printf("[userService::RefreshToken::issueTokens] error => %+v", err)
+		return nil, errmsg.RefreshTokenFailed
+	}
+
+	return res, nil
+}
+
+func (s *userService) GetUserByID(ctx context.Context, userId string) (*domains.User, error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::GetUserByID::GetByID] error => %+v", err)
+		return nil, errmsg.UserGetFailed
+	}
+
+	// a deactivated user is hidden like a deleted one
+	if user == nil || user.Deactivated {
+		return nil, errmsg.UserNotFound
+	}
+
+	return user, nil
+}
+
+func (s *userService) SearchUser(ctx context.Context, req *domains.SearchUserRequest) (*domains.SearchUserResponse, error) {
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	users, err := s.ur.Search(ctx, req.Query, &domains.PaginationOptions{
+		Offset: int64((req.Page - 1) * req.Limit),
+		Limit:  int64(req.Limit),
+	})
+	if err != nil {
+		log.Printf("[userService::SearchUser::Search] error => %+v", err)
+		return nil, errmsg.UserSearchFailed
+	}
+
+	// count + 1 to check if there is next page
+	count, err := s.ur.CountSearch(ctx, req.Query, &domains.PaginationOptions{
+		Offset: int64((req.Page - 1) * req.Limit),
+		Limit:  int64(req.Limit + 1),
+	})
+	if err != nil {
+		log.Printf("[userService::SearchUser::CountSearch] error => %+v", err)
+		return nil, errmsg.UserSearchFailed
+	}
+
+	return &domains.SearchUserResponse{
+		Data:    users,
+		HasNext: count > int64(len(users)),
 	}, nil
 }
 
 func (s *userService) Update(ctx context.Context, req *domains.UpdateUserRequest) (*domains.User, error) {
-	return s.ur.Update(ctx, req)
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::Update::GetByID] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	if user == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	updatedUser, err := s.ur.Update(ctx, req)
+	if err != nil {
+		log.Printf("[userService::Update::Update] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	// a link set by hand replaces the uploaded image
+	s.deleteBlobs(ctx, "Update", user.ProfileImageKeys)
+
+	return updatedUser, nil
+}
+
+func (s *userService) UploadProfileImage(ctx context.Context, req *domains.UploadProfileImageRequest) (*domains.User, error) {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	maxBytes := int64(config.Get().User.ProfileImageMaxBytes)
+	if req.Size > maxBytes {
+		return nil, errmsg.ProfileImageTooLarge
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::UploadProfileImage::GetByID] error => %+v", err)
+		return nil, errmsg.ProfileImageUploadFailed
+	}
+
+	if user == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	// the declared size isn't trusted, one byte more than allowed is enough to tell
+	data, err := io.ReadAll(io.LimitReader(req.File, maxBytes+1))
+	if err != nil {
+		log.Printf("[userService::UploadProfileImage::ReadAll] error => %+v", err)
+		return nil, errmsg.ProfileImageUploadFailed
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, errmsg.ProfileImageTooLarge
+	}
+
+	version, err := utils.GenerateRandomString(8)
+	if err != nil {
+		log.Printf("[userService::UploadProfileImage::GenerateRandomString] error => %+v", err)
+		return nil, errmsg.ProfileImageUploadFailed
+	}
+
+	blobs, err := profileImageBlobs(fmt.Sprintf("profile/%s/%s", uid.Hex(), version), data)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]string{}
+	for size, blob := range blobs {
+		if err := s.blobs.Put(ctx, blob); err != nil {
+			log.Printf("[userService::UploadProfileImage::Put] error => %+v", err)
+			s.deleteBlobs(ctx, "UploadProfileImage", keys)
+			return nil, errmsg.ProfileImageUploadFailed
+		}
+		keys[size] = blob.Key
+	}
+
+	// the version in the link changes with every upload so clients don't keep an old image
+	link := fmt.Sprintf("%s/user/%s/profile-image?v=%s", config.Get().App.PublicURL, uid.Hex(), version)
+	updatedUser, err := s.ur.UpdateProfileImage(ctx, uid, link, keys)
+	if err != nil {
+		log.Printf("[userService::UploadProfileImage::UpdateProfileImage] error => %+v", err)
+		s.deleteBlobs(ctx, "UploadProfileImage", keys)
+		return nil, errmsg.ProfileImageUploadFailed
+	}
+
+	s.deleteBlobs(ctx, "UploadProfileImage", user.ProfileImageKeys)
+
+	return updatedUser, nil
+}
+
+func (s *userService) ProfileImageURL(ctx context.Context, req *domains.ProfileImageURLRequest) (string, error) {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return "", errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::ProfileImageURL::GetByID] error => %+v", err)
+		return "", errmsg.ProfileImageGetFailed
+	}
+
+	if user == nil || user.Deactivated {
+		return "", errmsg.UserNotFound
+	}
+
+	size := req.Size
+	if size == "" {
+		size = constants.PROFILE_IMAGE_DEFAULT_SIZE
+	}
+	key, ok := user.ProfileImageKeys[size]
+	if !ok {
+		return "", errmsg.ProfileImageNotFound
+	}
+
+	expires := time.Duration(config.Get().Blob.URLExpiresMinutes) * time.Minute
+	link, err := s.blobs.SignedURL(ctx, key, expires)
+	if err != nil {
+		log.Printf("[userService::ProfileImageURL::SignedURL] error => %+v", err)
+		return "", errmsg.ProfileImageGetFailed
+	}
+
+	return link, nil
+}
+
+func (s *userService) ChangePassword(ctx context.Context, req *domains.ChangePasswordRequest) (*domains.LoginResponse, error) {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::ChangePassword::GetByID] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	if user == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	if !utils.CheckPasswordHash(req.CurrentPassword, user.Password) {
+		return nil, errmsg.PasswordIncorrect
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword, utils.DefaultCost)
+	if err != nil {
+		log.Printf("[userService::ChangePassword::HashPassword] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	if err := s.ur.UpdatePassword(ctx, uid, hashedPassword); err != nil {
+		log.Printf("[userService::ChangePassword::UpdatePassword] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	// every session is revoked, the current one continues with the new tokens
+	if err := s.LogoutAll(ctx, req.UserId); err != nil {
+		log.Printf("[userService::ChangePassword::LogoutAll] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+	user.TokenVersion++
+
+	res, err := s.completeLogin(ctx, user)
+	if err != nil {
+		log.Printf("[userService::ChangePassword::completeLogin] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	return res, nil
+}
+
+func (s *userService) ChangeEmail(ctx context.Context, req *domains.ChangeEmailRequest) (*domains.User, error) {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::ChangeEmail::GetByID] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	if user == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	if !utils.CheckPasswordHash(req.Password, user.Password) {
+		return nil, errmsg.PasswordIncorrect
+	}
+
+	existed, err := s.ur.GetByEmail(ctx, req.Email)
+	if err != nil {
+		log.Printf("[userService::ChangeEmail::GetByEmail] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	// changing only the case of the own email is allowed
+	if existed != nil && existed.ID != uid {
+		return nil, errmsg.EmailExisted
+	}
+
+	updatedUser, err := s.ur.UpdateEmail(ctx, uid, req.Email)
+	if err != nil {
+		if taken := takenError(err); taken != nil {
+			return nil, taken
+		}
+		log.Printf("[userService::ChangeEmail::UpdateEmail] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	if updatedUser == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	// the new email is unverified, the user can ask for another email
+	if err := s.sendVerification(ctx, updatedUser); err != nil {
+		log.Printf("[userService::ChangeEmail::sendVerification] error => %+v", err)
+	}
+
+	return updatedUser, nil
+}
+
+func (s *userService) ChangeUsername(ctx context.Context, req *domains.ChangeUsernameRequest) (*domains.User, error) {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	existed, err := s.ur.GetByUsername(ctx, req.Username)
+	if err != nil {
+		log.Printf("[userService::ChangeUsername::GetByUsername] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	// changing only the case of the own username is allowed
+	if existed != nil && existed.ID != uid {
+		return nil, errmsg.UserExisted
+	}
+
+	updatedUser, err := s.ur.UpdateUsername(ctx, uid, req.Username)
+	if err != nil {
+		if taken := takenError(err); taken != nil {
+			return nil, taken
+		}
+		log.Printf("[userService::ChangeUsername::UpdateUsername] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	if updatedUser == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	return updatedUser, nil
+}
+
+func (s *userService) Deactivate(ctx context.Context, req *domains.DeactivateUserRequest) error {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::Deactivate::GetByID] error => %+v", err)
+		return errmsg.UserDeactivateFailed
+	}
+
+	if user == nil {
+		return errmsg.UserNotFound
+	}
+
+	// an account created by the identity provider has no password to confirm with
+	if user.HasPassword() && !utils.CheckPasswordHash(req.Password, user.Password) {
+		return errmsg.PasswordIncorrect
+	}
+
+	if err := s.ur.Deactivate(ctx, uid, time.Now().UTC()); err != nil {
+		log.Printf("[userService::Deactivate::Deactivate] error => %+v", err)
+		return errmsg.UserDeactivateFailed
+	}
+
+	// sign out every device, the account can't login anymore
+	if err := s.LogoutAll(ctx, req.UserId); err != nil {
+		log.Printf("[userService::Deactivate::LogoutAll] error => %+v", err)
+		return errmsg.UserDeactivateFailed
+	}
+
+	return nil
+}
+
+func (s *userService) Delete(ctx context.Context, req *domains.DeleteUserRequest) error {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::Delete::GetByID] error => %+v", err)
+		return errmsg.UserDeleteFailed
+	}
+
+	if user == nil {
+		return errmsg.UserNotFound
+	}
+
+	// an account created by the identity provider has no password to confirm with
+	if user.HasPassword() && !utils.CheckPasswordHash(req.Password, user.Password) {
+		return errmsg.PasswordIncorrect
+	}
+
+	// blogs and comments are kept without an author, every step can be
+	// retried so a failed deletion is completed by asking again
+	if err := s.br.AnonymizeAuthor(ctx, req.UserId); err != nil {
+		log.Printf("[userService::Delete::AnonymizeAuthor] error => %+v", err)
+		return errmsg.UserDeleteFailed
+	}
+
+	// a stale assignee would still count against the limit of the blog
+	if err := s.br.UnassignUser(ctx, req.UserId); err != nil {
+		log.Printf("[userService::Delete::UnassignUser] error => %+v", err)
+		return errmsg.UserDeleteFailed
+	}
+
+	if err := s.cr.AnonymizeAuthor(ctx, req.UserId); err != nil {
+		log.Printf("[userService::Delete::AnonymizeAuthor] error => %+v", err)
+		return errmsg.UserDeleteFailed
+	}
+
+	if err := s.ur.Delete(ctx, uid); err != nil {
+		log.Printf("[userService::Delete::Delete] error => %+v", err)
+		return errmsg.UserDeleteFailed
+	}
+
+	// the tokens of a deleted user are already useless, the rest only tidies up
+	if err := s.rtr.RevokeByUserID(ctx, uid); err != nil {
+		log.Printf("[userService::Delete::RevokeByUserID] error => %+v", err)
+	}
+
+	for _, purpose := range []string{
+		constants.TOKEN_PURPOSE_RESET_PASSWORD,
+		constants.TOKEN_PURPOSE_VERIFY_EMAIL,
+		constants.TOKEN_PURPOSE_MFA_CHALLENGE,
+	} {
+		if err := s.utr.DeleteByUserID(ctx, uid, purpose); err != nil {
+			log.Printf("[userService::Delete::DeleteByUserID] error => %+v", err)
+		}
+	}
+
+	if err := s.lar.DeleteByKey(ctx, usernameLoginKey(user.Username)); err != nil {
+		log.Printf("[userService::Delete::DeleteByKey] error => %+v", err)
+	}
+
+	if err := s.akr.DeleteByUserID(ctx, uid); err != nil {
+		log.Printf("[userService::Delete::DeleteByUserID] error => %+v", err)
+	}
+
+	s.deleteBlobs(ctx, "Delete", user.ProfileImageKeys)
+
+	return nil
+}
+
+// Export hands the profile, then the blogs and then the comments of the user
+// to w as they are read, nothing is written before the user is found.
+func (s *userService) Export(ctx context.Context, userId string, w ports.UserExportWriter) error {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::Export::GetByID] error => %+v", err)
+		return errmsg.UserExportFailed
+	}
+
+	if user == nil {
+		return errmsg.UserNotFound
+	}
+
+	if err := w.WriteProfile(*user); err != nil {
+		log.Printf("[userService::Export::WriteProfile] error => %+v", err)
+		return errmsg.UserExportFailed
+	}
+
+	if err := s.br.EachByAuthorID(ctx, userId, w.WriteBlog); err != nil {
+		log.Printf("[userService::Export::EachByAuthorID] error => %+v", err)
+		return errmsg.UserExportFailed
+	}
+
+	if err := s.cr.EachByAuthorID(ctx, userId, w.WriteComment); err != nil {
+		log.Printf("[userService::Export::EachByAuthorID] error => %+v", err)
+		return errmsg.UserExportFailed
+	}
+
+	return nil
+}
+
+func (s *userService) CreateAPIKey(ctx context.Context, req *domains.CreateAPIKeyRequest) (*domains.CreateAPIKeyResponse, error) {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	if len(req.Scopes) == 0 {
+		return nil, errmsg.APIKeyInvalidScope
+	}
+	for _, scope := range req.Scopes {
+		if !constants.IsValidAPIKeyScope(scope) {
+			return nil, errmsg.APIKeyInvalidScope
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errmsg.APIKeyInvalidExpiry
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::CreateAPIKey::GetByID] error => %+v", err)
+		return nil, errmsg.APIKeyCreateFailed
+	}
+
+	if user == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	secret, err := utils.GenerateRandomString(32)
+	if err != nil {
+		log.Printf("[userService::CreateAPIKey::GenerateRandomString] error => %+v", err)
+		return nil, errmsg.APIKeyCreateFailed
+	}
+	key := constants.API_KEY_PREFIX + secret
+
+	// only the hash is stored, the key can't be shown again
+	apiKey, err := s.akr.Create(ctx, &domains.StoreAPIKeyRequest{
+		UserId:    uid,
+		Name:      req.Name,
+		Prefix:    key[:len(constants.API_KEY_PREFIX)+8],
+		KeyHash:   utils.HashToken(key),
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		log.Printf("[userService::CreateAPIKey::Create] error => %+v", err)
+		return nil, errmsg.APIKeyCreateFailed
+	}
+
+	return &domains.CreateAPIKeyResponse{
+		APIKey: *apiKey,
+		Key:    key,
+	}, nil
+}
+
+func (s *userService) ListAPIKeys(ctx context.Context, userId string) ([]domains.APIKey, error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	apiKeys, err := s.akr.ListByUserID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::ListAPIKeys::ListByUserID] error => %+v", err)
+		return nil, errmsg.APIKeyListFailed
+	}
+
+	return apiKeys, nil
+}
+
+func (s *userService) RevokeAPIKey(ctx context.Context, req *domains.RevokeAPIKeyRequest) error {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return errmsg.UserNotFound
+	}
+
+	id, err := primitive.ObjectIDFromHex(req.APIKeyId)
+	if err != nil {
+		return errmsg.APIKeyNotFound
+	}
+
+	// a key of another user is reported as not found
+	deleted, err := s.akr.Delete(ctx, id, uid)
+	if err != nil {
+		log.Printf("[userService::RevokeAPIKey::Delete] error => %+v", err)
+		return errmsg.APIKeyRevokeFailed
+	}
+
+	if !deleted {
+		return errmsg.APIKeyNotFound
+	}
+
+	return nil
+}
+
+func (s *userService) Unlock(ctx context.Context, req *domains.UnlockUserRequest) error {
+	uid, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::Unlock::GetByID] error => %+v", err)
+		return errmsg.UserUnlockFailed
+	}
+
+	if user == nil {
+		return errmsg.UserNotFound
+	}
+
+	if err := s.lar.DeleteByKey(ctx, usernameLoginKey(user.Username)); err != nil {
+		log.Printf("[userService::Unlock::DeleteByKey] error => %+v", err)
+		return errmsg.UserUnlockFailed
+	}
+
+	return nil
+}
+
+func (s *userService) UpdateRole(ctx context.Context, req *domains.UpdateUserRoleRequest) (*domains.User, error) {
+	if !constants.IsValidRole(req.Role) {
+		return nil, errmsg.UserInvalidRole
+	}
+
+	user, err := s.ur.UpdateRole(ctx, req)
+	if err != nil {
+		log.Printf("[userService::UpdateRole::UpdateRole] error => %+v", err)
+		return nil, errmsg.UserUpdateFailed
+	}
+
+	if user == nil {
+		return nil, errmsg.UserNotFound
+	}
+
+	return user, nil
+}
+
+func (s *userService) Authenticate(ctx context.Context, token string) (*auth.JWTCustomClaims, error) {
+	claims, err := auth.ParseToken(token)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errmsg.TokenExpired
+		}
+		return nil, errmsg.TokenInvalid
+	}
+
+	uid, err := primitive.ObjectIDFromHex(claims.UserId)
+	if err != nil {
+		return nil, errmsg.TokenInvalid
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::Authenticate::GetByID] error => %+v", err)
+		return nil, errmsg.InternalServer
+	}
+
+	if user == nil {
+		return nil, errmsg.TokenInvalid
+	}
+
+	if user.Deactivated {
+		return nil, errmsg.UserDeactivated
+	}
+
+	// tokens issued before the last logout from all devices are no longer valid
+	if claims.TokenVersion != user.TokenVersion {
+		return nil, errmsg.TokenRevoked
+	}
+
+	revoked, err := s.rvr.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		log.Printf("[userService::Authenticate::IsRevoked] error => %+v", err)
+		return nil, errmsg.InternalServer
+	}
+
+	if revoked {
+		return nil, errmsg.TokenRevoked
+	}
+
+	// log off the user when there is no activity within the auto logoff window
+	now := time.Now().UTC()
+	idle := now.Sub(user.LastActiveAt)
+	if window := auth.AutoLogoffDuration(); window > 0 && idle > window {
+		return nil, errmsg.TokenIdleExpired
+	}
+
+	// the role is always taken from the user so a role change applies immediately
+	claims.Role = roleOf(user)
+	claims.Verified = user.Verified
+
+	if idle > lastActiveResolution {
+		if err := s.ur.UpdateLastActiveAt(ctx, user.ID, now); err != nil {
+			log.Printf("[userService::Authenticate::UpdateLastActiveAt] error => %+v", err)
+		}
+	}
+
+	return claims, nil
+}
+
+func (s *userService) AuthenticateAPIKey(ctx context.Context, key string) (*auth.JWTCustomClaims, error) {
+	if !strings.HasPrefix(key, constants.API_KEY_PREFIX) {
+		return nil, errmsg.APIKeyInvalid
+	}
+
+	apiKey, err := s.akr.GetByKeyHash(ctx, utils.HashToken(key))
+	if err != nil {
+		log.Printf("[userService::AuthenticateAPIKey::GetByKeyHash] error => %+v", err)
+		return nil, errmsg.InternalServer
+	}
+
+	if apiKey == nil {
+		return nil, errmsg.APIKeyInvalid
+	}
+
+	now := time.Now().UTC()
+	if apiKey.ExpiresAt != nil && now.After(*apiKey.ExpiresAt) {
+		return nil, errmsg.APIKeyExpired
+	}
+
+	user, err := s.ur.GetByID(ctx, apiKey.UserId)
+	if err != nil {
+		log.Printf("[userService::AuthenticateAPIKey::GetByID] error => %+v", err)
+		return nil, errmsg.InternalServer
+	}
+
+	if user == nil {
+		return nil, errmsg.APIKeyInvalid
+	}
+
+	if user.Deactivated {
+		return nil, errmsg.UserDeactivated
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > lastActiveResolution {
+		if err := s.akr.UpdateLastUsedAt(ctx, apiKey.ID, now); err != nil {
+			log.Printf("[userService::AuthenticateAPIKey::UpdateLastUsedAt] error => %+v", err)
+		}
+	}
+
+	// the key can't do more than its owner, the role is checked along with the scopes
+	return &auth.JWTCustomClaims{
+		UserId:   user.ID.Hex(),
+		Role:     roleOf(user),
+		Verified: user.Verified,
+		Scopes:   apiKey.Scopes,
+	}, nil
+}
+
+func (s *userService) Logout(ctx context.Context, req *domains.LogoutRequest) error {
+	// deny the access token until it expires by itself
+	if err := s.rvr.Create(ctx, &domains.RevokeTokenRequest{
+		TokenId:   req.TokenId,
+		UserId:    req.UserId,
+		ExpiresAt: req.ExpiresAt,
+	}); err != nil {
+		log.Printf("[userService::Logout::Create] error => %+v", err)
+		return errmsg.UserLogoutFailed
+	}
+
+	// the session can't be refreshed anymore
+	if req.SessionId != "" {
+		if err := s.rtr.RevokeFamily(ctx, req.SessionId); err != nil {
+			log.Printf("[userService::Logout::RevokeFamily] error => %+v", err)
+			return errmsg.UserLogoutFailed
+		}
+	}
+
+	return nil
+}
+
+func (s *userService) LogoutAll(ctx context.Context, userId string) error {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return errmsg.UserNotFound
+	}
+
+	// bumping the token version invalidates every access token issued so far
+	if err := s.ur.IncrementTokenVersion(ctx, uid); err != nil {
+		log.Printf("[userService::LogoutAll::IncrementTokenVersion] error => %+v", err)
+		return errmsg.UserLogoutFailed
+	}
+
+	if err := s.rtr.RevokeByUserID(ctx, uid); err != nil {
+		log.Printf("[userService::LogoutAll::RevokeByUserID] error => %+v", err)
+		return errmsg.UserLogoutFailed
+	}
+
+	return nil
+}
+
+func (s *userService) ForgotPassword(ctx context.Context, req *domains.ForgotPasswordRequest) error {
+	user, err := s.ur.GetByEmail(ctx, req.Email)
+	if err != nil {
+		log.Printf("[userService::ForgotPassword::GetByEmail] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	// don't reveal whether the email is registered
+	if user == nil {
+		return nil
+	}
+
+	token, err := utils.GenerateRandomString(32)
+	if err != nil {
+		log.Printf("[userService::ForgotPassword::GenerateRandomString] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	// only the latest requested token can be used
+	if err := s.utr.DeleteByUserID(ctx, user.ID, constants.TOKEN_PURPOSE_RESET_PASSWORD); err != nil {
+		log.Printf("[userService::ForgotPassword::DeleteByUserID] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	expires := time.Duration(config.Get().User.PasswordResetExpiresMinutes) * time.Minute
+	if _, err := s.utr.Create(ctx, &domains.CreateUserTokenRequest{
+		UserId:    user.ID,
+		Purpose:   constants.TOKEN_PURPOSE_RESET_PASSWORD,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().UTC().Add(expires),
+	}); err != nil {
+		log.Printf("[userService::ForgotPassword::Create] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nUse this token to reset your password: %s\n", user.Username, token)
+	if link := config.Get().User.PasswordResetURL; link != "" {
+		body += fmt.Sprintf("or open %s?token=%s\n", link, url.QueryEscape(token))
+	}
+	body += fmt.Sprintf("\nThe token expires in %d minutes. If you didn't request it, you can ignore this email.\n", int(expires.Minutes()))
+
+	if err := s.mailer.Send(ctx, &domains.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    body,
+	}); err != nil {
+		log.Printf("[userService::ForgotPassword::Send] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	return nil
+}
+
+func (s *userService) ResetPassword(ctx context.Context, req *domains.ResetPasswordRequest) error {
+	ut, err := s.utr.GetByTokenHash(ctx, constants.TOKEN_PURPOSE_RESET_PASSWORD, utils.HashToken(req.Token))
+	if err != nil {
+		log.Printf("[userService::ResetPassword::GetByTokenHash] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	if ut == nil || ut.UsedAt != nil || time.Now().UTC().After(ut.ExpiresAt) {
+		return errmsg.PasswordResetTokenInvalid
+	}
+
+	used, err := s.utr.MarkUsed(ctx, ut.ID)
+	if err != nil {
+		log.Printf("[userService::ResetPassword::MarkUsed] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	if !used {
+		return errmsg.PasswordResetTokenInvalid
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password, utils.DefaultCost)
+	if err != nil {
+		log.Printf("[userService::ResetPassword::HashPassword] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	if err := s.ur.UpdatePassword(ctx, ut.UserId, hashedPassword); err != nil {
+		log.Printf("[userService::ResetPassword::UpdatePassword] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	// whoever knew the old password is logged out everywhere
+	if err := s.LogoutAll(ctx, ut.UserId.Hex()); err != nil {
+		log.Printf("[userService::ResetPassword::LogoutAll] error => %+v", err)
+		return errmsg.PasswordResetFailed
+	}
+
+	return nil
+}
+
+func (s *userService) VerifyEmail(ctx context.Context, req *domains.VerifyEmailRequest) error {
+	ut, err := s.utr.GetByTokenHash(ctx, constants.TOKEN_PURPOSE_VERIFY_EMAIL, utils.HashToken(req.Token))
+	if err != nil {
+		log.Printf("[userService::VerifyEmail::GetByTokenHash] error => %+v", err)
+		return errmsg.EmailVerificationFailed
+	}
+
+	if ut == nil || ut.UsedAt != nil || time.Now().UTC().After(ut.ExpiresAt) {
+		return errmsg.EmailVerificationTokenInvalid
+	}
+
+	used, err := s.utr.MarkUsed(ctx, ut.ID)
+	if err != nil {
+		log.Printf("[userService::VerifyEmail::MarkUsed] error => %+v", err)
+		return errmsg.EmailVerificationFailed
+	}
+
+	if !used {
+		return errmsg.EmailVerificationTokenInvalid
+	}
+
+	if err := s.ur.UpdateVerified(ctx, ut.UserId); err != nil {
+		log.Printf("[userService::VerifyEmail::UpdateVerified] error => %+v", err)
+		return errmsg.EmailVerificationFailed
+	}
+
+	return nil
+}
+
+func (s *userService) ResendVerification(ctx context.Context, userId string) error {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return errmsg.UserNotFound
+	}
+
+	user, err := s.ur.GetByID(ctx, uid)
+	if err != nil {
+		log.Printf("[userService::ResendVerification::GetByID] error => %+v", err)
+		return errmsg.EmailVerificationFailed
+	}
+
+	if user == nil {
+		return errmsg.UserNotFound
+	}
+
+	if user.Verified {
+		return errmsg.EmailAlreadyVerified
+	}
+
+	// only one email is sent within the resend window
+	last, err := s.utr.GetLatestByUserID(ctx, uid, constants.TOKEN_PURPOSE_VERIFY_EMAIL)
+	if err != nil {
+		log.Printf("[userService::ResendVerification::GetLatestByUserID] error => %+v", err)
+		return errmsg.EmailVerificationFailed
+	}
+
+	window := time.Duration(config.Get().User.VerificationResendSeconds) * time.Second
+	if last != nil && time.Since(last.CreatedAt) < window {
+		return errmsg.EmailVerificationThrottled
+	}
+
+	if err := s.sendVerification(ctx, user); err != nil {
+		log.Printf("[userService::ResendVerification::sendVerification] error => %+v", err)
+		return errmsg.EmailVerificationFailed
+	}
+
+	return nil
+}
+
+// sendVerification replaces the pending verification token of the user and emails the new one.
+func (s *userService) sendVerification(ctx context.Context, user *domains.User) error {
+	token, err := utils.GenerateRandomString(32)
+	if err != nil {
+		return err
+	}
+
+	if err := s.utr.DeleteByUserID(ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL); err != nil {
+		return err
+	}
+
+	expires := time.Duration(config.Get().User.VerificationExpiresHours) * time.Hour
+	if _, err := s.utr.Create(ctx, &domains.CreateUserTokenRequest{
+		UserId:    user.ID,
+		Purpose:   constants.TOKEN_PURPOSE_VERIFY_EMAIL,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().UTC().Add(expires),
+	}); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nUse this token to verify your email: %s\n", user.Username, token)
+	if link := config.Get().User.VerificationURL; link != "" {
+		body += fmt.Sprintf("or open %s?token=%s\n", link, url.QueryEscape(token))
+	}
+	body += fmt.Sprintf("\nThe token expires in %d hours.\n", int(expires.Hours()))
+
+	return s.mailer.Send(ctx, &domains.Mail{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body:    body,
+	})
+}
+
+// issueTokens generates a new access token and a refresh token belonging to the given family.
+func (s *userService) issueTokens(ctx context.Context, user *domains.User, familyId string) (*domains.LoginResponse, error) {
+	// generate custom claims JWT token
+	token, err := auth.GenerateToken(auth.JWTCustomClaims{
+		UserId:       user.ID.Hex(),
+		Role:         roleOf(user),
+		Verified:     user.Verified,
+		SessionId:    familyId,
+		TokenVersion: user.TokenVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// refresh token is opaque and only its hash is stored
+	refreshToken, err := utils.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.rtr.Create(ctx, &domains.CreateRefreshTokenRequest{
+		UserId:    user.ID,
+		FamilyId:  familyId,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: time.Now().UTC().Add(auth.RefreshTokenDuration()),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &domains.LoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// checkLoginLock refuses the login while any of the keys is locked.
+func (s *userService) checkLoginLock(ctx context.Context, limits map[string]int) error {
+	keys := make([]string, 0, len(limits))
+	for key := range limits {
+		keys = append(keys, key)
+	}
+	attempts, err := s.lar.GetByKeys(ctx, keys)
+	if err != nil {
+		log.Printf("[userService::checkLoginLock::GetByKeys] error => %+v", err)
+		return errmsg.UserLoginFailed
+	}
+
+	now := time.Now().UTC()
+	var retryAfter time.Duration
+	for _, attempt := range attempts {
+		if wait := attempt.LockedUntil.Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	if retryAfter > 0 {
+		return errmsg.NewLoginLockedError(retryAfter)
+	}
+
+	return nil
+}
+
+// completeLogin issues the tokens of a user who passed every login factor.
+func (s *userService) completeLogin(ctx context.Context, user *domains.User) (*domains.LoginResponse, error) {
+	// every login starts a new refresh token family
+	familyId, err := utils.GenerateRandomString(16)
+	if err != nil {
+		log.Printf("[userService::completeLogin::GenerateRandomString] error => %+v", err)
+		return nil, errmsg.UserLoginFailed
+	}
+
+	res, err := s.issueTokens(ctx, user, familyId)
+	if err != nil {
+		log.Printf("[userService::completeLogin::issueTokens] error => %+v", err)
+		return nil, errmsg.UserLoginFailed
+	}
+
+	// start the auto logoff window from now
+	if err := s.ur.UpdateLastActiveAt(ctx, user.ID, time.Now().UTC()); err != nil {
+		log.Printf("[userService::completeLogin::UpdateLastActiveAt] error => %+v", err)
+		return nil, errmsg.UserLoginFailed
+	}
+
+	// the ip keeps its failures, a successful login to one account must not
+	// reset the guesses made against the others
+	if err := s.lar.DeleteByKey(ctx, usernameLoginKey(user.Username)); err != nil {
+		log.Printf("[userService::completeLogin::DeleteByKey] error => %+v", err)
+	}
+
+	return res, nil
+}
+
+// createMFAChallenge returns a short-lived token which LoginMFA exchanges for the real tokens.
+func (s *userService) createMFAChallenge(ctx context.Context, user *domains.User) (*domains.LoginResponse, error) {
+	token, err := utils.GenerateRandomString(32)
+	if err != nil {
+		log.Printf("[userService::createMFAChallenge::GenerateRandomString] error => %+v", err)
+		return nil, errmsg.UserLoginFailed
+	}
+
+	expires := time.Duration(config.Get().User.MFAChallengeExpiresMinutes) * time.Minute
+	if _, err := s.utr.Create(ctx, &domains.CreateUserTokenRequest{
+		UserId:    user.ID,
+		Purpose:   constants.TOKEN_PURPOSE_MFA_CHALLENGE,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().UTC().Add(expires),
+	}); err != nil {
+		log.Printf("[userService::createMFAChallenge::Create] error => %+v", err)
+		return nil, errmsg.UserLoginFailed
+	}
+
+	return &domains.LoginResponse{
+		MFARequired: true,
+		MFAToken:    token,
+	}, nil
+}
+
+// verifyMFACode accepts a TOTP code of a period which wasn't used yet or one of the recovery codes.
+func (s *userService) verifyMFACode(ctx context.Context, user *domains.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if counter, ok := auth.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		return s.ur.UseTOTPCounter(ctx, user.ID, counter)
+	}
+	return s.ur.UseRecoveryCode(ctx, user.ID, utils.HashToken(normalizeRecoveryCode(code)))
+}
+
+// oidcUser finds the user of the identity, a user with the same verified email
+// is linked to it and otherwise a new user is provisioned.
+func (s *userService) oidcUser(ctx context.Context, identity *domains.OIDCIdentity) (*domains.User, error) {
+	user, err := s.ur.GetByOIDCSubject(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		log.Printf("[userService::oidcUser::GetByOIDCSubject] error => %+v", err)
+		return nil, errmsg.OIDCLoginFailed
+	}
+
+	if user != nil {
+		return user, nil
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, errmsg.OIDCEmailNotVerified
+	}
+
+	user, err = s.ur.GetByEmail(ctx, identity.Email)
+	if err != nil {
+		log.Printf("[userService::oidcUser::GetByEmail] error => %+v", err)
+		return nil, errmsg.OIDCLoginFailed
+	}
+
+	if user != nil {
+		// anyone can register with an email they don't own, only an account
+		// which proved the email is handed over
+		if !user.Verified {
+			return nil, errmsg.OIDCAccountConflict
+		}
+		if err := s.ur.LinkOIDC(ctx, user.ID, identity.Issuer, identity.Subject); err != nil {
+			log.Printf("[userService::oidcUser::LinkOIDC] error => %+v", err)
+			return nil, errmsg.OIDCLoginFailed
+		}
+		return user, nil
+	}
+
+	// the user has no password, no password matches it on login and one can
+	// be set with forgot password
+	username := oidcUsername(identity)
+	for attempt := 0; ; attempt++ {
+		user, err = s.ur.Create(ctx, &domains.CreateUserRequest{
+			Username:    username,
+			Email:       identity.Email,
+			Role:        constants.DEFAULT_ROLE,
+			Verified:    true,
+			OIDCIssuer:  identity.Issuer,
+			OIDCSubject: identity.Subject,
+		})
+		// a taken username is retried with a random suffix, the email is unique anyway
+		if !errors.Is(err, domains.ErrUsernameTaken) || attempt == oidcUsernameAttempts {
+			break
+		}
+		suffix, serr := utils.GenerateRandomString(2)
+		if serr != nil {
+			break
+		}
+		username = truncate(oidcUsername(identity), maxUsernameLength-len(suffix)-1) + "-" + suffix
+	}
+	if err != nil {
+		if taken := takenError(err); taken != nil {
+			return nil, taken
+		}
+		log.Printf("[userService::oidcUser::Create] error => %+v", err)
+		return nil, errmsg.OIDCLoginFailed
+	}
+
+	return user, nil
+}
+
+// recordLoginFailure counts the failure against every key and locks the keys
+// which are over their limit, the lockout doubles with every further failure.
+func (s *userService) recordLoginFailure(ctx context.Context, limits map[string]int) {
+	cfg := config.Get().User
+	now := time.Now().UTC()
+	window := time.Duration(cfg.LoginAttemptWindowMinutes) * time.Minute
+	for key, limit := range limits {
+		attempt, err := s.lar.IncrementFailures(ctx, key, now.Add(window))
+		if err != nil {
+			log.Printf("[userService::recordLoginFailure::IncrementFailures] error => %+v", err)
+			continue
+		}
+
+		if attempt.Failures < limit {
+			continue
+		}
+
+		lockout := time.Duration(cfg.LoginLockoutSeconds) * time.Second
+		maxLockout := time.Duration(cfg.LoginMaxLockoutMinutes) * time.Minute
+		for i := limit; i < attempt.Failures && lockout < maxLockout; i++ {
+			lockout *= 2
+		}
+		if lockout > maxLockout {
+			lockout = maxLockout
+		}
+
+		if err := s.lar.Lock(ctx, key, now.Add(lockout)); err != nil {
+			log.Printf("[userService::recordLoginFailure::Lock] error => %+v", err)
+		}
+	}
+}
+
+// loginLimits returns the login attempt keys of the username and the ip with the failures allowed for each.
+func loginLimits(username, ip string) map[string]int {
+	cfg := config.Get().User
+	limits := map[string]int{
+		usernameLoginKey(username): int(cfg.LoginMaxAttempts),
+	}
+	if ip != "" {
+		limits["ip:"+ip] = int(cfg.LoginMaxAttemptsPerIP)
+	}
+	return limits
+}
+
+// oidcUsername makes a username out of the preferred username or the email,
+// keeping the characters people can type and the length of a registration.
+func oidcUsername(identity *domains.OIDCIdentity) string {
+	name := identity.Username
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	var b strings.Builder
+	for _, r := range name {
+		if r < 128 && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("._-", r)) {
+			b.WriteRune(r)
+		}
+	}
+	username := truncate(b.String(), maxUsernameLength)
+	if len(username) < minUsernameLength {
+		username = "user" + username
+	}
+	return username
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func usernameLoginKey(username string) string {
+	return "username:" + strings.ToLower(username)
+}
+
+// takenError maps the unique index errors of the user repository, it returns nil for any other error.
+func takenError(err error) error {
+	switch {
+	case errors.Is(err, domains.ErrUsernameTaken):
+		return errmsg.UserExisted
+	case errors.Is(err, domains.ErrEmailTaken):
+		return errmsg.EmailExisted
+	}
+	return nil
+}
+
+// normalizeRecoveryCode lets recovery codes be typed without dashes or in upper case.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
+
+// roleOf returns the role of the user, users created before roles existed are members.
+func roleOf(user *domains.User) string {
+	if user.Role == "" {
+		return constants.DEFAULT_ROLE
+	}
+	return user.Role
+}
+
+// profileImageBlobs checks an uploaded profile image by its content and
+// returns it with its thumbnails by size, all stored in the folder.
+func profileImageBlobs(folder string, data []byte) (map[string]*domains.Blob, error) {
+	contentType := http.DetectContentType(data)
+	ext, ok := constants.ProfileImageExtension(contentType)
+	if !ok {
+		return nil, errmsg.ProfileImageInvalid
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width == 0 || cfg.Height == 0 || cfg.Width*cfg.Height > constants.PROFILE_IMAGE_MAX_PIXELS {
+		return nil, errmsg.ProfileImageInvalid
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errmsg.ProfileImageInvalid
+	}
+
+	blobs := map[string]*domains.Blob{
+		constants.PROFILE_IMAGE_ORIGINAL: {
+			Key:         folder + "/" + constants.PROFILE_IMAGE_ORIGINAL + ext,
+			ContentType: contentType,
+			Data:        data,
+		},
+	}
+	for _, size := range constants.PROFILE_IMAGE_THUMBNAILS {
+		// photos stay jpeg, png keeps the transparency of the others
+		var buf bytes.Buffer
+		thumbnail := utils.Thumbnail(img, size)
+		thumbnailType, thumbnailExt := "image/png", ".png"
+		if contentType == "image/jpeg" {
+			thumbnailType, thumbnailExt = "image/jpeg", ".jpg"
+			err = jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&buf, thumbnail)
+		}
+		if err != nil {
+			log.Printf("[userService::profileImageBlobs::Encode] error => %+v", err)
+			return nil, errmsg.ProfileImageUploadFailed
+		}
+		blobs[strconv.Itoa(size)] = &domains.Blob{
+			Key:         fmt.Sprintf("%s/%d%s", folder, size, thumbnailExt),
+			ContentType: thumbnailType,
+			Data:        buf.Bytes(),
+		}
+	}
+	return blobs, nil
+}
+
+// deleteBlobs removes files which aren't referenced anymore, a failure only
+// leaves an orphan file behind.
+func (s *userService) deleteBlobs(ctx context.Context, method string, keys map[string]string) {
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			log.Printf("[userService::%s::Delete] error => %+v", method, err)
+		}
+	}
 }
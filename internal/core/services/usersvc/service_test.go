@@ -1,23 +1,55 @@
 package usersvc_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"reflect"
+	"robinhood/config"
+	"robinhood/internal/core/constants"
 	"robinhood/internal/core/domains"
 	"robinhood/internal/core/ports"
 	"robinhood/internal/core/ports/mocks"
 	"robinhood/internal/core/services/usersvc"
 	"robinhood/internal/errmsg"
+	"robinhood/internal/repositories"
+	"robinhood/pkg/auth"
 	"robinhood/pkg/utils"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 type testModule struct {
-	ur  *mocks.UserRepository
-	svc ports.UserService
+	ur     *mocks.UserRepository
+	br     *mocks.BlogRepository
+	cr     *mocks.CommentRepository
+	rtr    *mocks.RefreshTokenRepository
+	rvr    *mocks.RevokedTokenRepository
+	utr    *mocks.UserTokenRepository
+	lar    *mocks.LoginAttemptRepository
+	akr    *mocks.APIKeyRepository
+	osr    *mocks.OIDCStateRepository
+	mailer *mocks.Mailer
+	idp    *mocks.IdentityProvider
+	blobs  *mocks.BlobStore
+	svc    ports.UserService
 }
 
 type test struct {
@@ -31,11 +63,37 @@ var (
 	ctx = context.TODO()
 )
 
+func init() {
+	config.New()
+}
+
 func new(t *testing.T) *testModule {
 	ur := mocks.NewUserRepository(t)
+	br := mocks.NewBlogRepository(t)
+	cr := mocks.NewCommentRepository(t)
+	rtr := mocks.NewRefreshTokenRepository(t)
+	rvr := mocks.NewRevokedTokenRepository(t)
+	utr := mocks.NewUserTokenRepository(t)
+	lar := mocks.NewLoginAttemptRepository(t)
+	akr := mocks.NewAPIKeyRepository(t)
+	osr := mocks.NewOIDCStateRepository(t)
+	mailer := mocks.NewMailer(t)
+	idp := mocks.NewIdentityProvider(t)
+	blobs := mocks.NewBlobStore(t)
 	return &testModule{
-		ur:  ur,
-		svc: usersvc.New(ur),
+		ur:     ur,
+		br:     br,
+		cr:     cr,
+		rtr:    rtr,
+		rvr:    rvr,
+		utr:    utr,
+		lar:    lar,
+		akr:    akr,
+		osr:    osr,
+		mailer: mailer,
+		idp:    idp,
+		blobs:  blobs,
+		svc:    usersvc.New(ur, br, cr, rtr, rvr, utr, lar, akr, osr, mailer, idp, blobs),
 	}
 }
 
@@ -95,7 +153,37 @@ func TestRegister(t *testing.T) {
 			},
 		},
 		{
-			name: "success",
+			name: "return error when username is taken by a concurrent registration",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.ur.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserRequest")).Return(nil, domains.ErrUsernameTaken)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserExisted, err)
+			},
+		},
+		{
+			name: "return error when email is taken",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.ur.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserRequest")).Return(nil, domains.ErrEmailTaken)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailExisted, err)
+			},
+		},
+		{
+			name: "success even when send verification email failed",
 			args: []interface{}{
 				ctx,
 				&domains.RegisterRequest{
@@ -107,6 +195,37 @@ func TestRegister(t *testing.T) {
 			mockFn: func(m *testModule) {
 				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
 				m.ur.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserRequest")).Return(&domains.User{}, nil)
+				m.utr.On("DeleteByUserID", ctx, primitive.NilObjectID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil)
+				m.utr.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserTokenRequest")).Return(&domains.UserToken{}, nil)
+				m.mailer.On("Send", ctx, mock.AnythingOfType("*domains.Mail")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "success and send verification email",
+			args: []interface{}{
+				ctx,
+				&domains.RegisterRequest{
+					Username: "username",
+					Password: "password",
+					Email:    "email",
+				},
+			},
+			mockFn: func(m *testModule) {
+				user := &domains.User{ID: primitive.NewObjectID(), Username: "username", Email: "email"}
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.ur.On("Create", ctx, mock.MatchedBy(func(req *domains.CreateUserRequest) bool {
+					return req.Username == "username" && req.Role == constants.DEFAULT_ROLE
+				})).Return(user, nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil)
+				m.utr.On("Create", ctx, mock.MatchedBy(func(req *domains.CreateUserTokenRequest) bool {
+					return req.UserId == user.ID && req.Purpose == constants.TOKEN_PURPOSE_VERIFY_EMAIL && req.ExpiresAt.After(time.Now())
+				})).Return(&domains.UserToken{}, nil)
+				m.mailer.On("Send", ctx, mock.MatchedBy(func(mail *domains.Mail) bool {
+					return mail.To == user.Email
+				})).Return(nil)
 			},
 			assertFn: func(m *testModule) {
 				assert.NoError(t, err)
@@ -124,15 +243,205 @@ func TestRegister(t *testing.T) {
 	}
 }
 
+// TestRegisterDuplicateOnInsert checks how Register handles a registration
+// which passed the username check but lost the insert, the unique indexes
+// themselves are exercised by TestRegisterDuplicateOnMongo.
+func TestRegisterDuplicateOnInsert(t *testing.T) {
+	m := new(t)
+	mockReq := &domains.RegisterRequest{
+		Username: "username",
+		Password: "password",
+		Email:    "user@mail.com",
+	}
+
+	// every registration passes the username check before any of them is
+	// inserted, the mocked repository only accepts the first insert
+	var mu sync.Mutex
+	created := false
+	m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+	m.ur.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserRequest")).Return(
+		func(ctx context.Context, req *domains.CreateUserRequest) (*domains.User, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if created {
+				return nil, domains.ErrUsernameTaken
+			}
+			created = true
+			return &domains.User{ID: primitive.NewObjectID(), Username: req.Username, Email: req.Email}, nil
+		},
+		nil,
+	)
+	m.utr.On("DeleteByUserID", ctx, mock.AnythingOfType("primitive.ObjectID"), constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil)
+	m.utr.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserTokenRequest")).Return(&domains.UserToken{}, nil)
+	m.mailer.On("Send", ctx, mock.AnythingOfType("*domains.Mail")).Return(nil)
+
+	const n = 10
+	errs := make([]error, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = m.svc.Register(ctx, mockReq)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	wins := 0
+	for _, err := range errs {
+		if err == nil {
+			wins++
+			continue
+		}
+		assert.Equal(t, errmsg.UserExisted, err)
+	}
+	assert.Equal(t, 1, wins)
+	m.mailer.AssertNumberOfCalls(t, "Send", 1)
+}
+
+// TestRegisterDuplicateOnMongo registers users which only differ in case at
+// the same time against a MongoDB, the unique indexes of the user repository
+// let one of them in. It is skipped unless MONGO_URI is set.
+func TestRegisterDuplicateOnMongo(t *testing.T) {
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		t.Skip("MONGO_URI is not set")
+	}
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	mc, err := mongo.Connect(connectCtx, options.Client().ApplyURI(uri))
+	assert.NoError(t, err)
+	assert.NoError(t, mc.Ping(connectCtx, readpref.Primary()))
+	defer mc.Disconnect(ctx)
+
+	type test struct {
+		name          string
+		reqs          [2]*domains.RegisterRequest
+		expectedError error
+	}
+
+	tests := []test{
+		{
+			name: "should let one of two usernames differing in case in",
+			reqs: [2]*domains.RegisterRequest{
+				{Username: "Alice", Password: "password", Email: "alice1@mail.com"},
+				{Username: "alice", Password: "password", Email: "alice2@mail.com"},
+			},
+			expectedError: errmsg.UserExisted,
+		},
+		{
+			name: "should let one of two emails differing in case in",
+			reqs: [2]*domains.RegisterRequest{
+				{Username: "bob1", Password: "password", Email: "Bob@mail.com"},
+				{Username: "bob2", Password: "password", Email: "bob@MAIL.com"},
+			},
+			expectedError: errmsg.EmailExisted,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// a database of its own, dropped afterwards
+			db := "robinhood_test_" + primitive.NewObjectID().Hex()
+			defer mc.Database(db).Drop(ctx)
+
+			m := new(t)
+			m.utr.On("DeleteByUserID", ctx, mock.AnythingOfType("primitive.ObjectID"), constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil)
+			m.utr.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserTokenRequest")).Return(&domains.UserToken{}, nil)
+			m.mailer.On("Send", ctx, mock.AnythingOfType("*domains.Mail")).Return(nil)
+			ur := repositories.NewUserRepository(mc, db)
+			svc := usersvc.New(ur, m.br, m.cr, m.rtr, m.rvr, m.utr, m.lar, m.akr, m.osr, m.mailer, m.idp, m.blobs)
+
+			var errs [2]error
+			start := make(chan struct{})
+			var wg sync.WaitGroup
+			for i, req := range tc.reqs {
+				wg.Add(1)
+				go func(i int, req *domains.RegisterRequest) {
+					defer wg.Done()
+					<-start
+					errs[i] = svc.Register(ctx, req)
+				}(i, req)
+			}
+			close(start)
+			wg.Wait()
+
+			wins := 0
+			for _, err := range errs {
+				if err == nil {
+					wins++
+					continue
+				}
+				assert.Equal(t, tc.expectedError, err)
+			}
+			assert.Equal(t, 1, wins)
+
+			n, err := mc.Database(db).Collection("user").CountDocuments(ctx, bson.M{})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), n)
+		})
+	}
+}
+
 func TestLogin(t *testing.T) {
 	var result *domains.LoginResponse
 	var err error
 	mockReq := &domains.LoginRequest{
 		Username: "username",
 		Password: "password",
+		IP:       "127.0.0.1",
+	}
+	keys := []string{"ip:127.0.0.1", "username:username"}
+	matchKeys := mock.MatchedBy(func(k []string) bool {
+		sort.Strings(k)
+		return reflect.DeepEqual(keys, k)
+	})
+	lockedFor := func(d time.Duration) interface{} {
+		return mock.MatchedBy(func(until time.Time) bool {
+			diff := time.Until(until) - d
+			return diff > -5*time.Second && diff < 5*time.Second
+		})
 	}
 
 	tests := []*test{
+		{
+			name: "return error when get login attempts failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.lar.On("GetByKeys", ctx, matchKeys).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserLoginFailed, err)
+			},
+		},
+		{
+			name: "return locked error without checking password when username or ip is locked",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{
+					{Key: "username:username", LockedUntil: time.Now().UTC().Add(-time.Minute)},
+					{Key: "ip:127.0.0.1", LockedUntil: time.Now().UTC().Add(5 * time.Minute)},
+				}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, errmsg.LoginLocked)
+				var locked *errmsg.LoginLockedError
+				assert.ErrorAs(t, err, &locked)
+				assert.InDelta(t, (5 * time.Minute).Seconds(), locked.RetryAfter.Seconds(), 5)
+			},
+		},
 		{
 			name: "return error when get user by username failed",
 			args: []interface{}{
@@ -140,6 +449,7 @@ func TestLogin(t *testing.T) {
 				mockReq,
 			},
 			mockFn: func(m *testModule) {
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
 				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, errors.New("error"))
 			},
 			assertFn: func(m *testModule) {
@@ -148,13 +458,33 @@ func TestLogin(t *testing.T) {
 			},
 		},
 		{
-			name: "return error when user not found",
+			name: "return error and count failure when user not found",
 			args: []interface{}{
 				ctx,
 				mockReq,
 			},
 			mockFn: func(m *testModule) {
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
 				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.lar.On("IncrementFailures", ctx, "username:username", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 1}, nil)
+				m.lar.On("IncrementFailures", ctx, "ip:127.0.0.1", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 1}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UsernameOrPasswordIncorrect, err)
+			},
+		},
+		{
+			name: "return error and count failure when the user of the identity provider has no password",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(&domains.User{ID: primitive.NewObjectID(), Username: mockReq.Username, OIDCSubject: "subject"}, nil)
+				m.lar.On("IncrementFailures", ctx, "username:username", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 1}, nil)
+				m.lar.On("IncrementFailures", ctx, "ip:127.0.0.1", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 1}, nil)
 			},
 			assertFn: func(m *testModule) {
 				assert.Error(t, err)
@@ -168,10 +498,13 @@ func TestLogin(t *testing.T) {
 				mockReq,
 			},
 			mockFn: func(m *testModule) {
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
 				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(&domains.User{
 					Username: mockReq.Username,
 					Password: mockReq.Password,
 				}, nil)
+				m.lar.On("IncrementFailures", ctx, "username:username", mock.AnythingOfType("time.Time")).Return(nil, errors.New("error"))
+				m.lar.On("IncrementFailures", ctx, "ip:127.0.0.1", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 1}, nil)
 			},
 			assertFn: func(m *testModule) {
 				assert.Error(t, err)
@@ -179,21 +512,157 @@ func TestLogin(t *testing.T) {
 			},
 		},
 		{
-			name: "success",
+			name: "lock username when failures reach the limit",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.lar.On("IncrementFailures", ctx, "username:username", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 5}, nil)
+				m.lar.On("IncrementFailures", ctx, "ip:127.0.0.1", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 5}, nil)
+				m.lar.On("Lock", ctx, "username:username", lockedFor(30*time.Second)).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UsernameOrPasswordIncorrect, err)
+			},
+		},
+		{
+			name: "double the lockout on every failure after the limit up to the max",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.lar.On("IncrementFailures", ctx, "username:username", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 8}, nil)
+				m.lar.On("IncrementFailures", ctx, "ip:127.0.0.1", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 100}, nil)
+				m.lar.On("Lock", ctx, "username:username", lockedFor(240*time.Second)).Return(nil)
+				m.lar.On("Lock", ctx, "ip:127.0.0.1", lockedFor(time.Hour)).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UsernameOrPasswordIncorrect, err)
+			},
+		},
+		{
+			name: "return error when create refresh token failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				hash, _ := utils.HashPassword(mockReq.Password, utils.DefaultCost)
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(&domains.User{
+					Username: mockReq.Username,
+					Password: hash,
+				}, nil)
+				m.rtr.On("Create", ctx, mock.AnythingOfType("*domains.CreateRefreshTokenRequest")).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserLoginFailed, err)
+			},
+		},
+		{
+			name: "return error when user is deactivated",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				hash, _ := utils.HashPassword(mockReq.Password, utils.DefaultCost)
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(&domains.User{
+					ID:          primitive.NewObjectID(),
+					Username:    mockReq.Username,
+					Password:    hash,
+					Deactivated: true,
+				}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeactivated, err)
+			},
+		},
+		{
+			name: "return mfa challenge instead of tokens when mfa is enabled",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				hash, _ := utils.HashPassword(mockReq.Password, utils.DefaultCost)
+				user := &domains.User{
+					ID:         primitive.NewObjectID(),
+					Username:   mockReq.Username,
+					Password:   hash,
+					MFAEnabled: true,
+				}
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(user, nil)
+				m.utr.On("Create", ctx, mock.MatchedBy(func(req *domains.CreateUserTokenRequest) bool {
+					return req.UserId == user.ID && req.Purpose == constants.TOKEN_PURPOSE_MFA_CHALLENGE &&
+						req.ExpiresAt.Before(time.Now().Add(5*time.Minute+time.Second))
+				})).Return(&domains.UserToken{}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.True(t, result.MFARequired)
+				assert.NotEmpty(t, result.MFAToken)
+				assert.Empty(t, result.Token)
+				assert.Empty(t, result.RefreshToken)
+			},
+		},
+		{
+			name: "return error when create mfa challenge failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				hash, _ := utils.HashPassword(mockReq.Password, utils.DefaultCost)
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(&domains.User{
+					Username:   mockReq.Username,
+					Password:   hash,
+					MFAEnabled: true,
+				}, nil)
+				m.utr.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserTokenRequest")).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserLoginFailed, err)
+			},
+		},
+		{
+			name: "success and reset failures of the username",
 			args: []interface{}{
 				ctx,
 				mockReq,
 			},
 			mockFn: func(m *testModule) {
 				hash, _ := utils.HashPassword(mockReq.Password, utils.DefaultCost)
+				m.lar.On("GetByKeys", ctx, matchKeys).Return([]domains.LoginAttempt{
+					{Key: "username:username", Failures: 3},
+				}, nil)
 				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(&domains.User{
 					Username: mockReq.Username,
 					Password: hash,
 				}, nil)
+				m.rtr.On("Create", ctx, mock.AnythingOfType("*domains.CreateRefreshTokenRequest")).Return(&domains.RefreshToken{}, nil)
+				m.ur.On("UpdateLastActiveAt", ctx, mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("time.Time")).Return(nil)
+				m.lar.On("DeleteByKey", ctx, "username:username").Return(nil)
 			},
 			assertFn: func(m *testModule) {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
+				assert.NotEmpty(t, result.Token)
+				assert.NotEmpty(t, result.RefreshToken)
 			},
 		},
 	}
@@ -208,50 +677,4146 @@ func TestLogin(t *testing.T) {
 	}
 }
 
-func TestUpdate(t *testing.T) {
-	var result *domains.User
+func TestLoginMFA(t *testing.T) {
+	var result *domains.LoginResponse
 	var err error
-	mockReq := &domains.UpdateUserRequest{
-		UserId:       "user_id",
-		ProfileImage: "profile_image",
+	secret, _ := auth.GenerateTOTPSecret()
+	code, _ := auth.GenerateTOTPCode(secret, time.Now())
+	user := &domains.User{
+		ID:         primitive.NewObjectID(),
+		Username:   "username",
+		MFAEnabled: true,
+		TOTPSecret: secret,
+	}
+	mockReq := &domains.LoginMFARequest{
+		MFAToken: "mfa_token",
+		Code:     code,
+	}
+	hash := utils.HashToken(mockReq.MFAToken)
+	ut := &domains.UserToken{
+		ID:        primitive.NewObjectID(),
+		UserId:    user.ID,
+		Purpose:   constants.TOKEN_PURPOSE_MFA_CHALLENGE,
+		TokenHash: hash,
+		ExpiresAt: time.Now().UTC().Add(time.Minute),
 	}
+	keys := []string{"username:username"}
 
 	tests := []*test{
 		{
-			name: "return error when update failed",
+			name: "return error when get challenge failed",
 			args: []interface{}{
 				ctx,
 				mockReq,
 			},
 			mockFn: func(m *testModule) {
-				m.ur.On("Update", ctx, mockReq).Return(nil, errors.New("error"))
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(nil, errors.New("error"))
 			},
 			assertFn: func(m *testModule) {
 				assert.Error(t, err)
-				assert.EqualError(t, err, "error")
+				assert.Equal(t, errmsg.MFAFailed, err)
 			},
 		},
 		{
-			name: "success",
+			name: "return error when challenge is expired",
 			args: []interface{}{
 				ctx,
 				mockReq,
 			},
 			mockFn: func(m *testModule) {
-				m.ur.On("Update", ctx, mockReq).Return(&domains.User{}, nil)
+				expired := *ut
+				expired.ExpiresAt = time.Now().UTC().Add(-time.Second)
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(&expired, nil)
 			},
 			assertFn: func(m *testModule) {
-				assert.NoError(t, err)
-				assert.NotNil(t, result)
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAChallengeInvalid, err)
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			m := new(t)
-			tc.mockFn(m)
-			result, err = m.svc.Update(tc.args[0].(context.Context), tc.args[1].(*domains.UpdateUserRequest))
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(ut, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAChallengeInvalid, err)
+			},
+		},
+		{
+			name: "return locked error when username is locked",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(ut, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.lar.On("GetByKeys", ctx, keys).Return([]domains.LoginAttempt{
+					{Key: "username:username", LockedUntil: time.Now().UTC().Add(time.Minute)},
+				}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, errmsg.LoginLocked)
+			},
+		},
+		{
+			name: "return error and count failure when code is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.LoginMFARequest{
+					MFAToken: mockReq.MFAToken,
+					Code:     "invalid",
+				},
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(ut, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.lar.On("GetByKeys", ctx, keys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("UseRecoveryCode", ctx, user.ID, utils.HashToken("invalid")).Return(false, nil)
+				m.lar.On("IncrementFailures", ctx, "username:username", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 1}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFACodeInvalid, err)
+			},
+		},
+		{
+			name: "return error when totp code is replayed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(ut, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.lar.On("GetByKeys", ctx, keys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("UseTOTPCounter", ctx, user.ID, mock.AnythingOfType("int64")).Return(false, nil)
+				m.lar.On("IncrementFailures", ctx, "username:username", mock.AnythingOfType("time.Time")).Return(&domains.LoginAttempt{Failures: 1}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFACodeInvalid, err)
+			},
+		},
+		{
+			name: "return error when verify code failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(ut, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.lar.On("GetByKeys", ctx, keys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("UseTOTPCounter", ctx, user.ID, mock.AnythingOfType("int64")).Return(false, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAFailed, err)
+			},
+		},
+		{
+			name: "return error when challenge is already used",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(ut, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.lar.On("GetByKeys", ctx, keys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("UseTOTPCounter", ctx, user.ID, mock.AnythingOfType("int64")).Return(true, nil)
+				m.utr.On("MarkUsed", ctx, ut.ID).Return(false, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAChallengeInvalid, err)
+			},
+		},
+		{
+			name: "success with totp code",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(ut, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.lar.On("GetByKeys", ctx, keys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("UseTOTPCounter", ctx, user.ID, mock.AnythingOfType("int64")).Return(true, nil)
+				m.utr.On("MarkUsed", ctx, ut.ID).Return(true, nil)
+				m.rtr.On("Create", ctx, mock.AnythingOfType("*domains.CreateRefreshTokenRequest")).Return(&domains.RefreshToken{}, nil)
+				m.ur.On("UpdateLastActiveAt", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(nil)
+				m.lar.On("DeleteByKey", ctx, "username:username").Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+				assert.NotEmpty(t, result.RefreshToken)
+			},
+		},
+		{
+			name: "success with recovery code",
+			args: []interface{}{
+				ctx,
+				&domains.LoginMFARequest{
+					MFAToken: mockReq.MFAToken,
+					Code:     " ABCD-1234-EF56 ",
+				},
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_MFA_CHALLENGE, hash).Return(ut, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.lar.On("GetByKeys", ctx, keys).Return([]domains.LoginAttempt{}, nil)
+				m.ur.On("UseRecoveryCode", ctx, user.ID, utils.HashToken("abcd1234ef56")).Return(true, nil)
+				m.utr.On("MarkUsed", ctx, ut.ID).Return(true, nil)
+				m.rtr.On("Create", ctx, mock.AnythingOfType("*domains.CreateRefreshTokenRequest")).Return(&domains.RefreshToken{}, nil)
+				m.ur.On("UpdateLastActiveAt", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(nil)
+				m.lar.On("DeleteByKey", ctx, "username:username").Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.LoginMFA(tc.args[0].(context.Context), tc.args[1].(*domains.LoginMFARequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	var result *domains.OIDCLoginResponse
+	var err error
+
+	tests := []*test{
+		{
+			name: "return error when state can't be stored",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Create", ctx, mock.AnythingOfType("*domains.CreateOIDCStateRequest")).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCLoginFailed, err)
+			},
+		},
+		{
+			name: "return error when discovery failed",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Create", ctx, mock.AnythingOfType("*domains.CreateOIDCStateRequest")).Return(&domains.OIDCState{}, nil)
+				m.idp.On("AuthCodeURL", ctx, mock.AnythingOfType("*domains.AuthCodeURLRequest")).Return("", errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCLoginFailed, err)
+			},
+		},
+		{
+			name: "success and store the hash of the state with the pkce verifier",
+			args: []interface{}{
+				ctx,
+			},
+			mockFn: func(m *testModule) {
+				var stored *domains.CreateOIDCStateRequest
+				m.osr.On("Create", ctx, mock.MatchedBy(func(req *domains.CreateOIDCStateRequest) bool {
+					stored = req
+					return req.StateHash != "" && req.Nonce != "" && req.CodeVerifier != "" &&
+						req.ExpiresAt.After(time.Now()) && req.ExpiresAt.Before(time.Now().Add(11*time.Minute))
+				})).Return(&domains.OIDCState{}, nil)
+				m.idp.On("AuthCodeURL", ctx, mock.MatchedBy(func(req *domains.AuthCodeURLRequest) bool {
+					return utils.HashToken(req.State) == stored.StateHash && req.Nonce == stored.Nonce &&
+						req.CodeChallenge == auth.CodeChallengeS256(stored.CodeVerifier)
+				})).Return("https://idp.local/authorize?state=state", nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, "https://idp.local/authorize?state=state", result.URL)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.OIDCLogin(tc.args[0].(context.Context))
+			tc.assertFn(m)
+		})
+	}
+
+	t.Run("return error when identity provider is not configured", func(t *testing.T) {
+		svc := usersvc.New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		_, err := svc.OIDCLogin(ctx)
+		assert.Equal(t, errmsg.OIDCNotConfigured, err)
+	})
+}
+
+func TestOIDCCallback(t *testing.T) {
+	var result *domains.LoginResponse
+	var err error
+	mockReq := &domains.OIDCCallbackRequest{
+		State: "state",
+		Code:  "code",
+	}
+	stateHash := utils.HashToken(mockReq.State)
+	st := &domains.OIDCState{
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+		ExpiresAt:    time.Now().UTC().Add(time.Minute),
+	}
+	exchange := &domains.ExchangeCodeRequest{
+		Code:         mockReq.Code,
+		CodeVerifier: st.CodeVerifier,
+		Nonce:        st.Nonce,
+	}
+	identity := &domains.OIDCIdentity{
+		Issuer:        "https://idp.local",
+		Subject:       "subject",
+		Email:         "alice@company.com",
+		EmailVerified: true,
+		Username:      "alice",
+	}
+	loggedIn := func(m *testModule) {
+		m.rtr.On("Create", ctx, mock.AnythingOfType("*domains.CreateRefreshTokenRequest")).Return(&domains.RefreshToken{}, nil)
+		m.ur.On("UpdateLastActiveAt", ctx, mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("time.Time")).Return(nil)
+		m.lar.On("DeleteByKey", ctx, mock.AnythingOfType("string")).Return(nil)
+	}
+
+	tests := []*test{
+		{
+			name: "return error when consume state failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCLoginFailed, err)
+			},
+		},
+		{
+			name: "return error when state is unknown or already used",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCStateInvalid, err)
+			},
+		},
+		{
+			name: "return error when state is expired",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(&domains.OIDCState{ExpiresAt: time.Now().UTC().Add(-time.Second)}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCStateInvalid, err)
+			},
+		},
+		{
+			name: "return error when code exchange failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCLoginFailed, err)
+			},
+		},
+		{
+			name: "success with a linked user",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(identity, nil)
+				m.ur.On("GetByOIDCSubject", ctx, identity.Issuer, identity.Subject).Return(&domains.User{
+					ID:         primitive.NewObjectID(),
+					Username:   "alice",
+					MFAEnabled: true,
+				}, nil)
+				loggedIn(m)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+				assert.NotEmpty(t, result.RefreshToken)
+				assert.False(t, result.MFARequired)
+			},
+		},
+		{
+			name: "return error when linked user is deactivated",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(identity, nil)
+				m.ur.On("GetByOIDCSubject", ctx, identity.Issuer, identity.Subject).Return(&domains.User{
+					ID:          primitive.NewObjectID(),
+					Deactivated: true,
+				}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeactivated, err)
+			},
+		},
+		{
+			name: "return error when email isn't verified by the identity provider",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(&domains.OIDCIdentity{
+					Issuer:  identity.Issuer,
+					Subject: identity.Subject,
+					Email:   identity.Email,
+				}, nil)
+				m.ur.On("GetByOIDCSubject", ctx, identity.Issuer, identity.Subject).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCEmailNotVerified, err)
+			},
+		},
+		{
+			name: "return error without linking when the user of the email hasn't verified it",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(identity, nil)
+				m.ur.On("GetByOIDCSubject", ctx, identity.Issuer, identity.Subject).Return(nil, nil)
+				m.ur.On("GetByEmail", ctx, identity.Email).Return(&domains.User{ID: primitive.NewObjectID()}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCAccountConflict, err)
+			},
+		},
+		{
+			name: "return error when link user failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				uid := primitive.NewObjectID()
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(identity, nil)
+				m.ur.On("GetByOIDCSubject", ctx, identity.Issuer, identity.Subject).Return(nil, nil)
+				m.ur.On("GetByEmail", ctx, identity.Email).Return(&domains.User{ID: uid, Verified: true}, nil)
+				m.ur.On("LinkOIDC", ctx, uid, identity.Issuer, identity.Subject).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCLoginFailed, err)
+			},
+		},
+		{
+			name: "success and link the user with the same verified email",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				uid := primitive.NewObjectID()
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(identity, nil)
+				m.ur.On("GetByOIDCSubject", ctx, identity.Issuer, identity.Subject).Return(nil, nil)
+				m.ur.On("GetByEmail", ctx, identity.Email).Return(&domains.User{ID: uid, Username: "alice", Verified: true}, nil)
+				m.ur.On("LinkOIDC", ctx, uid, identity.Issuer, identity.Subject).Return(nil)
+				loggedIn(m)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+			},
+		},
+		{
+			name: "return error when provision user failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(identity, nil)
+				m.ur.On("GetByOIDCSubject", ctx, identity.Issuer, identity.Subject).Return(nil, nil)
+				m.ur.On("GetByEmail", ctx, identity.Email).Return(nil, nil)
+				m.ur.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserRequest")).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.OIDCLoginFailed, err)
+			},
+		},
+		{
+			name: "success and provision a verified user linked to the identity",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(identity, nil)
+				m.ur.On("GetByOIDCSubject", ctx, identity.Issuer, identity.Subject).Return(nil, nil)
+				m.ur.On("GetByEmail", ctx, identity.Email).Return(nil, nil)
+				m.ur.On("Create", ctx, mock.MatchedBy(func(req *domains.CreateUserRequest) bool {
+					return req.Username == "alice" && req.Email == identity.Email && req.Verified &&
+						req.Role == constants.DEFAULT_ROLE && req.Password == "" &&
+						req.OIDCIssuer == identity.Issuer && req.OIDCSubject == identity.Subject
+				})).Return(&domains.User{ID: primitive.NewObjectID(), Username: "alice"}, nil)
+				loggedIn(m)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+				m.mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+			},
+		},
+		{
+			name: "success and provision with a suffix when the username is taken",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.osr.On("Consume", ctx, stateHash).Return(st, nil)
+				m.idp.On("Exchange", ctx, exchange).Return(&domains.OIDCIdentity{
+					Issuer:        identity.Issuer,
+					Subject:       identity.Subject,
+					Email:         "Alice Wonderland+robinhood@company.com",
+					EmailVerified: true,
+				}, nil)
+				m.ur.On("GetByOIDCSubject", ctx, identity.Issuer, identity.Subject).Return(nil, nil)
+				m.ur.On("GetByEmail", ctx, "Alice Wonderland+robinhood@company.com").Return(nil, nil)
+				m.ur.On("Create", ctx, mock.MatchedBy(func(req *domains.CreateUserRequest) bool {
+					return req.Username == "AliceWonderlandrobin"
+				})).Return(nil, domains.ErrUsernameTaken).Once()
+				m.ur.On("Create", ctx, mock.MatchedBy(func(req *domains.CreateUserRequest) bool {
+					return strings.HasPrefix(req.Username, "AliceWonderland-") && len(req.Username) == 20
+				})).Return(&domains.User{ID: primitive.NewObjectID(), Username: "AliceWonderland-0000"}, nil).Once()
+				loggedIn(m)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.OIDCCallback(tc.args[0].(context.Context), tc.args[1].(*domains.OIDCCallbackRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	var result *domains.EnrollTOTPResponse
+	var err error
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+	}
+	userId := user.ID.Hex()
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				"invalid",
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user failed",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAFailed, err)
+			},
+		},
+		{
+			name: "return error when mfa is already enabled",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{ID: user.ID, MFAEnabled: true}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAAlreadyEnabled, err)
+			},
+		},
+		{
+			name: "return error when update secret failed",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("UpdateTOTPSecret", ctx, user.ID, mock.AnythingOfType("string")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAFailed, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("UpdateTOTPSecret", ctx, user.ID, mock.AnythingOfType("string")).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Secret)
+				assert.True(t, strings.HasPrefix(result.URI, "otpauth://totp/Robinhood:username?"))
+				assert.Contains(t, result.URI, "secret="+result.Secret)
+				m.ur.AssertCalled(t, "UpdateTOTPSecret", ctx, user.ID, result.Secret)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.EnrollTOTP(tc.args[0].(context.Context), tc.args[1].(string))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	var result *domains.ConfirmTOTPResponse
+	var err error
+	secret, _ := auth.GenerateTOTPSecret()
+	code, _ := auth.GenerateTOTPCode(secret, time.Now())
+	user := &domains.User{
+		ID:         primitive.NewObjectID(),
+		Username:   "username",
+		TOTPSecret: secret,
+	}
+	mockReq := &domains.ConfirmTOTPRequest{
+		UserId: user.ID.Hex(),
+		Code:   code,
+	}
+
+	tests := []*test{
+		{
+			name: "return error when get user failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAFailed, err)
+			},
+		},
+		{
+			name: "return error when mfa is already enabled",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{ID: user.ID, TOTPSecret: secret, MFAEnabled: true}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAAlreadyEnabled, err)
+			},
+		},
+		{
+			name: "return error when totp is not enrolled",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{ID: user.ID}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFANotEnrolled, err)
+			},
+		},
+		{
+			name: "return error when code is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.ConfirmTOTPRequest{
+					UserId: mockReq.UserId,
+					Code:   "12345",
+				},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFACodeInvalid, err)
+			},
+		},
+		{
+			name: "return error when enable mfa failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("EnableMFA", ctx, user.ID, mock.AnythingOfType("[]string")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.MFAFailed, err)
+			},
+		},
+		{
+			name: "success and store only hashed recovery codes",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("EnableMFA", ctx, user.ID, mock.AnythingOfType("[]string")).Return(nil)
+				m.ur.On("UseTOTPCounter", ctx, user.ID, mock.AnythingOfType("int64")).Return(true, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Len(t, result.RecoveryCodes, 10)
+				hashes := m.ur.Calls[1].Arguments.Get(2).([]string)
+				for i, code := range result.RecoveryCodes {
+					assert.Equal(t, utils.HashToken(strings.ReplaceAll(code, "-", "")), hashes[i])
+				}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.ConfirmTOTP(tc.args[0].(context.Context), tc.args[1].(*domains.ConfirmTOTPRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	var result *domains.LoginResponse
+	var err error
+	mockReq := &domains.RefreshTokenRequest{
+		RefreshToken: "refresh_token",
+	}
+	hash := utils.HashToken(mockReq.RefreshToken)
+	now := time.Now().UTC()
+	rt := &domains.RefreshToken{
+		ID:        primitive.NewObjectID(),
+		UserId:    primitive.NewObjectID(),
+		FamilyId:  "family_id",
+		TokenHash: hash,
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	tests := []*test{
+		{
+			name: "return error when get refresh token failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.rtr.On("GetByTokenHash", ctx, hash).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.RefreshTokenFailed, err)
+			},
+		},
+		{
+			name: "return error when refresh token not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.rtr.On("GetByTokenHash", ctx, hash).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.RefreshTokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when refresh token is revoked",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				revoked := *rt
+				revoked.RevokedAt = &now
+				m.rtr.On("GetByTokenHash", ctx, hash).Return(&revoked, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.RefreshTokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when refresh token is expired",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				expired := *rt
+				expired.ExpiresAt = now.Add(-time.Hour)
+				m.rtr.On("GetByTokenHash", ctx, hash).Return(&expired, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.RefreshTokenExpired, err)
+			},
+		},
+		{
+			name: "revoke family when refresh token is reused",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				used := *rt
+				used.UsedAt = &now
+				m.rtr.On("GetByTokenHash", ctx, hash).Return(&used, nil)
+				m.rtr.On("RevokeFamily", ctx, rt.FamilyId).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.RefreshTokenReused, err)
+			},
+		},
+		{
+			name: "revoke family when refresh token is rotated concurrently",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.rtr.On("GetByTokenHash", ctx, hash).Return(rt, nil)
+				m.rtr.On("MarkUsed", ctx, rt.ID).Return(false, nil)
+				m.rtr.On("RevokeFamily", ctx, rt.FamilyId).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.RefreshTokenReused, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.rtr.On("GetByTokenHash", ctx, hash).Return(rt, nil)
+				m.rtr.On("MarkUsed", ctx, rt.ID).Return(true, nil)
+				m.ur.On("GetByID", ctx, rt.UserId).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.RefreshTokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when user is idle longer than auto logoff",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.rtr.On("GetByTokenHash", ctx, hash).Return(rt, nil)
+				m.rtr.On("MarkUsed", ctx, rt.ID).Return(true, nil)
+				m.ur.On("GetByID", ctx, rt.UserId).Return(&domains.User{
+					ID: rt.UserId,
+				}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.TokenIdleExpired, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.rtr.On("GetByTokenHash", ctx, hash).Return(rt, nil)
+				m.rtr.On("MarkUsed", ctx, rt.ID).Return(true, nil)
+				m.ur.On("GetByID", ctx, rt.UserId).Return(&domains.User{
+					ID:           rt.UserId,
+					LastActiveAt: now,
+				}, nil)
+				m.rtr.On("Create", ctx, mock.MatchedBy(func(req *domains.CreateRefreshTokenRequest) bool {
+					return req.FamilyId == rt.FamilyId && req.UserId == rt.UserId
+				})).Return(&domains.RefreshToken{}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+				assert.NotEmpty(t, result.RefreshToken)
+				assert.NotEqual(t, mockReq.RefreshToken, result.RefreshToken)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.RefreshToken(tc.args[0].(context.Context), tc.args[1].(*domains.RefreshTokenRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	var err error
+	mockReq := &domains.LogoutRequest{
+		UserId:    primitive.NewObjectID().Hex(),
+		TokenId:   "token_id",
+		SessionId: "session_id",
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+
+	tests := []*test{
+		{
+			name: "return error when revoke token failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.rvr.On("Create", ctx, mock.AnythingOfType("*domains.RevokeTokenRequest")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserLogoutFailed, err)
+			},
+		},
+		{
+			name: "return error when revoke refresh token family failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.rvr.On("Create", ctx, mock.AnythingOfType("*domains.RevokeTokenRequest")).Return(nil)
+				m.rtr.On("RevokeFamily", ctx, mockReq.SessionId).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserLogoutFailed, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.rvr.On("Create", ctx, &domains.RevokeTokenRequest{
+					TokenId:   mockReq.TokenId,
+					UserId:    mockReq.UserId,
+					ExpiresAt: mockReq.ExpiresAt,
+				}).Return(nil)
+				m.rtr.On("RevokeFamily", ctx, mockReq.SessionId).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.Logout(tc.args[0].(context.Context), tc.args[1].(*domains.LogoutRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	var err error
+	uid := primitive.NewObjectID()
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				"invalid_id",
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when increment token version failed",
+			args: []interface{}{
+				ctx,
+				uid.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("IncrementTokenVersion", ctx, uid).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserLogoutFailed, err)
+			},
+		},
+		{
+			name: "return error when revoke refresh tokens failed",
+			args: []interface{}{
+				ctx,
+				uid.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("IncrementTokenVersion", ctx, uid).Return(nil)
+				m.rtr.On("RevokeByUserID", ctx, uid).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserLogoutFailed, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				uid.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("IncrementTokenVersion", ctx, uid).Return(nil)
+				m.rtr.On("RevokeByUserID", ctx, uid).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.LogoutAll(tc.args[0].(context.Context), tc.args[1].(string))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestForgotPassword(t *testing.T) {
+	var err error
+	mockReq := &domains.ForgotPasswordRequest{
+		Email: "user@mail.com",
+	}
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+		Email:    mockReq.Email,
+	}
+
+	tests := []*test{
+		{
+			name: "return error when get user by email failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordResetFailed, err)
+			},
+		},
+		{
+			name: "success without sending email when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "return error when create token failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(user, nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_RESET_PASSWORD).Return(nil)
+				m.utr.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserTokenRequest")).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordResetFailed, err)
+			},
+		},
+		{
+			name: "return error when send email failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(user, nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_RESET_PASSWORD).Return(nil)
+				m.utr.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserTokenRequest")).Return(&domains.UserToken{}, nil)
+				m.mailer.On("Send", ctx, mock.AnythingOfType("*domains.Mail")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordResetFailed, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				var hash string
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(user, nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_RESET_PASSWORD).Return(nil)
+				m.utr.On("Create", ctx, mock.MatchedBy(func(req *domains.CreateUserTokenRequest) bool {
+					hash = req.TokenHash
+					return req.UserId == user.ID && req.Purpose == constants.TOKEN_PURPOSE_RESET_PASSWORD && req.ExpiresAt.After(time.Now())
+				})).Return(&domains.UserToken{}, nil)
+				m.mailer.On("Send", ctx, mock.MatchedBy(func(mail *domains.Mail) bool {
+					// the mail carries the raw token, only its hash is stored
+					token := strings.Fields(strings.SplitN(mail.Body, "password: ", 2)[1])[0]
+					return mail.To == user.Email && utils.HashToken(token) == hash
+				})).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.ForgotPassword(tc.args[0].(context.Context), tc.args[1].(*domains.ForgotPasswordRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	var err error
+	mockReq := &domains.ResetPasswordRequest{
+		Token:    "reset_token",
+		Password: "new_password",
+	}
+	hash := utils.HashToken(mockReq.Token)
+	now := time.Now().UTC()
+	ut := &domains.UserToken{
+		ID:        primitive.NewObjectID(),
+		UserId:    primitive.NewObjectID(),
+		Purpose:   constants.TOKEN_PURPOSE_RESET_PASSWORD,
+		TokenHash: hash,
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	tests := []*test{
+		{
+			name: "return error when get token failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_RESET_PASSWORD, hash).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordResetFailed, err)
+			},
+		},
+		{
+			name: "return error when token not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_RESET_PASSWORD, hash).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordResetTokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when token is expired",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				expired := *ut
+				expired.ExpiresAt = now.Add(-time.Minute)
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_RESET_PASSWORD, hash).Return(&expired, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordResetTokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when token is already used",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_RESET_PASSWORD, hash).Return(ut, nil)
+				m.utr.On("MarkUsed", ctx, ut.ID).Return(false, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordResetTokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when update password failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_RESET_PASSWORD, hash).Return(ut, nil)
+				m.utr.On("MarkUsed", ctx, ut.ID).Return(true, nil)
+				m.ur.On("UpdatePassword", ctx, ut.UserId, mock.AnythingOfType("string")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordResetFailed, err)
+			},
+		},
+		{
+			name: "success and logout from all devices",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_RESET_PASSWORD, hash).Return(ut, nil)
+				m.utr.On("MarkUsed", ctx, ut.ID).Return(true, nil)
+				m.ur.On("UpdatePassword", ctx, ut.UserId, mock.MatchedBy(func(password string) bool {
+					return utils.CheckPasswordHash(mockReq.Password, password)
+				})).Return(nil)
+				m.ur.On("IncrementTokenVersion", ctx, ut.UserId).Return(nil)
+				m.rtr.On("RevokeByUserID", ctx, ut.UserId).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.ResetPassword(tc.args[0].(context.Context), tc.args[1].(*domains.ResetPasswordRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	var err error
+	mockReq := &domains.VerifyEmailRequest{
+		Token: "verify_token",
+	}
+	hash := utils.HashToken(mockReq.Token)
+	ut := &domains.UserToken{
+		ID:        primitive.NewObjectID(),
+		UserId:    primitive.NewObjectID(),
+		Purpose:   constants.TOKEN_PURPOSE_VERIFY_EMAIL,
+		TokenHash: hash,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+
+	tests := []*test{
+		{
+			name: "return error when get token failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_VERIFY_EMAIL, hash).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailVerificationFailed, err)
+			},
+		},
+		{
+			name: "return error when token not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_VERIFY_EMAIL, hash).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailVerificationTokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when token is expired",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				expired := *ut
+				expired.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_VERIFY_EMAIL, hash).Return(&expired, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailVerificationTokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when token is already used",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_VERIFY_EMAIL, hash).Return(ut, nil)
+				m.utr.On("MarkUsed", ctx, ut.ID).Return(false, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailVerificationTokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when update verified failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_VERIFY_EMAIL, hash).Return(ut, nil)
+				m.utr.On("MarkUsed", ctx, ut.ID).Return(true, nil)
+				m.ur.On("UpdateVerified", ctx, ut.UserId).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailVerificationFailed, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.utr.On("GetByTokenHash", ctx, constants.TOKEN_PURPOSE_VERIFY_EMAIL, hash).Return(ut, nil)
+				m.utr.On("MarkUsed", ctx, ut.ID).Return(true, nil)
+				m.ur.On("UpdateVerified", ctx, ut.UserId).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.VerifyEmail(tc.args[0].(context.Context), tc.args[1].(*domains.VerifyEmailRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	var err error
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+		Email:    "user@mail.com",
+	}
+	userId := user.ID.Hex()
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				"invalid",
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user failed",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailVerificationFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when email is already verified",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{ID: user.ID, Verified: true}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailAlreadyVerified, err)
+			},
+		},
+		{
+			name: "return error when get latest token failed",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.utr.On("GetLatestByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailVerificationFailed, err)
+			},
+		},
+		{
+			name: "return error when email was sent within the resend window",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.utr.On("GetLatestByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(&domains.UserToken{
+					CreatedAt: time.Now().UTC().Add(-time.Second),
+				}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailVerificationThrottled, err)
+			},
+		},
+		{
+			name: "return error when send email failed",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.utr.On("GetLatestByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil, nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil)
+				m.utr.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserTokenRequest")).Return(&domains.UserToken{}, nil)
+				m.mailer.On("Send", ctx, mock.AnythingOfType("*domains.Mail")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailVerificationFailed, err)
+			},
+		},
+		{
+			name: "success after the resend window",
+			args: []interface{}{
+				ctx,
+				userId,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.utr.On("GetLatestByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(&domains.UserToken{
+					CreatedAt: time.Now().UTC().Add(-time.Hour),
+				}, nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil)
+				m.utr.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserTokenRequest")).Return(&domains.UserToken{}, nil)
+				m.mailer.On("Send", ctx, mock.MatchedBy(func(mail *domains.Mail) bool {
+					return mail.To == user.Email
+				})).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.ResendVerification(tc.args[0].(context.Context), tc.args[1].(string))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestGetUserByID(t *testing.T) {
+	var result *domains.User
+	var err error
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				"invalid",
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserGetFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when user is deactivated",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{ID: user.ID, Deactivated: true}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, user, result)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.GetUserByID(tc.args[0].(context.Context), tc.args[1].(string))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestSearchUser(t *testing.T) {
+	var result *domains.SearchUserResponse
+	var err error
+	users := []domains.User{
+		{ID: primitive.NewObjectID(), Username: "user1"},
+		{ID: primitive.NewObjectID(), Username: "user2"},
+	}
+	listReq := &domains.PaginationOptions{Offset: 0, Limit: 10}
+	countReq := &domains.PaginationOptions{Offset: 0, Limit: 11}
+
+	tests := []*test{
+		{
+			name: "return error when search failed",
+			args: []interface{}{
+				ctx,
+				&domains.SearchUserRequest{Query: "user"},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("Search", ctx, "user", listReq).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserSearchFailed, err)
+			},
+		},
+		{
+			name: "return error when count failed",
+			args: []interface{}{
+				ctx,
+				&domains.SearchUserRequest{Query: "user"},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("Search", ctx, "user", listReq).Return(users, nil)
+				m.ur.On("CountSearch", ctx, "user", countReq).Return(int64(0), errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserSearchFailed, err)
+			},
+		},
+		{
+			name: "success with next page",
+			args: []interface{}{
+				ctx,
+				&domains.SearchUserRequest{Query: "user", Page: 2, Limit: 2},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("Search", ctx, "user", &domains.PaginationOptions{Offset: 2, Limit: 2}).Return(users, nil)
+				m.ur.On("CountSearch", ctx, "user", &domains.PaginationOptions{Offset: 2, Limit: 3}).Return(int64(3), nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, users, result.Data)
+				assert.True(t, result.HasNext)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				&domains.SearchUserRequest{Query: "user"},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("Search", ctx, "user", listReq).Return(users, nil)
+				m.ur.On("CountSearch", ctx, "user", countReq).Return(int64(2), nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, users, result.Data)
+				assert.False(t, result.HasNext)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.SearchUser(tc.args[0].(context.Context), tc.args[1].(*domains.SearchUserRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var result *domains.User
+	var err error
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+	}
+	mockReq := &domains.UpdateUserRequest{
+		UserId:       user.ID.Hex(),
+		ProfileImage: "profile_image",
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateUserRequest{UserId: "invalid"},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when update failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("Update", ctx, mockReq).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "success and delete the uploaded image",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{
+					ID:               user.ID,
+					ProfileImageKeys: map[string]string{"original": "profile/original.png", "64": "profile/64.png"},
+				}, nil)
+				m.ur.On("Update", ctx, mockReq).Return(&domains.User{}, nil)
+				m.blobs.On("Delete", ctx, "profile/original.png").Return(nil)
+				m.blobs.On("Delete", ctx, "profile/64.png").Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("Update", ctx, mockReq).Return(&domains.User{}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.Update(tc.args[0].(context.Context), tc.args[1].(*domains.UpdateUserRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestUploadProfileImage(t *testing.T) {
+	var result *domains.User
+	var err error
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+	}
+	oldKeys := map[string]string{"original": "profile/old/original.png"}
+	maxBytes := int64(config.Get().User.ProfileImageMaxBytes)
+
+	encode := func(contentType string, w, h int) []byte {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		for x := 0; x < w; x++ {
+			img.Set(x, x%h, color.RGBA{R: 255, A: 255})
+		}
+		var buf bytes.Buffer
+		switch contentType {
+		case "image/jpeg":
+			_ = jpeg.Encode(&buf, img, nil)
+		case "image/gif":
+			_ = gif.Encode(&buf, img, nil)
+		default:
+			_ = png.Encode(&buf, img)
+		}
+		return buf.Bytes()
+	}
+	jpegImage := encode("image/jpeg", 400, 300)
+	pngImage := encode("image/png", 20, 30)
+	gifImage := encode("image/gif", 100, 100)
+
+	// isProfileImage checks the uploaded files of a size by their key and content
+	isProfileImage := func(blob *domains.Blob, contentType string, size int) bool {
+		if !strings.HasPrefix(blob.Key, "profile/"+user.ID.Hex()+"/") || blob.ContentType != contentType {
+			return false
+		}
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(blob.Data))
+		if err != nil || "image/"+format != contentType {
+			return false
+		}
+		return size == 0 || (cfg.Width == size && cfg.Height == size)
+	}
+
+	request := func(data []byte) *domains.UploadProfileImageRequest {
+		return &domains.UploadProfileImageRequest{
+			UserId: user.ID.Hex(),
+			Size:   int64(len(data)),
+			File:   bytes.NewReader(data),
+		}
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.UploadProfileImageRequest{UserId: "invalid"},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when the declared size is too large",
+			args: []interface{}{
+				ctx,
+				&domains.UploadProfileImageRequest{UserId: user.ID.Hex(), Size: maxBytes + 1},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageTooLarge, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				request(pngImage),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageUploadFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				request(pngImage),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when the file is larger than declared",
+			args: []interface{}{
+				ctx,
+				&domains.UploadProfileImageRequest{
+					UserId: user.ID.Hex(),
+					Size:   1,
+					File:   bytes.NewReader(make([]byte, maxBytes+1)),
+				},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageTooLarge, err)
+			},
+		},
+		{
+			name: "return error when the file is not an image",
+			args: []interface{}{
+				ctx,
+				request([]byte("<html><body>not an image</body></html>")),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageInvalid, err)
+			},
+		},
+		{
+			name: "return error when the image is broken",
+			args: []interface{}{
+				ctx,
+				request(pngImage[:len(pngImage)/2]),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageInvalid, err)
+			},
+		},
+		{
+			name: "return error and delete the stored files when put failed",
+			args: []interface{}{
+				ctx,
+				request(pngImage),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.blobs.On("Put", ctx, mock.Anything).Return(nil).Once()
+				m.blobs.On("Put", ctx, mock.Anything).Return(errors.New("error")).Once()
+				m.blobs.On("Delete", ctx, mock.AnythingOfType("string")).Return(nil).Once()
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageUploadFailed, err)
+			},
+		},
+		{
+			name: "return error and delete the stored files when update failed",
+			args: []interface{}{
+				ctx,
+				request(pngImage),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.blobs.On("Put", ctx, mock.Anything).Return(nil).Times(3)
+				m.ur.On("UpdateProfileImage", ctx, user.ID, mock.AnythingOfType("string"), mock.Anything).Return(nil, errors.New("error"))
+				m.blobs.On("Delete", ctx, mock.AnythingOfType("string")).Return(nil).Times(3)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageUploadFailed, err)
+			},
+		},
+		{
+			name: "success with jpeg thumbnails of a photo and delete the old image",
+			args: []interface{}{
+				ctx,
+				request(jpegImage),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{ID: user.ID, ProfileImageKeys: oldKeys}, nil)
+				m.blobs.On("Put", ctx, mock.MatchedBy(func(b *domains.Blob) bool {
+					return isProfileImage(b, "image/jpeg", 0) && strings.HasSuffix(b.Key, "/original.jpg") && bytes.Equal(b.Data, jpegImage)
+				})).Return(nil).Once()
+				m.blobs.On("Put", ctx, mock.MatchedBy(func(b *domains.Blob) bool {
+					return isProfileImage(b, "image/jpeg", 64) && strings.HasSuffix(b.Key, "/64.jpg")
+				})).Return(nil).Once()
+				m.blobs.On("Put", ctx, mock.MatchedBy(func(b *domains.Blob) bool {
+					return isProfileImage(b, "image/jpeg", 256) && strings.HasSuffix(b.Key, "/256.jpg")
+				})).Return(nil).Once()
+				m.ur.On("UpdateProfileImage", ctx, user.ID, mock.MatchedBy(func(link string) bool {
+					return strings.HasPrefix(link, config.Get().App.PublicURL+"/user/"+user.ID.Hex()+"/profile-image?v=")
+				}), mock.MatchedBy(func(keys map[string]string) bool {
+					return len(keys) == 3 && keys["original"] != "" && keys["64"] != "" && keys["256"] != ""
+				})).Return(user, nil)
+				m.blobs.On("Delete", ctx, "profile/old/original.png").Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, user, result)
+			},
+		},
+		{
+			name: "success with png thumbnails of a small png",
+			args: []interface{}{
+				ctx,
+				request(pngImage),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.blobs.On("Put", ctx, mock.MatchedBy(func(b *domains.Blob) bool {
+					return isProfileImage(b, "image/png", 0) && strings.HasSuffix(b.Key, "/original.png")
+				})).Return(nil).Once()
+				m.blobs.On("Put", ctx, mock.MatchedBy(func(b *domains.Blob) bool {
+					return isProfileImage(b, "image/png", 64) && strings.HasSuffix(b.Key, "/64.png")
+				})).Return(nil).Once()
+				m.blobs.On("Put", ctx, mock.MatchedBy(func(b *domains.Blob) bool {
+					return isProfileImage(b, "image/png", 256) && strings.HasSuffix(b.Key, "/256.png")
+				})).Return(nil).Once()
+				m.ur.On("UpdateProfileImage", ctx, user.ID, mock.AnythingOfType("string"), mock.Anything).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, user, result)
+			},
+		},
+		{
+			name: "success with png thumbnails of a gif",
+			args: []interface{}{
+				ctx,
+				request(gifImage),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.blobs.On("Put", ctx, mock.MatchedBy(func(b *domains.Blob) bool {
+					return isProfileImage(b, "image/gif", 0) && strings.HasSuffix(b.Key, "/original.gif")
+				})).Return(nil).Once()
+				m.blobs.On("Put", ctx, mock.MatchedBy(func(b *domains.Blob) bool {
+					return isProfileImage(b, "image/png", 64) || isProfileImage(b, "image/png", 256)
+				})).Return(nil).Twice()
+				m.ur.On("UpdateProfileImage", ctx, user.ID, mock.AnythingOfType("string"), mock.Anything).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, user, result)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.UploadProfileImage(tc.args[0].(context.Context), tc.args[1].(*domains.UploadProfileImageRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestProfileImageURL(t *testing.T) {
+	var result string
+	var err error
+	user := &domains.User{
+		ID:               primitive.NewObjectID(),
+		Username:         "username",
+		ProfileImageKeys: map[string]string{"original": "profile/original.png", "256": "profile/256.png"},
+	}
+	expires := time.Duration(config.Get().Blob.URLExpiresMinutes) * time.Minute
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.ProfileImageURLRequest{UserId: "invalid"},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				&domains.ProfileImageURLRequest{UserId: user.ID.Hex()},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageGetFailed, err)
+			},
+		},
+		{
+			name: "return error when user is deactivated",
+			args: []interface{}{
+				ctx,
+				&domains.ProfileImageURLRequest{UserId: user.ID.Hex()},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{ID: user.ID, Deactivated: true, ProfileImageKeys: user.ProfileImageKeys}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when no image is uploaded",
+			args: []interface{}{
+				ctx,
+				&domains.ProfileImageURLRequest{UserId: user.ID.Hex()},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{ID: user.ID}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageNotFound, err)
+			},
+		},
+		{
+			name: "return error when the size is unknown",
+			args: []interface{}{
+				ctx,
+				&domains.ProfileImageURLRequest{UserId: user.ID.Hex(), Size: "1000"},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageNotFound, err)
+			},
+		},
+		{
+			name: "return error when sign url failed",
+			args: []interface{}{
+				ctx,
+				&domains.ProfileImageURLRequest{UserId: user.ID.Hex()},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.blobs.On("SignedURL", ctx, "profile/256.png", expires).Return("", errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.ProfileImageGetFailed, err)
+			},
+		},
+		{
+			name: "success with the default size",
+			args: []interface{}{
+				ctx,
+				&domains.ProfileImageURLRequest{UserId: user.ID.Hex()},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.blobs.On("SignedURL", ctx, "profile/256.png", expires).Return("https://signed/256", nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, "https://signed/256", result)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				&domains.ProfileImageURLRequest{UserId: user.ID.Hex(), Size: "original"},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.blobs.On("SignedURL", ctx, "profile/original.png", expires).Return("https://signed/original", nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, "https://signed/original", result)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.ProfileImageURL(tc.args[0].(context.Context), tc.args[1].(*domains.ProfileImageURLRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	var result *domains.LoginResponse
+	var err error
+	hash, _ := utils.HashPassword("current_password", utils.DefaultCost)
+	user := &domains.User{
+		ID:           primitive.NewObjectID(),
+		Username:     "username",
+		Password:     hash,
+		TokenVersion: 2,
+	}
+	mockReq := &domains.ChangePasswordRequest{
+		UserId:          user.ID.Hex(),
+		CurrentPassword: "current_password",
+		NewPassword:     "new_password",
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.ChangePasswordRequest{UserId: "invalid"},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when current password is incorrect",
+			args: []interface{}{
+				ctx,
+				&domains.ChangePasswordRequest{
+					UserId:          mockReq.UserId,
+					CurrentPassword: "wrong_password",
+					NewPassword:     mockReq.NewPassword,
+				},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordIncorrect, err)
+			},
+		},
+		{
+			name: "return error when update password failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("UpdatePassword", ctx, user.ID, mock.AnythingOfType("string")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "return error when revoke sessions failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("UpdatePassword", ctx, user.ID, mock.AnythingOfType("string")).Return(nil)
+				m.ur.On("IncrementTokenVersion", ctx, user.ID).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "success and issue new tokens after revoking every session",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				// the service bumps the token version of the loaded user
+				u := *user
+				m.ur.On("GetByID", ctx, user.ID).Return(&u, nil)
+				m.ur.On("UpdatePassword", ctx, user.ID, mock.MatchedBy(func(password string) bool {
+					return utils.CheckPasswordHash(mockReq.NewPassword, password)
+				})).Return(nil)
+				m.ur.On("IncrementTokenVersion", ctx, user.ID).Return(nil)
+				m.rtr.On("RevokeByUserID", ctx, user.ID).Return(nil)
+				m.rtr.On("Create", ctx, mock.AnythingOfType("*domains.CreateRefreshTokenRequest")).Return(&domains.RefreshToken{}, nil)
+				m.ur.On("UpdateLastActiveAt", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(nil)
+				m.lar.On("DeleteByKey", ctx, "username:username").Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.RefreshToken)
+				claims, _ := auth.ParseToken(result.Token)
+				assert.Equal(t, 3, claims.TokenVersion)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.ChangePassword(tc.args[0].(context.Context), tc.args[1].(*domains.ChangePasswordRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	var result *domains.User
+	var err error
+	hash, _ := utils.HashPassword("password", utils.DefaultCost)
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+		Password: hash,
+		Email:    "old@mail.com",
+		Verified: true,
+	}
+	mockReq := &domains.ChangeEmailRequest{
+		UserId:   user.ID.Hex(),
+		Email:    "new@mail.com",
+		Password: "password",
+	}
+	updatedUser := &domains.User{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    mockReq.Email,
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when password is incorrect",
+			args: []interface{}{
+				ctx,
+				&domains.ChangeEmailRequest{
+					UserId:   mockReq.UserId,
+					Email:    mockReq.Email,
+					Password: "wrong_password",
+				},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordIncorrect, err)
+			},
+		},
+		{
+			name: "return error when get user by email failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "return error when email is taken",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(&domains.User{ID: primitive.NewObjectID()}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailExisted, err)
+			},
+		},
+		{
+			name: "return error when update email failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(nil, nil)
+				m.ur.On("UpdateEmail", ctx, user.ID, mockReq.Email).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "return error when email is taken concurrently",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(nil, nil)
+				m.ur.On("UpdateEmail", ctx, user.ID, mockReq.Email).Return(nil, domains.ErrEmailTaken)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.EmailExisted, err)
+			},
+		},
+		{
+			name: "success and send verification email to the new email",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("GetByEmail", ctx, mockReq.Email).Return(nil, nil)
+				m.ur.On("UpdateEmail", ctx, user.ID, mockReq.Email).Return(updatedUser, nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil)
+				m.utr.On("Create", ctx, mock.AnythingOfType("*domains.CreateUserTokenRequest")).Return(&domains.UserToken{}, nil)
+				m.mailer.On("Send", ctx, mock.MatchedBy(func(mail *domains.Mail) bool {
+					return mail.To == mockReq.Email
+				})).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, mockReq.Email, result.Email)
+				assert.False(t, result.Verified)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.ChangeEmail(tc.args[0].(context.Context), tc.args[1].(*domains.ChangeEmailRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestChangeUsername(t *testing.T) {
+	var result *domains.User
+	var err error
+	uid := primitive.NewObjectID()
+	mockReq := &domains.ChangeUsernameRequest{
+		UserId:   uid.Hex(),
+		Username: "new_username",
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.ChangeUsernameRequest{UserId: "invalid"},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user by username failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "return error when username is taken",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(&domains.User{}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserExisted, err)
+			},
+		},
+		{
+			name: "return error when update username failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.ur.On("UpdateUsername", ctx, uid, mockReq.Username).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "return error when username is taken concurrently",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.ur.On("UpdateUsername", ctx, uid, mockReq.Username).Return(nil, domains.ErrUsernameTaken)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserExisted, err)
+			},
+		},
+		{
+			name: "success when changing only the case of the own username",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(&domains.User{ID: uid, Username: "New_Username"}, nil)
+				m.ur.On("UpdateUsername", ctx, uid, mockReq.Username).Return(&domains.User{ID: uid, Username: mockReq.Username}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, mockReq.Username, result.Username)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.ur.On("UpdateUsername", ctx, uid, mockReq.Username).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByUsername", ctx, mockReq.Username).Return(nil, nil)
+				m.ur.On("UpdateUsername", ctx, uid, mockReq.Username).Return(&domains.User{ID: uid, Username: mockReq.Username}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, mockReq.Username, result.Username)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.ChangeUsername(tc.args[0].(context.Context), tc.args[1].(*domains.ChangeUsernameRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestDeactivate(t *testing.T) {
+	var err error
+	hash, _ := utils.HashPassword("password", utils.DefaultCost)
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+		Password: hash,
+	}
+	mockReq := &domains.DeactivateUserRequest{
+		UserId:   user.ID.Hex(),
+		Password: "password",
+	}
+	// provisioned by the identity provider, it has no password of its own
+	oidcUser := &domains.User{
+		ID:          user.ID,
+		Username:    "username",
+		OIDCIssuer:  "https://idp.example.com",
+		OIDCSubject: "subject",
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.DeactivateUserRequest{UserId: "invalid", Password: "password"},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeactivateFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when password is incorrect",
+			args: []interface{}{
+				ctx,
+				&domains.DeactivateUserRequest{UserId: mockReq.UserId, Password: "wrong_password"},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordIncorrect, err)
+			},
+		},
+		{
+			name: "return error when deactivate failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("Deactivate", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeactivateFailed, err)
+			},
+		},
+		{
+			name: "return error when logout from all devices failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("Deactivate", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(nil)
+				m.ur.On("IncrementTokenVersion", ctx, user.ID).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeactivateFailed, err)
+			},
+		},
+		{
+			name: "return error when password is missing",
+			args: []interface{}{
+				ctx,
+				&domains.DeactivateUserRequest{UserId: mockReq.UserId},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordIncorrect, err)
+			},
+		},
+		{
+			name: "success without a password when the user of the identity provider has none",
+			args: []interface{}{
+				ctx,
+				&domains.DeactivateUserRequest{UserId: mockReq.UserId},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(oidcUser, nil)
+				m.ur.On("Deactivate", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(nil)
+				m.ur.On("IncrementTokenVersion", ctx, user.ID).Return(nil)
+				m.rtr.On("RevokeByUserID", ctx, user.ID).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "success and logout from all devices",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.ur.On("Deactivate", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(nil)
+				m.ur.On("IncrementTokenVersion", ctx, user.ID).Return(nil)
+				m.rtr.On("RevokeByUserID", ctx, user.ID).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.Deactivate(tc.args[0].(context.Context), tc.args[1].(*domains.DeactivateUserRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var err error
+	hash, _ := utils.HashPassword("password", utils.DefaultCost)
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "Username",
+		Password: hash,
+	}
+	mockReq := &domains.DeleteUserRequest{
+		UserId:   user.ID.Hex(),
+		Password: "password",
+	}
+	// provisioned by the identity provider, it has no password of its own
+	oidcUser := &domains.User{
+		ID:          user.ID,
+		Username:    "Username",
+		OIDCIssuer:  "https://idp.example.com",
+		OIDCSubject: "subject",
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeleteFailed, err)
+			},
+		},
+		{
+			name: "return error when password is incorrect",
+			args: []interface{}{
+				ctx,
+				&domains.DeleteUserRequest{UserId: mockReq.UserId, Password: "wrong_password"},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordIncorrect, err)
+			},
+		},
+		{
+			name: "return error when password is missing",
+			args: []interface{}{
+				ctx,
+				&domains.DeleteUserRequest{UserId: mockReq.UserId},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.PasswordIncorrect, err)
+				m.ur.AssertNotCalled(t, "Delete", ctx, user.ID)
+			},
+		},
+		{
+			name: "success without a password when the user of the identity provider has none",
+			args: []interface{}{
+				ctx,
+				&domains.DeleteUserRequest{UserId: mockReq.UserId},
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(oidcUser, nil)
+				m.br.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.br.On("UnassignUser", ctx, mockReq.UserId).Return(nil)
+				m.cr.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.ur.On("Delete", ctx, user.ID).Return(nil)
+				m.rtr.On("RevokeByUserID", ctx, user.ID).Return(nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, mock.AnythingOfType("string")).Return(nil)
+				m.lar.On("DeleteByKey", ctx, "username:username").Return(nil)
+				m.akr.On("DeleteByUserID", ctx, user.ID).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "return error and keep the user when anonymize blogs failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.br.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeleteFailed, err)
+				m.ur.AssertNotCalled(t, "Delete", ctx, user.ID)
+			},
+		},
+		{
+			name: "return error and keep the user when unassign from blogs failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.br.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.br.On("UnassignUser", ctx, mockReq.UserId).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeleteFailed, err)
+				m.ur.AssertNotCalled(t, "Delete", ctx, user.ID)
+			},
+		},
+		{
+			name: "return error and keep the user when anonymize comments failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.br.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.br.On("UnassignUser", ctx, mockReq.UserId).Return(nil)
+				m.cr.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeleteFailed, err)
+				m.ur.AssertNotCalled(t, "Delete", ctx, user.ID)
+			},
+		},
+		{
+			name: "return error when delete user failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.br.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.br.On("UnassignUser", ctx, mockReq.UserId).Return(nil)
+				m.cr.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.ur.On("Delete", ctx, user.ID).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeleteFailed, err)
+			},
+		},
+		{
+			name: "success even when cleaning up tokens failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.br.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.br.On("UnassignUser", ctx, mockReq.UserId).Return(nil)
+				m.cr.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.ur.On("Delete", ctx, user.ID).Return(nil)
+				m.rtr.On("RevokeByUserID", ctx, user.ID).Return(errors.New("error"))
+				m.utr.On("DeleteByUserID", ctx, user.ID, mock.AnythingOfType("string")).Return(errors.New("error"))
+				m.lar.On("DeleteByKey", ctx, "username:username").Return(errors.New("error"))
+				m.akr.On("DeleteByUserID", ctx, user.ID).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "success and clean up tokens, login attempts and api keys",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.br.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.br.On("UnassignUser", ctx, mockReq.UserId).Return(nil)
+				m.cr.On("AnonymizeAuthor", ctx, mockReq.UserId).Return(nil)
+				m.ur.On("Delete", ctx, user.ID).Return(nil)
+				m.rtr.On("RevokeByUserID", ctx, user.ID).Return(nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_RESET_PASSWORD).Return(nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_VERIFY_EMAIL).Return(nil)
+				m.utr.On("DeleteByUserID", ctx, user.ID, constants.TOKEN_PURPOSE_MFA_CHALLENGE).Return(nil)
+				m.lar.On("DeleteByKey", ctx, "username:username").Return(nil)
+				m.akr.On("DeleteByUserID", ctx, user.ID).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.Delete(tc.args[0].(context.Context), tc.args[1].(*domains.DeleteUserRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	var err error
+	var w *mocks.UserExportWriter
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+	}
+	blogs := []domains.Blog{
+		{ID: primitive.NewObjectID(), AuthorId: user.ID, Title: "title"},
+		{ID: primitive.NewObjectID(), AuthorId: user.ID, Title: "title 2"},
+	}
+	comments := []domains.Comment{{ID: primitive.NewObjectID(), AuthorId: user.ID, Content: "content"}}
+
+	// eachBlog and eachComment hand the items to the writer like the
+	// repositories do with their cursors
+	eachBlog := func(ctx context.Context, authorId string, fn func(domains.Blog) error) error {
+		for _, blog := range blogs {
+			if err := fn(blog); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	eachComment := func(ctx context.Context, authorId string, fn func(domains.Comment) error) error {
+		for _, comment := range comments {
+			if err := fn(comment); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				"invalid",
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserExportFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when write profile failed",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				w.On("WriteProfile", *user).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserExportFailed, err)
+			},
+		},
+		{
+			name: "return error when read blogs failed",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				w.On("WriteProfile", *user).Return(nil)
+				m.br.On("EachByAuthorID", ctx, user.ID.Hex(), mock.Anything).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserExportFailed, err)
+			},
+		},
+		{
+			name: "return error when write blog failed",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				w.On("WriteProfile", *user).Return(nil)
+				m.br.On("EachByAuthorID", ctx, user.ID.Hex(), mock.Anything).Return(eachBlog)
+				w.On("WriteBlog", blogs[0]).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserExportFailed, err)
+				w.AssertNotCalled(t, "WriteBlog", blogs[1])
+			},
+		},
+		{
+			name: "return error when read comments failed",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				w.On("WriteProfile", *user).Return(nil)
+				m.br.On("EachByAuthorID", ctx, user.ID.Hex(), mock.Anything).Return(eachBlog)
+				w.On("WriteBlog", mock.AnythingOfType("domains.Blog")).Return(nil)
+				m.cr.On("EachByAuthorID", ctx, user.ID.Hex(), mock.Anything).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserExportFailed, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				user.ID.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				w.On("WriteProfile", *user).Return(nil)
+				m.br.On("EachByAuthorID", ctx, user.ID.Hex(), mock.Anything).Return(eachBlog)
+				w.On("WriteBlog", mock.AnythingOfType("domains.Blog")).Return(nil)
+				m.cr.On("EachByAuthorID", ctx, user.ID.Hex(), mock.Anything).Return(eachComment)
+				w.On("WriteComment", mock.AnythingOfType("domains.Comment")).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				// the profile, the blogs and then the comments in the order read
+				var written []interface{}
+				for _, call := range w.Calls {
+					written = append(written, call.Arguments.Get(0))
+				}
+				assert.Equal(t, []interface{}{*user, blogs[0], blogs[1], comments[0]}, written)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			w = mocks.NewUserExportWriter(t)
+			tc.mockFn(m)
+			err = m.svc.Export(tc.args[0].(context.Context), tc.args[1].(string), w)
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	var result *domains.CreateAPIKeyResponse
+	var err error
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "username",
+	}
+	expiresAt := time.Now().Add(24 * time.Hour)
+	mockReq := &domains.CreateAPIKeyRequest{
+		UserId:    user.ID.Hex(),
+		Name:      "ci",
+		Scopes:    []string{constants.PERMISSION_BLOG_READ, constants.PERMISSION_BLOG_WRITE},
+		ExpiresAt: &expiresAt,
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.CreateAPIKeyRequest{UserId: "invalid", Name: "ci", Scopes: mockReq.Scopes},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when there is no scope",
+			args: []interface{}{
+				ctx,
+				&domains.CreateAPIKeyRequest{UserId: mockReq.UserId, Name: "ci"},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyInvalidScope, err)
+			},
+		},
+		{
+			name: "return error when a scope can't be given to an api key",
+			args: []interface{}{
+				ctx,
+				&domains.CreateAPIKeyRequest{
+					UserId: mockReq.UserId,
+					Name:   "ci",
+					Scopes: []string{constants.PERMISSION_BLOG_READ, constants.PERMISSION_USER_MANAGE},
+				},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyInvalidScope, err)
+			},
+		},
+		{
+			name: "return error when expiry is in the past",
+			args: []interface{}{
+				ctx,
+				&domains.CreateAPIKeyRequest{
+					UserId:    mockReq.UserId,
+					Name:      "ci",
+					Scopes:    mockReq.Scopes,
+					ExpiresAt: func() *time.Time { t := time.Now().Add(-time.Minute); return &t }(),
+				},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyInvalidExpiry, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyCreateFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when create api key failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.akr.On("Create", ctx, mock.AnythingOfType("*domains.StoreAPIKeyRequest")).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyCreateFailed, err)
+			},
+		},
+		{
+			name: "success and store only the hash of the key",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.akr.On("Create", ctx, mock.MatchedBy(func(req *domains.StoreAPIKeyRequest) bool {
+					return req.UserId == user.ID && req.Name == mockReq.Name &&
+						reflect.DeepEqual(req.Scopes, mockReq.Scopes) && req.ExpiresAt == mockReq.ExpiresAt &&
+						strings.HasPrefix(req.Prefix, constants.API_KEY_PREFIX) && len(req.KeyHash) == 64
+				})).Return(
+					func(ctx context.Context, req *domains.StoreAPIKeyRequest) (*domains.APIKey, error) {
+						return &domains.APIKey{
+							ID:        primitive.NewObjectID(),
+							UserId:    req.UserId,
+							Name:      req.Name,
+							Prefix:    req.Prefix,
+							KeyHash:   req.KeyHash,
+							Scopes:    req.Scopes,
+							ExpiresAt: req.ExpiresAt,
+						}, nil
+					},
+				)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(result.Key, result.APIKey.Prefix))
+				assert.Equal(t, utils.HashToken(result.Key), result.APIKey.KeyHash)
+				assert.NotEqual(t, result.Key, result.APIKey.KeyHash)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.CreateAPIKey(tc.args[0].(context.Context), tc.args[1].(*domains.CreateAPIKeyRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestListAPIKeys(t *testing.T) {
+	var result []domains.APIKey
+	var err error
+	uid := primitive.NewObjectID()
+	apiKeys := []domains.APIKey{{ID: primitive.NewObjectID(), UserId: uid, Name: "ci"}}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				"invalid",
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when list api keys failed",
+			args: []interface{}{
+				ctx,
+				uid.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("ListByUserID", ctx, uid).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyListFailed, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				uid.Hex(),
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("ListByUserID", ctx, uid).Return(apiKeys, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, apiKeys, result)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.ListAPIKeys(tc.args[0].(context.Context), tc.args[1].(string))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	var err error
+	uid := primitive.NewObjectID()
+	id := primitive.NewObjectID()
+	mockReq := &domains.RevokeAPIKeyRequest{
+		UserId:   uid.Hex(),
+		APIKeyId: id.Hex(),
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.RevokeAPIKeyRequest{UserId: "invalid", APIKeyId: id.Hex()},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when api key id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.RevokeAPIKeyRequest{UserId: uid.Hex(), APIKeyId: "invalid"},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyNotFound, err)
+			},
+		},
+		{
+			name: "return error when delete api key failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("Delete", ctx, id, uid).Return(false, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyRevokeFailed, err)
+			},
+		},
+		{
+			name: "return error when api key is not found or belongs to another user",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("Delete", ctx, id, uid).Return(false, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyNotFound, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("Delete", ctx, id, uid).Return(true, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.RevokeAPIKey(tc.args[0].(context.Context), tc.args[1].(*domains.RevokeAPIKeyRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	var err error
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Username: "UserName",
+	}
+	mockReq := &domains.UnlockUserRequest{
+		UserId: user.ID.Hex(),
+	}
+
+	tests := []*test{
+		{
+			name: "return error when user id is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.UnlockUserRequest{UserId: "invalid"},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when get user failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUnlockFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "return error when delete login attempts failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.lar.On("DeleteByKey", ctx, "username:username").Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUnlockFailed, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.lar.On("DeleteByKey", ctx, "username:username").Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			err = m.svc.Unlock(tc.args[0].(context.Context), tc.args[1].(*domains.UnlockUserRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestUpdateRole(t *testing.T) {
+	var result *domains.User
+	var err error
+	mockReq := &domains.UpdateUserRoleRequest{
+		UserId: "user_id",
+		Role:   constants.ROLE_EDITOR,
+	}
+
+	tests := []*test{
+		{
+			name: "return error when role is invalid",
+			args: []interface{}{
+				ctx,
+				&domains.UpdateUserRoleRequest{
+					UserId: "user_id",
+					Role:   "owner",
+				},
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserInvalidRole, err)
+			},
+		},
+		{
+			name: "return error when update role failed",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("UpdateRole", ctx, mockReq).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserUpdateFailed, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("UpdateRole", ctx, mockReq).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserNotFound, err)
+			},
+		},
+		{
+			name: "success",
+			args: []interface{}{
+				ctx,
+				mockReq,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("UpdateRole", ctx, mockReq).Return(&domains.User{Role: constants.ROLE_EDITOR}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, constants.ROLE_EDITOR, result.Role)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.UpdateRole(tc.args[0].(context.Context), tc.args[1].(*domains.UpdateUserRoleRequest))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	var result *auth.JWTCustomClaims
+	var err error
+	uid := primitive.NewObjectID()
+	token, _ := auth.GenerateToken(auth.JWTCustomClaims{UserId: uid.Hex(), SessionId: "session_id", TokenVersion: 1})
+
+	tests := []*test{
+		{
+			name: "return error when token is invalid",
+			args: []interface{}{
+				ctx,
+				"invalid_token",
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.TokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				token,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, uid).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.InternalServer, err)
+			},
+		},
+		{
+			name: "return error when user not found",
+			args: []interface{}{
+				ctx,
+				token,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, uid).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.TokenInvalid, err)
+			},
+		},
+		{
+			name: "return error when user is deactivated",
+			args: []interface{}{
+				ctx,
+				token,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, uid).Return(&domains.User{
+					ID:           uid,
+					TokenVersion: 1,
+					Deactivated:  true,
+				}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeactivated, err)
+			},
+		},
+		{
+			name: "return error when token version is outdated",
+			args: []interface{}{
+				ctx,
+				token,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, uid).Return(&domains.User{
+					ID:           uid,
+					TokenVersion: 2,
+				}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.TokenRevoked, err)
+			},
+		},
+		{
+			name: "return error when check revoked token failed",
+			args: []interface{}{
+				ctx,
+				token,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, uid).Return(&domains.User{
+					ID:           uid,
+					TokenVersion: 1,
+				}, nil)
+				m.rvr.On("IsRevoked", ctx, mock.AnythingOfType("string")).Return(false, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.InternalServer, err)
+			},
+		},
+		{
+			name: "return error when token is revoked",
+			args: []interface{}{
+				ctx,
+				token,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, uid).Return(&domains.User{
+					ID:           uid,
+					TokenVersion: 1,
+				}, nil)
+				m.rvr.On("IsRevoked", ctx, mock.AnythingOfType("string")).Return(true, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.TokenRevoked, err)
+			},
+		},
+		{
+			name: "return error when user is idle longer than auto logoff",
+			args: []interface{}{
+				ctx,
+				token,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, uid).Return(&domains.User{
+					ID:           uid,
+					TokenVersion: 1,
+					LastActiveAt: time.Now().UTC().Add(-auth.AutoLogoffDuration() - time.Hour),
+				}, nil)
+				m.rvr.On("IsRevoked", ctx, mock.AnythingOfType("string")).Return(false, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.TokenIdleExpired, err)
+			},
+		},
+		{
+			name: "success and refresh last activity",
+			args: []interface{}{
+				ctx,
+				token,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, uid).Return(&domains.User{
+					ID:           uid,
+					Role:         constants.ROLE_ADMIN,
+					TokenVersion: 1,
+					LastActiveAt: time.Now().UTC().Add(-time.Hour),
+				}, nil)
+				m.rvr.On("IsRevoked", ctx, mock.AnythingOfType("string")).Return(false, nil)
+				m.ur.On("UpdateLastActiveAt", ctx, uid, mock.AnythingOfType("time.Time")).Return(nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, uid.Hex(), result.UserId)
+				assert.Equal(t, constants.ROLE_ADMIN, result.Role)
+			},
+		},
+		{
+			name: "success without refreshing recent activity",
+			args: []interface{}{
+				ctx,
+				token,
+			},
+			mockFn: func(m *testModule) {
+				m.ur.On("GetByID", ctx, uid).Return(&domains.User{
+					ID:           uid,
+					TokenVersion: 1,
+					LastActiveAt: time.Now().UTC(),
+				}, nil)
+				m.rvr.On("IsRevoked", ctx, mock.AnythingOfType("string")).Return(false, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, uid.Hex(), result.UserId)
+				assert.Equal(t, constants.DEFAULT_ROLE, result.Role)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.Authenticate(tc.args[0].(context.Context), tc.args[1].(string))
+			tc.assertFn(m)
+		})
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	var result *auth.JWTCustomClaims
+	var err error
+	key := constants.API_KEY_PREFIX + "secret"
+	hash := utils.HashToken(key)
+	user := &domains.User{
+		ID:       primitive.NewObjectID(),
+		Role:     constants.ROLE_EDITOR,
+		Verified: true,
+	}
+	scopes := []string{constants.PERMISSION_BLOG_READ}
+	recently := time.Now().UTC().Add(-10 * time.Second)
+
+	tests := []*test{
+		{
+			name: "return error when key has no api key prefix",
+			args: []interface{}{
+				ctx,
+				"secret",
+			},
+			mockFn: func(m *testModule) {},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyInvalid, err)
+			},
+		},
+		{
+			name: "return error when get api key failed",
+			args: []interface{}{
+				ctx,
+				key,
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("GetByKeyHash", ctx, hash).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.InternalServer, err)
+			},
+		},
+		{
+			name: "return error when api key is not found",
+			args: []interface{}{
+				ctx,
+				key,
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("GetByKeyHash", ctx, hash).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyInvalid, err)
+			},
+		},
+		{
+			name: "return error when api key is expired",
+			args: []interface{}{
+				ctx,
+				key,
+			},
+			mockFn: func(m *testModule) {
+				expiresAt := time.Now().UTC().Add(-time.Minute)
+				m.akr.On("GetByKeyHash", ctx, hash).Return(&domains.APIKey{UserId: user.ID, ExpiresAt: &expiresAt}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyExpired, err)
+			},
+		},
+		{
+			name: "return error when get user by id failed",
+			args: []interface{}{
+				ctx,
+				key,
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("GetByKeyHash", ctx, hash).Return(&domains.APIKey{UserId: user.ID}, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.InternalServer, err)
+			},
+		},
+		{
+			name: "return error when owner is not found",
+			args: []interface{}{
+				ctx,
+				key,
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("GetByKeyHash", ctx, hash).Return(&domains.APIKey{UserId: user.ID}, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(nil, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.APIKeyInvalid, err)
+			},
+		},
+		{
+			name: "return error when owner is deactivated",
+			args: []interface{}{
+				ctx,
+				key,
+			},
+			mockFn: func(m *testModule) {
+				m.akr.On("GetByKeyHash", ctx, hash).Return(&domains.APIKey{UserId: user.ID}, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(&domains.User{ID: user.ID, Deactivated: true}, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.Error(t, err)
+				assert.Equal(t, errmsg.UserDeactivated, err)
+			},
+		},
+		{
+			name: "success and record last use even when it failed",
+			args: []interface{}{
+				ctx,
+				key,
+			},
+			mockFn: func(m *testModule) {
+				apiKey := &domains.APIKey{ID: primitive.NewObjectID(), UserId: user.ID, Scopes: scopes}
+				m.akr.On("GetByKeyHash", ctx, hash).Return(apiKey, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+				m.akr.On("UpdateLastUsedAt", ctx, apiKey.ID, mock.AnythingOfType("time.Time")).Return(errors.New("error"))
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, user.ID.Hex(), result.UserId)
+			},
+		},
+		{
+			name: "success with the role of the owner and the scopes of the key",
+			args: []interface{}{
+				ctx,
+				key,
+			},
+			mockFn: func(m *testModule) {
+				expiresAt := time.Now().UTC().Add(time.Hour)
+				m.akr.On("GetByKeyHash", ctx, hash).Return(&domains.APIKey{
+					ID:         primitive.NewObjectID(),
+					UserId:     user.ID,
+					Scopes:     scopes,
+					ExpiresAt:  &expiresAt,
+					LastUsedAt: &recently,
+				}, nil)
+				m.ur.On("GetByID", ctx, user.ID).Return(user, nil)
+			},
+			assertFn: func(m *testModule) {
+				assert.NoError(t, err)
+				assert.Equal(t, user.ID.Hex(), result.UserId)
+				assert.Equal(t, constants.ROLE_EDITOR, result.Role)
+				assert.True(t, result.Verified)
+				assert.Equal(t, scopes, result.Scopes)
+				assert.True(t, result.HasScope(constants.PERMISSION_BLOG_READ))
+				assert.False(t, result.HasScope(constants.PERMISSION_BLOG_WRITE))
+				m.akr.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything, mock.Anything)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(t)
+			tc.mockFn(m)
+			result, err = m.svc.AuthenticateAPIKey(tc.args[0].(context.Context), tc.args[1].(string))
 			tc.assertFn(m)
 		})
 	}
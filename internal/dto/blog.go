@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type Blog struct {
 	ID        string `json:"id"`
 	Title     string `json:"title"`
@@ -10,26 +12,72 @@ type Blog struct {
 }
 
 type PopulatedBlog struct {
+	ID         string        `json:"id"`
+	Title      string        `json:"title"`
+	Content    string        `json:"content"`
+	Author     User          `json:"author"`
+	Assignees  []User        `json:"assignees"`
+	Labels     []Label       `json:"labels"`
+	Status     string        `json:"status"`
+	Priority   string        `json:"priority,omitempty"`
+	DueAt      string        `json:"dueAt,omitempty"`
+	CreatedAt  string        `json:"createdAt"`
+	UpdatedAt  string        `json:"updatedAt,omitempty"`
+	UpdatedBy  string        `json:"updatedBy,omitempty"`
+	ArchivedAt string        `json:"archivedAt,omitempty"`
+	ArchivedBy string        `json:"archivedBy,omitempty"`
+	History    []BlogHistory `json:"history,omitempty"`
+}
+
+type BlogHistory struct {
 	ID        string `json:"id"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	Author    User   `json:"author"`
-	Status    string `json:"status"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Actor     User   `json:"actor"`
 	CreatedAt string `json:"createdAt"`
 }
 
 type CreateBlogRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title    string     `json:"title"`
+	Content  string     `json:"content"`
+	Priority string     `json:"priority" valid:"in(low|medium|high|urgent)"`
+	DueAt    *time.Time `json:"dueAt"`
 }
 
 type GetBlogByIDRequest struct {
 	BlogId string `param:"blogId" valid:"required"`
+	// comma separated, history is the only one for now
+	Include string `query:"include"`
+}
+
+type ListBlogHistoryRequest struct {
+	BlogId string `param:"blogId" valid:"required"`
 }
 
 type ListBlogRequest struct {
 	Page  uint32 `query:"page"`
 	Limit uint32 `query:"limit"`
+	// lists the archived blogs instead
+	Archived bool `query:"archived"`
+	// me or a user id
+	Assignee string `query:"assignee"`
+	// due blogs which aren't done
+	Overdue bool `query:"overdue"`
+	// comma separated label ids
+	Labels string `query:"labels"`
+	// whether the blogs have any (default) or all of the labels
+	LabelMatch string `query:"labelMatch" valid:"in(any|all)"`
+	// comma separated statuses
+	Status string `query:"status"`
+	// me or a user id
+	Author string `query:"author"`
+	// created in [createdFrom, createdTo), RFC 3339
+	CreatedFrom string `query:"createdFrom" valid:"rfc3339"`
+	CreatedTo   string `query:"createdTo" valid:"rfc3339"`
+	// part of the title
+	Title string `query:"title"`
+	// priority, createdAt, updatedAt, dueAt or title, a leading - sorts descending
+	Sort string `query:"sort"`
 }
 
 type ListBlogResponse struct {
@@ -42,6 +90,63 @@ type UpdateBlogRequest struct {
 	Status string `json:"status" valid:"required"`
 }
 
+type EditBlogRequest struct {
+	BlogId   string  `param:"blogId" valid:"required"`
+	Title    *string `json:"title"`
+	Content  *string `json:"content"`
+	Priority *string `json:"priority"`
+}
+
+type BlogInvalidTransition struct {
+	From            string   `json:"from"`
+	To              string   `json:"to"`
+	AllowedStatuses []string `json:"allowedStatuses"`
+}
+
+type Workflow struct {
+	Name          string               `json:"name"`
+	InitialStatus string               `json:"initialStatus"`
+	Statuses      []string             `json:"statuses"`
+	Transitions   []WorkflowTransition `json:"transitions"`
+	DoneStatuses  []string             `json:"doneStatuses"`
+}
+
+type WorkflowTransition struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Roles []string `json:"roles"`
+}
+
 type ArchiveBlogRequest struct {
 	BlogId string `param:"blogId" valid:"required"`
 }
+
+type SetBlogDueRequest struct {
+	BlogId string `param:"blogId" valid:"required"`
+	// null removes the due date
+	DueAt *time.Time `json:"dueAt"`
+}
+
+type AttachLabelRequest struct {
+	BlogId  string `param:"blogId" valid:"required"`
+	LabelId string `json:"labelId" valid:"required"`
+}
+
+type DetachLabelRequest struct {
+	BlogId  string `param:"blogId" valid:"required"`
+	LabelId string `param:"labelId" valid:"required"`
+}
+
+type AssignBlogRequest struct {
+	BlogId string `param:"blogId" valid:"required"`
+	UserId string `json:"userId" valid:"required"`
+}
+
+type UnassignBlogRequest struct {
+	BlogId string `param:"blogId" valid:"required"`
+	UserId string `param:"userId" valid:"required"`
+}
+
+type RestoreBlogRequest struct {
+	BlogId string `param:"blogId" valid:"required"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+type Label struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type CreateLabelRequest struct {
+	Name  string `json:"name" valid:"required"`
+	Color string `json:"color" valid:"required"`
+}
+
+type UpdateLabelRequest struct {
+	LabelId string  `param:"labelId" valid:"required"`
+	Name    *string `json:"name"`
+	Color   *string `json:"color"`
+}
+
+type DeleteLabelRequest struct {
+	LabelId string `param:"labelId" valid:"required"`
+}
@@ -14,6 +14,11 @@ type BaseErrorResponse struct {
 	Message string `json:"message"`
 }
 
+type BaseErrorResponseWithData[T interface{}] struct {
+	BaseErrorResponse
+	Data T `json:"data"`
+}
+
 type BaseOKResponse struct {
 	Code    int  `json:"code"`
 	Success bool `json:"success"`
@@ -1,10 +1,47 @@
 package dto
 
+import "time"
+
 type User struct {
 	ID           string `json:"id"`
 	Username     string `json:"username"`
 	Email        string `json:"email"`
+	Role         string `json:"role,omitempty"`
+	Verified     bool   `json:"verified"`
+	ProfileImage string `json:"profileImage"`
+}
+
+type Profile struct {
+	ID           string `json:"id"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
+	Verified     bool   `json:"verified"`
+	ProfileImage string `json:"profileImage"`
+	MFAEnabled   bool   `json:"mfaEnabled"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+type PublicUser struct {
+	ID           string `json:"id"`
+	Username     string `json:"username"`
 	ProfileImage string `json:"profileImage"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+type GetUserRequest struct {
+	UserId string `param:"userId" valid:"required"`
+}
+
+type SearchUserRequest struct {
+	Query string `query:"q"`
+	Page  uint32 `query:"page"`
+	Limit uint32 `query:"limit"`
+}
+
+type SearchUserResponse struct {
+	Users   []PublicUser `json:"users"`
+	HasNext bool         `json:"hasNext"`
 }
 
 type RegisterRequest struct {
@@ -19,9 +56,138 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	MFARequired  bool   `json:"mfaRequired"`
+	MFAToken     string `json:"mfaToken,omitempty"`
+}
+
+type LoginMFARequest struct {
+	MFAToken string `json:"mfaToken" valid:"required"`
+	Code     string `json:"code" valid:"required"`
+}
+
+type OIDCCallbackRequest struct {
+	State            string `query:"state" valid:"required"`
+	Code             string `query:"code" valid:"required"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
+type EnrollTOTPResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" valid:"required"`
+}
+
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" valid:"required"`
 }
 
 type UpdateUserRequest struct {
 	ProfileImage string `json:"profileImage"`
 }
+
+type GetProfileImageRequest struct {
+	UserId string `param:"userId" valid:"required"`
+	Size   string `query:"size"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" valid:"required"`
+	NewPassword     string `json:"newPassword" valid:"required,length(6|20)"`
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email" valid:"required,email"`
+	Password string `json:"password" valid:"required"`
+}
+
+type ChangeUsernameRequest struct {
+	Username string `json:"username" valid:"required,length(3|20)"`
+}
+
+// an account created by the identity provider has no password to send
+type DeactivateUserRequest struct {
+	Password string `json:"password"`
+}
+
+type DeleteUserRequest struct {
+	Password string `json:"password"`
+}
+
+type UserExport struct {
+	ExportedAt string    `json:"exportedAt"`
+	Profile    User      `json:"profile"`
+	Blogs      []Blog    `json:"blogs"`
+	Comments   []Comment `json:"comments"`
+}
+
+type APIKey struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expiresAt,omitempty"`
+	LastUsedAt string   `json:"lastUsedAt,omitempty"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" valid:"required,length(1|50)"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type RevokeAPIKeyRequest struct {
+	APIKeyId string `param:"apiKeyId" valid:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" valid:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" valid:"required"`
+	Password string `json:"password" valid:"required,length(6|20)"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `query:"token" valid:"required"`
+}
+
+type UnlockUserRequest struct {
+	UserId string `param:"userId" valid:"required"`
+}
+
+type UpdateUserRoleRequest struct {
+	UserId string `param:"userId" valid:"required"`
+	Role   string `json:"role" valid:"required,in(admin|editor|member|viewer)"`
+}
+
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
@@ -1,6 +1,12 @@
 package errmsg
 
-import "robinhood/pkg/meta"
+import (
+	"fmt"
+	"robinhood/internal/core/constants"
+	"robinhood/pkg/meta"
+	"strings"
+	"time"
+)
 
 var (
 
@@ -10,25 +16,103 @@ var (
 	MetaDataNotFound = meta.Error.AppendMessage(1002, "Metadata not found.")
 
 	// 2000 - 2999: user error
-	UserNotFound                = meta.Error.AppendMessage(2000, "User not found.")
-	UserExisted                 = meta.Error.AppendMessage(2001, "User already existed.")
-	UsernameOrPasswordIncorrect = meta.Error.AppendMessage(2002, "Username or Password incorrect.")
-	UserRegisterFailed          = meta.Error.AppendMessage(2003, "User register failed.")
-	UserLoginFailed             = meta.Error.AppendMessage(2004, "User login failed.")
+	UserNotFound                  = meta.Error.AppendMessage(2000, "User not found.")
+	UserExisted                   = meta.Error.AppendMessage(2001, "User already existed.")
+	UsernameOrPasswordIncorrect   = meta.Error.AppendMessage(2002, "Username or Password incorrect.")
+	UserRegisterFailed            = meta.Error.AppendMessage(2003, "User register failed.")
+	UserLoginFailed               = meta.Error.AppendMessage(2004, "User login failed.")
+	TokenInvalid                  = meta.MetaErrorUnauthorized.AppendMessage(2005, "Token is invalid.")
+	TokenExpired                  = meta.MetaErrorUnauthorized.AppendMessage(2006, "Token is expired.")
+	TokenIdleExpired              = meta.MetaErrorUnauthorized.AppendMessage(2007, "Session is logged off due to inactivity.")
+	TokenMissing                  = meta.MetaErrorUnauthorized.AppendMessage(2008, "Token is missing or malformed.")
+	RefreshTokenInvalid           = meta.MetaErrorUnauthorized.AppendMessage(2009, "Refresh token is invalid.")
+	RefreshTokenExpired           = meta.MetaErrorUnauthorized.AppendMessage(2010, "Refresh token is expired.")
+	RefreshTokenReused            = meta.MetaErrorUnauthorized.AppendMessage(2011, "Refresh token is already used, please login again.")
+	RefreshTokenFailed            = meta.Error.AppendMessage(2012, "Refresh token failed.")
+	TokenRevoked                  = meta.MetaErrorUnauthorized.AppendMessage(2013, "Token is revoked.")
+	UserLogoutFailed              = meta.Error.AppendMessage(2014, "User logout failed.")
+	UserInvalidRole               = meta.MetaErrorBadRequest.AppendMessage(2015, "User invalid role.")
+	UserUpdateFailed              = meta.Error.AppendMessage(2016, "User update failed.")
+	PasswordResetFailed           = meta.Error.AppendMessage(2017, "Password reset failed.")
+	PasswordResetTokenInvalid     = meta.MetaErrorBadRequest.AppendMessage(2018, "Password reset token is invalid or expired.")
+	EmailVerificationFailed       = meta.Error.AppendMessage(2019, "Email verification failed.")
+	EmailVerificationTokenInvalid = meta.MetaErrorBadRequest.AppendMessage(2020, "Email verification token is invalid or expired.")
+	EmailAlreadyVerified          = meta.MetaErrorBadRequest.AppendMessage(2021, "Email is already verified.")
+	EmailVerificationThrottled    = meta.MetaErrorTooManyRequests.AppendMessage(2022, "Verification email was sent recently, please try again later.")
+	EmailNotVerified              = meta.MetaErrorForbidden.AppendMessage(2023, "Please verify your email first.")
+	LoginLocked                   = meta.MetaErrorTooManyRequests.AppendMessage(2024, "Too many failed login attempts, please try again later.")
+	UserUnlockFailed              = meta.Error.AppendMessage(2025, "User unlock failed.")
+	MFAFailed                     = meta.Error.AppendMessage(2026, "Two-factor authentication failed.")
+	MFAAlreadyEnabled             = meta.MetaErrorBadRequest.AppendMessage(2027, "Two-factor authentication is already enabled.")
+	MFANotEnrolled                = meta.MetaErrorBadRequest.AppendMessage(2028, "Two-factor authentication is not enrolled.")
+	MFACodeInvalid                = meta.MetaErrorBadRequest.AppendMessage(2029, "Two-factor authentication code is invalid.")
+	MFAChallengeInvalid           = meta.MetaErrorUnauthorized.AppendMessage(2030, "Two-factor authentication challenge is invalid or expired, please login again.")
+	PasswordIncorrect             = meta.MetaErrorBadRequest.AppendMessage(2031, "Password incorrect.")
+	EmailExisted                  = meta.Error.AppendMessage(2032, "Email already existed.")
+	UserDeactivated               = meta.MetaErrorForbidden.AppendMessage(2033, "User is deactivated.")
+	UserDeactivateFailed          = meta.Error.AppendMessage(2034, "User deactivate failed.")
+	UserDeleteFailed              = meta.Error.AppendMessage(2035, "User delete failed.")
+	UserExportFailed              = meta.Error.AppendMessage(2036, "User export failed.")
+	APIKeyInvalid                 = meta.MetaErrorUnauthorized.AppendMessage(2037, "API key is invalid.")
+	APIKeyExpired                 = meta.MetaErrorUnauthorized.AppendMessage(2038, "API key is expired.")
+	APIKeyInvalidScope            = meta.MetaErrorBadRequest.AppendMessage(2039, "API key scopes are invalid.")
+	APIKeyInvalidExpiry           = meta.MetaErrorBadRequest.AppendMessage(2040, "API key expiry has to be in the future.")
+	APIKeyCreateFailed            = meta.Error.AppendMessage(2041, "API key create failed.")
+	APIKeyListFailed              = meta.Error.AppendMessage(2042, "Something went wrong. Cannot get API key list.")
+	APIKeyRevokeFailed            = meta.Error.AppendMessage(2043, "API key revoke failed.")
+	APIKeyNotFound                = meta.Error.AppendMessage(2044, "API key not found.")
+	OIDCNotConfigured             = meta.MetaErrorNotFound.AppendMessage(2045, "Login with the identity provider is not available.")
+	OIDCLoginFailed               = meta.MetaErrorUnauthorized.AppendMessage(2046, "Login with the identity provider failed.")
+	OIDCStateInvalid              = meta.MetaErrorBadRequest.AppendMessage(2047, "Login with the identity provider is invalid or expired, please try again.")
+	OIDCEmailNotVerified          = meta.MetaErrorForbidden.AppendMessage(2048, "The identity provider has no verified email for you.")
+	OIDCAccountConflict           = meta.MetaErrorForbidden.AppendMessage(2049, "An account with this email already exists, please login with its password and verify the email first.")
+	UserGetFailed                 = meta.Error.AppendMessage(2050, "User get failed.")
+	UserSearchFailed              = meta.Error.AppendMessage(2051, "Something went wrong. Cannot search users.")
+	ProfileImageInvalid           = meta.MetaErrorBadRequest.AppendMessage(2052, "Profile image has to be a JPEG, PNG or GIF image.")
+	ProfileImageTooLarge          = meta.MetaErrorBadRequest.AppendMessage(2053, "Profile image is too large.")
+	ProfileImageUploadFailed      = meta.Error.AppendMessage(2054, "Profile image upload failed.")
+	ProfileImageNotFound          = meta.MetaErrorNotFound.AppendMessage(2055, "Profile image not found.")
+	ProfileImageGetFailed         = meta.Error.AppendMessage(2056, "Profile image get failed.")
 
 	// 3000 - 3999: blog error
-	BlogNotFound      = meta.Error.AppendMessage(3000, "Blog not found.")
-	BlogExisted       = meta.Error.AppendMessage(3001, "Blog already existed.")
-	BlogCreateFailed  = meta.Error.AppendMessage(3002, "Blog create failed.")
-	BlogUpdateFailed  = meta.Error.AppendMessage(3003, "Blog update failed.")
-	BlogArchiveFailed = meta.Error.AppendMessage(3004, "Blog archive failed.")
-	BlogInvalidStatus = meta.Error.AppendMessage(3005, "Blog invalid status.")
-	BlogGetFailed     = meta.Error.AppendMessage(3006, "Blog get failed.")
-	BlogListFailed    = meta.Error.AppendMessage(3007, "Something went wrong. Cannot get blog list.")
+	BlogNotFound          = meta.Error.AppendMessage(3000, "Blog not found.")
+	BlogExisted           = meta.Error.AppendMessage(3001, "Blog already existed.")
+	BlogCreateFailed      = meta.Error.AppendMessage(3002, "Blog create failed.")
+	BlogUpdateFailed      = meta.Error.AppendMessage(3003, "Blog update failed.")
+	BlogArchiveFailed     = meta.Error.AppendMessage(3004, "Blog archive failed.")
+	BlogInvalidTransition = meta.MetaErrorBadRequest.AppendMessage(3005, "Blog can't move to this status.")
+	BlogGetFailed         = meta.Error.AppendMessage(3006, "Blog get failed.")
+	BlogListFailed        = meta.Error.AppendMessage(3007, "Something went wrong. Cannot get blog list.")
+	BlogInvalidTitle      = meta.MetaErrorBadRequest.AppendMessage(3008, "Blog title has to be 1 to 200 characters.")
+	BlogInvalidContent    = meta.MetaErrorBadRequest.AppendMessage(3009, "Blog content has to be 1 to 20000 characters.")
+	BlogEditEmpty         = meta.MetaErrorBadRequest.AppendMessage(3010, "Blog title, content or priority is required.")
+	BlogHistoryListFailed = meta.Error.AppendMessage(3011, "Something went wrong. Cannot get blog history.")
+	BlogRestoreFailed     = meta.Error.AppendMessage(3012, "Blog restore failed.")
+	BlogPurgeFailed       = meta.Error.AppendMessage(3013, "Archived blog delete failed.")
+	BlogAssignFailed      = meta.Error.AppendMessage(3014, "Blog assign failed.")
+	BlogTooManyAssignees  = meta.MetaErrorBadRequest.AppendMessage(3015, fmt.Sprintf("Blog can be assigned to %d users at most.", constants.BLOG_MAX_ASSIGNEES))
+	BlogReminderFailed    = meta.Error.AppendMessage(3016, "Blog due date reminder failed.")
+	BlogTooManyLabels     = meta.MetaErrorBadRequest.AppendMessage(3017, fmt.Sprintf("Blog can have %d labels at most.", constants.BLOG_MAX_LABELS))
+	BlogInvalidPriority   = meta.MetaErrorBadRequest.AppendMessage(3018, "Blog priority has to be low, medium, high or urgent.")
+	BlogInvalidSort       = meta.MetaErrorBadRequest.AppendMessage(3019, "Blog list can be sorted by priority, createdAt, updatedAt, dueAt or title.")
+	BlogUnknownStatus     = meta.MetaErrorBadRequest.AppendMessage(3020, "Blog status is not in the workflow.")
+	BlogInvalidDateRange  = meta.MetaErrorBadRequest.AppendMessage(3021, "Blog created date range has to end after it starts.")
 
 	// 4000 - 4999: comment error
 	CommentCreateFailed = meta.Error.AppendMessage(4001, "Comment create failed.")
 	CommentListFailed   = meta.Error.AppendMessage(4002, "Something went wrong. Cannot get comment list.")
+
+	// 5000 - 5999: label error
+	LabelNotFound     = meta.Error.AppendMessage(5000, "Label not found.")
+	LabelExisted      = meta.Error.AppendMessage(5001, "Label already existed.")
+	LabelCreateFailed = meta.Error.AppendMessage(5002, "Label create failed.")
+	LabelUpdateFailed = meta.Error.AppendMessage(5003, "Label update failed.")
+	LabelDeleteFailed = meta.Error.AppendMessage(5004, "Label delete failed.")
+	LabelListFailed   = meta.Error.AppendMessage(5005, "Something went wrong. Cannot get label list.")
+	LabelGetFailed    = meta.Error.AppendMessage(5006, "Label get failed.")
+	LabelInvalidName  = meta.MetaErrorBadRequest.AppendMessage(5007, "Label name has to be 1 to 30 characters.")
+	LabelInvalidColor = meta.MetaErrorBadRequest.AppendMessage(5008, "Label color has to be a hex color like #1f883d.")
+	LabelUpdateEmpty  = meta.MetaErrorBadRequest.AppendMessage(5009, "Label name or color is required.")
 )
 
 func ErrorInvalidRequest(msg string) *meta.MetaError {
@@ -38,3 +122,42 @@ func ErrorInvalidRequest(msg string) *meta.MetaError {
 func ParseError(c int, desc string) *meta.MetaError {
 	return meta.Error.AppendMessage(c, desc)
 }
+
+// LoginLockedError is LoginLocked with how long the client has to wait before the next attempt.
+type LoginLockedError struct {
+	*meta.MetaError
+	RetryAfter time.Duration
+}
+
+func NewLoginLockedError(retryAfter time.Duration) *LoginLockedError {
+	return &LoginLockedError{MetaError: LoginLocked, RetryAfter: retryAfter}
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return e.MetaError
+}
+
+// BlogInvalidTransitionError is BlogInvalidTransition with the statuses the blog can move to instead.
+type BlogInvalidTransitionError struct {
+	*meta.MetaError
+	From    string
+	To      string
+	Allowed []string
+}
+
+func NewBlogInvalidTransitionError(from string, to string, allowed []string) *BlogInvalidTransitionError {
+	msg := fmt.Sprintf("Blog can't move from %s to %s, it can't move to any status.", from, to)
+	if len(allowed) > 0 {
+		msg = fmt.Sprintf("Blog can't move from %s to %s, it can move to %s.", from, to, strings.Join(allowed, ", "))
+	}
+	return &BlogInvalidTransitionError{
+		MetaError: meta.MetaErrorBadRequest.AppendMessage(BlogInvalidTransition.Code, msg),
+		From:      from,
+		To:        to,
+		Allowed:   allowed,
+	}
+}
+
+func (e *BlogInvalidTransitionError) Unwrap() error {
+	return e.MetaError
+}
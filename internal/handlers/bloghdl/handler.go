@@ -1,11 +1,15 @@
 package bloghdl
 
 import (
+	"errors"
 	"net/http"
 	"robinhood/internal/core/domains"
 	"robinhood/internal/core/ports"
 	"robinhood/internal/dto"
+	"robinhood/internal/errmsg"
 	"robinhood/pkg/auth"
+	"strings"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/golang-jwt/jwt/v5"
@@ -15,10 +19,11 @@ import (
 type Handler struct {
 	s ports.BlogService
 	c ports.CommentService
+	l ports.LabelService
 }
 
-func New(s ports.BlogService, c ports.CommentService) *Handler {
-	return &Handler{s: s, c: c}
+func New(s ports.BlogService, c ports.CommentService, l ports.LabelService) *Handler {
+	return &Handler{s: s, c: c, l: l}
 }
 
 // @Summary      Create Blog
@@ -29,6 +34,8 @@ func New(s ports.BlogService, c ports.CommentService) *Handler {
 // @Router       /blog [post]
 // @Param title body string true "blog title"
 // @Param content body string true "blog content"
+// @Param priority body string false "low, medium (default), high or urgent"
+// @Param dueAt body string false "due date, RFC 3339"
 // @Response 200 {object} dto.BaseResponseWithData[dto.PopulatedBlog]
 // @Response 400 {object} dto.BaseErrorResponse
 // @Response 500 {object} dto.BaseErrorResponse
@@ -56,6 +63,8 @@ func (h *Handler) CreateBlog(c echo.Context) error {
 		Title:    req.Title,
 		Content:  req.Content,
 		AuthorId: userId,
+		Priority: req.Priority,
+		DueAt:    req.DueAt,
 	})
 	if err != nil {
 		return err
@@ -65,19 +74,7 @@ func (h *Handler) CreateBlog(c echo.Context) error {
 		BaseResponse: dto.BaseResponse{
 			Code: 0,
 		},
-		Data: dto.PopulatedBlog{
-			ID:      blog.ID.Hex(),
-			Title:   blog.Title,
-			Content: blog.Content,
-			Author: dto.User{
-				ID:           blog.Author.ID.Hex(),
-				Username:     blog.Author.Username,
-				Email:        blog.Author.Email,
-				ProfileImage: blog.Author.ProfileImage,
-			},
-			Status:    blog.Status,
-			CreatedAt: blog.CreatedAt.String(),
-		},
+		Data: populatedBlog(*blog),
 	})
 }
 
@@ -88,6 +85,7 @@ func (h *Handler) CreateBlog(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Router       /blog/{blogId} [post]
 // @Param blogId path string true "blog id"
+// @Param include query string false "history to add the status history"
 // @Response 200 {object} dto.BaseResponseWithData[dto.PopulatedBlog]
 // @Response 400 {object} dto.BaseErrorResponse
 // @Response 500 {object} dto.BaseErrorResponse
@@ -109,23 +107,58 @@ func (h *Handler) GetBlogByID(c echo.Context) error {
 		return err
 	}
 
+	data := populatedBlog(*blog)
+	for _, include := range strings.Split(req.Include, ",") {
+		if strings.TrimSpace(include) != "history" {
+			continue
+		}
+		history, err := h.s.ListBlogHistory(ctx, req.BlogId)
+		if err != nil {
+			return err
+		}
+		data.History = blogHistory(history)
+	}
+
 	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.PopulatedBlog]{
 		BaseResponse: dto.BaseResponse{
 			Code: 0,
 		},
-		Data: dto.PopulatedBlog{
-			ID:      blog.ID.Hex(),
-			Title:   blog.Title,
-			Content: blog.Content,
-			Author: dto.User{
-				ID:           blog.Author.ID.Hex(),
-				Username:     blog.Author.Username,
-				Email:        blog.Author.Email,
-				ProfileImage: blog.Author.ProfileImage,
-			},
-			Status:    blog.Status,
-			CreatedAt: blog.CreatedAt.String(),
+		Data: data,
+	})
+}
+
+// @Summary      List blog status history
+// @Tags         Blog
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /blog/{blogId}/history [get]
+// @Param blogId path string true "blog id"
+// @Response 200 {object} dto.BaseResponseWithData[[]dto.BlogHistory]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ListBlogHistory(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.ListBlogHistoryRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	history, err := h.s.ListBlogHistory(ctx, req.BlogId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[[]dto.BlogHistory]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
 		},
+		Data: blogHistory(history),
 	})
 }
 
@@ -137,6 +170,17 @@ func (h *Handler) GetBlogByID(c echo.Context) error {
 // @Router       /blog [get]
 // @Param page query uint32 true "page number"
 // @Param limit query uint32 true "limit per page"
+// @Param archived query bool false "list the archived blogs instead"
+// @Param assignee query string false "me or a user id, list the blogs assigned to the user"
+// @Param overdue query bool false "list the blogs past their due date which aren't done"
+// @Param labels query string false "comma separated label ids"
+// @Param labelMatch query string false "any (default) or all of the labels"
+// @Param status query string false "comma separated statuses"
+// @Param author query string false "me or a user id, list the blogs written by the user"
+// @Param createdFrom query string false "created at or after, RFC 3339"
+// @Param createdTo query string false "created before, RFC 3339"
+// @Param title query string false "part of the title, case insensitive"
+// @Param sort query string false "priority, createdAt (default -createdAt), updatedAt, dueAt or title, a leading - sorts descending"
 // @Response 200 {object} dto.BaseResponseWithData[dto.ListBlogResponse]
 // @Response 400 {object} dto.BaseErrorResponse
 // @Response 500 {object} dto.BaseErrorResponse
@@ -152,10 +196,34 @@ func (h *Handler) ListBlog(c echo.Context) error {
 		return err
 	}
 
+	assignee, err := me(c, req.Assignee)
+	if err != nil {
+		return err
+	}
+	author, err := me(c, req.Author)
+	if err != nil {
+		return err
+	}
+
+	// validated as RFC 3339 already
+	createdFrom, _ := time.Parse(time.RFC3339, req.CreatedFrom)
+	createdTo, _ := time.Parse(time.RFC3339, req.CreatedTo)
+
 	// list blog
 	blogs, err := h.s.ListBlog(ctx, &domains.ListBlogRequest{
-		Page:  req.Page,
-		Limit: req.Limit,
+		Page:        req.Page,
+		Limit:       req.Limit,
+		Archived:    req.Archived,
+		AssigneeId:  assignee,
+		Overdue:     req.Overdue,
+		LabelIds:    commaSeparated(req.Labels),
+		AllLabels:   req.LabelMatch == "all",
+		Statuses:    commaSeparated(req.Status),
+		AuthorId:    author,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		Title:       req.Title,
+		Sort:        req.Sort,
 	})
 	if err != nil {
 		return err
@@ -163,19 +231,7 @@ func (h *Handler) ListBlog(c echo.Context) error {
 
 	data := make([]dto.PopulatedBlog, len(blogs.Data))
 	for i, blog := range blogs.Data {
-		data[i] = dto.PopulatedBlog{
-			ID:      blog.ID.Hex(),
-			Title:   blog.Title,
-			Content: blog.Content,
-			Author: dto.User{
-				ID:           blog.Author.ID.Hex(),
-				Username:     blog.Author.Username,
-				Email:        blog.Author.Email,
-				ProfileImage: blog.Author.ProfileImage,
-			},
-			Status:    blog.Status,
-			CreatedAt: blog.CreatedAt.String(),
-		}
+		data[i] = populatedBlog(blog)
 	}
 
 	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.ListBlogResponse]{
@@ -234,14 +290,9 @@ func (h *Handler) CreateComment(c echo.Context) error {
 			Code: 0,
 		},
 		Data: dto.PopulatedComment{
-			ID:     comment.ID.Hex(),
-			BlogId: comment.BlogId.Hex(),
-			Author: dto.User{
-				ID:           comment.Author.ID.Hex(),
-				Username:     comment.Author.Username,
-				Email:        comment.Author.Email,
-				ProfileImage: comment.Author.ProfileImage,
-			},
+			ID:        comment.ID.Hex(),
+			BlogId:    comment.BlogId.Hex(),
+			Author:    author(comment.Author),
 			Content:   comment.Content,
 			CreatedAt: comment.CreatedAt.String(),
 		},
@@ -281,14 +332,9 @@ func (h *Handler) ListComment(c echo.Context) error {
 	data := make([]dto.PopulatedComment, len(comments))
 	for i, cm := range comments {
 		data[i] = dto.PopulatedComment{
-			ID:     cm.ID.Hex(),
-			BlogId: cm.BlogId.Hex(),
-			Author: dto.User{
-				ID:           cm.Author.ID.Hex(),
-				Username:     cm.Author.Username,
-				Email:        cm.Author.Email,
-				ProfileImage: cm.Author.ProfileImage,
-			},
+			ID:        cm.ID.Hex(),
+			BlogId:    cm.BlogId.Hex(),
+			Author:    author(cm.Author),
 			Content:   cm.Content,
 			CreatedAt: cm.CreatedAt.String(),
 		}
@@ -311,10 +357,17 @@ func (h *Handler) ListComment(c echo.Context) error {
 // @Param blogId path string true "blog id"
 // @Param status body string true "blog status"
 // @Response 200 {object} dto.BaseResponse
-// @Response 400 {object} dto.BaseErrorResponse
+// @Response 400 {object} dto.BaseErrorResponseWithData[dto.BlogInvalidTransition]
+// @Response 403 {object} dto.BaseErrorResponse
 // @Response 500 {object} dto.BaseErrorResponse
 func (h *Handler) UpdateBlogStatus(c echo.Context) error {
 	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
 	var req dto.UpdateBlogRequest
 	if err := c.Bind(&req); err != nil {
 		return err
@@ -329,16 +382,504 @@ func (h *Handler) UpdateBlogStatus(c echo.Context) error {
 	err := h.s.UpdateBlogStatus(ctx, &domains.UpdateBlogStatusRequest{
 		BlogId: req.BlogId,
 		Status: req.Status,
+		UserId: claims.UserId,
+		Role:   claims.Role,
+	})
+	if err != nil {
+		return transitionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Get workflow
+// @Description  the statuses of a blog and which roles can move it between them
+// @Tags         Blog
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /blog/workflow [get]
+// @Response 200 {object} dto.BaseResponseWithData[dto.Workflow]
+func (h *Handler) GetWorkflow(c echo.Context) error {
+	ctx := c.Request().Context()
+	workflow := h.s.GetWorkflow(ctx)
+
+	transitions := make([]dto.WorkflowTransition, len(workflow.Transitions))
+	for i, t := range workflow.Transitions {
+		roles := t.Roles
+		if len(roles) == 0 {
+			roles = []string{}
+		}
+		transitions[i] = dto.WorkflowTransition{From: t.From, To: t.To, Roles: roles}
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.Workflow]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.Workflow{
+			Name:          workflow.Name,
+			InitialStatus: workflow.InitialStatus,
+			Statuses:      workflow.Statuses,
+			Transitions:   transitions,
+			DoneStatuses:  workflow.DoneStatuses,
+		},
+	})
+}
+
+// @Summary      Edit blog
+// @Description  only the author can edit the title, the content and the priority, a field which is left out is kept
+// @Tags         Blog
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /blog/{blogId} [patch]
+// @Param blogId path string true "blog id"
+// @Param title body string false "blog title"
+// @Param content body string false "blog content"
+// @Param priority body string false "low, medium, high or urgent"
+// @Response 200 {object} dto.BaseResponseWithData[dto.PopulatedBlog]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) EditBlog(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.EditBlogRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// edit blog
+	blog, err := h.s.EditBlog(ctx, &domains.EditBlogRequest{
+		BlogId:   req.BlogId,
+		Title:    req.Title,
+		Content:  req.Content,
+		Priority: req.Priority,
+		UserId:   claims.UserId,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.PopulatedBlog]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: populatedBlog(*blog),
+	})
+}
+
+// @Summary      Set blog due date
+// @Description  only the author or an admin can, a null due date removes it
+// @Tags         Blog
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /blog/{blogId}/due [put]
+// @Param blogId path string true "blog id"
+// @Param dueAt body string false "due date, RFC 3339"
+// @Response 200 {object} dto.BaseResponseWithData[dto.PopulatedBlog]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) SetBlogDue(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.SetBlogDueRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// set blog due date
+	blog, err := h.s.SetBlogDue(ctx, &domains.SetBlogDueRequest{
+		BlogId: req.BlogId,
+		DueAt:  req.DueAt,
+		UserId: claims.UserId,
+		Role:   claims.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.PopulatedBlog]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: populatedBlog(*blog),
+	})
+}
+
+// @Summary      Attach label
+// @Description  only the author or an admin can
+// @Tags         Blog
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /blog/{blogId}/labels [post]
+// @Param blogId path string true "blog id"
+// @Param labelId body string true "label id"
+// @Response 200 {object} dto.BaseResponseWithData[dto.PopulatedBlog]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) AttachLabel(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.AttachLabelRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// attach label
+	blog, err := h.s.AttachLabel(ctx, &domains.AttachLabelRequest{
+		BlogId:  req.BlogId,
+		LabelId: req.LabelId,
+		UserId:  claims.UserId,
+		Role:    claims.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.PopulatedBlog]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: populatedBlog(*blog),
+	})
+}
+
+// @Summary      Detach label
+// @Description  only the author or an admin can
+// @Tags         Blog
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /blog/{blogId}/labels/{labelId} [delete]
+// @Param blogId path string true "blog id"
+// @Param labelId path string true "label id"
+// @Response 200 {object} dto.BaseResponseWithData[dto.PopulatedBlog]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) DetachLabel(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.DetachLabelRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// detach label
+	blog, err := h.s.DetachLabel(ctx, &domains.DetachLabelRequest{
+		BlogId:  req.BlogId,
+		LabelId: req.LabelId,
+		UserId:  claims.UserId,
+		Role:    claims.Role,
 	})
 	if err != nil {
 		return err
 	}
 
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.PopulatedBlog]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: populatedBlog(*blog),
+	})
+}
+
+// @Summary      Create label
+// @Description  an admin or an editor can manage the labels
+// @Tags         Label
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /label [post]
+// @Param name body string true "label name"
+// @Param color body string true "hex color like #1f883d"
+// @Response 200 {object} dto.BaseResponseWithData[dto.Label]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) CreateLabel(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.CreateLabelRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// create label
+	l, err := h.l.CreateLabel(ctx, &domains.CreateLabelRequest{
+		Name:  req.Name,
+		Color: req.Color,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.Label]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: label(*l),
+	})
+}
+
+// @Summary      List label
+// @Tags         Label
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /label [get]
+// @Response 200 {object} dto.BaseResponseWithData[[]dto.Label]
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ListLabels(c echo.Context) error {
+	ctx := c.Request().Context()
+	labels, err := h.l.ListLabels(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := make([]dto.Label, len(labels))
+	for i, l := range labels {
+		data[i] = label(l)
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[[]dto.Label]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: data,
+	})
+}
+
+// @Summary      Update label
+// @Description  a field which is left out is kept
+// @Tags         Label
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /label/{labelId} [patch]
+// @Param labelId path string true "label id"
+// @Param name body string false "label name"
+// @Param color body string false "hex color like #1f883d"
+// @Response 200 {object} dto.BaseResponseWithData[dto.Label]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) UpdateLabel(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.UpdateLabelRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// update label
+	l, err := h.l.UpdateLabel(ctx, &domains.UpdateLabelRequest{
+		LabelId: req.LabelId,
+		Name:    req.Name,
+		Color:   req.Color,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.Label]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: label(*l),
+	})
+}
+
+// @Summary      Delete label
+// @Description  the label is detached from every blog
+// @Tags         Label
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /label/{labelId} [delete]
+// @Param labelId path string true "label id"
+// @Response 200 {object} dto.BaseResponse
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) DeleteLabel(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.DeleteLabelRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// delete label
+	if err := h.l.DeleteLabel(ctx, req.LabelId); err != nil {
+		return err
+	}
+
 	return c.JSON(http.StatusOK, dto.BaseResponse{
 		Code: 0,
 	})
 }
 
+// @Summary      Assign blog
+// @Description  make a user responsible for the blog, only the author or an admin can
+// @Tags         Blog
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /blog/{blogId}/assignees [post]
+// @Param blogId path string true "blog id"
+// @Param userId body string true "user id of the assignee"
+// @Response 200 {object} dto.BaseResponseWithData[dto.PopulatedBlog]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) AssignBlog(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.AssignBlogRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// assign blog
+	blog, err := h.s.AssignBlog(ctx, &domains.AssignBlogRequest{
+		BlogId:     req.BlogId,
+		AssigneeId: req.UserId,
+		UserId:     claims.UserId,
+		Role:       claims.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.PopulatedBlog]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: populatedBlog(*blog),
+	})
+}
+
+// @Summary      Unassign blog
+// @Description  the author or an admin can unassign anyone, an assignee can unassign itself
+// @Tags         Blog
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /blog/{blogId}/assignees/{userId} [delete]
+// @Param blogId path string true "blog id"
+// @Param userId path string true "user id of the assignee"
+// @Response 200 {object} dto.BaseResponseWithData[dto.PopulatedBlog]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) UnassignBlog(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.UnassignBlogRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// unassign blog
+	blog, err := h.s.UnassignBlog(ctx, &domains.UnassignBlogRequest{
+		BlogId:     req.BlogId,
+		AssigneeId: req.UserId,
+		UserId:     claims.UserId,
+		Role:       claims.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.PopulatedBlog]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: populatedBlog(*blog),
+	})
+}
+
 // @Summary      Archive blog
 // @Tags         Blog
 // @Accept       json
@@ -348,9 +889,16 @@ func (h *Handler) UpdateBlogStatus(c echo.Context) error {
 // @Param blogId path string true "blog id"
 // @Response 200 {object} dto.BaseResponse
 // @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
 // @Response 500 {object} dto.BaseErrorResponse
 func (h *Handler) ArchiveBlog(c echo.Context) error {
 	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
 	var req dto.ArchiveBlogRequest
 	if err := c.Bind(&req); err != nil {
 		return err
@@ -364,6 +912,8 @@ func (h *Handler) ArchiveBlog(c echo.Context) error {
 	// archive blog
 	err := h.s.ArchiveBlog(ctx, &domains.ArchiveBlogRequest{
 		BlogId: req.BlogId,
+		UserId: claims.UserId,
+		Role:   claims.Role,
 	})
 	if err != nil {
 		return err
@@ -373,3 +923,166 @@ func (h *Handler) ArchiveBlog(c echo.Context) error {
 		Code: 0,
 	})
 }
+
+// @Summary      Restore blog
+// @Description  bring back an archived blog, only the author or an admin can
+// @Tags         Blog
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Router       /blog/{blogId}/restore [post]
+// @Param blogId path string true "blog id"
+// @Response 200 {object} dto.BaseResponseWithData[dto.PopulatedBlog]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) RestoreBlog(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.RestoreBlogRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// restore blog
+	blog, err := h.s.RestoreBlog(ctx, &domains.RestoreBlogRequest{
+		BlogId: req.BlogId,
+		UserId: claims.UserId,
+		Role:   claims.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.PopulatedBlog]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: populatedBlog(*blog),
+	})
+}
+
+// transitionError responds with the statuses a blog can move to when the
+// workflow doesn't allow the one asked for.
+func transitionError(c echo.Context, err error) error {
+	var transition *errmsg.BlogInvalidTransitionError
+	if !errors.As(err, &transition) {
+		return err
+	}
+	return c.JSON(transition.HttpStatus, dto.BaseErrorResponseWithData[dto.BlogInvalidTransition]{
+		BaseErrorResponse: dto.BaseErrorResponse{
+			BaseResponse: dto.BaseResponse{
+				Code: transition.Code,
+			},
+			Message: transition.Message,
+		},
+		Data: dto.BlogInvalidTransition{
+			From:            transition.From,
+			To:              transition.To,
+			AllowedStatuses: transition.Allowed,
+		},
+	})
+}
+
+func populatedBlog(blog domains.PopulatedBlog) dto.PopulatedBlog {
+	assignees := make([]dto.User, len(blog.Assignees))
+	for i, assignee := range blog.Assignees {
+		assignees[i] = author(assignee)
+	}
+	labels := make([]dto.Label, len(blog.Labels))
+	for i, l := range blog.Labels {
+		labels[i] = label(l)
+	}
+	res := dto.PopulatedBlog{
+		ID:        blog.ID.Hex(),
+		Title:     blog.Title,
+		Content:   blog.Content,
+		Author:    author(blog.Author),
+		Assignees: assignees,
+		Labels:    labels,
+		Status:    blog.Status,
+		Priority:  blog.Priority.String(),
+		CreatedAt: blog.CreatedAt.String(),
+	}
+	if !blog.DueAt.IsZero() {
+		res.DueAt = blog.DueAt.String()
+	}
+	if !blog.UpdatedAt.IsZero() {
+		res.UpdatedAt = blog.UpdatedAt.String()
+		res.UpdatedBy = blog.UpdatedBy.Hex()
+	}
+	if !blog.ArchivedAt.IsZero() {
+		res.ArchivedAt = blog.ArchivedAt.String()
+		res.ArchivedBy = blog.ArchivedBy.Hex()
+	}
+	return res
+}
+
+// me replaces me with the id of the user who calls.
+func me(c echo.Context, id string) (string, error) {
+	if id != "me" {
+		return id, nil
+	}
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return "", echo.ErrUnauthorized
+	}
+	return claims.UserId, nil
+}
+
+func commaSeparated(s string) []string {
+	var result []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func label(l domains.Label) dto.Label {
+	return dto.Label{
+		ID:    l.ID.Hex(),
+		Name:  l.Name,
+		Color: l.Color,
+	}
+}
+
+func blogHistory(history []domains.PopulatedBlogHistory) []dto.BlogHistory {
+	res := make([]dto.BlogHistory, len(history))
+	for i, h := range history {
+		res[i] = dto.BlogHistory{
+			ID:        h.ID.Hex(),
+			From:      h.From,
+			To:        h.To,
+			Actor:     author(h.Actor),
+			CreatedAt: h.CreatedAt.String(),
+		}
+	}
+	return res
+}
+
+// author hides the profile of a deactivated or deleted author, the
+// repositories leave such an author empty.
+func author(u domains.User) dto.User {
+	if u.ID.IsZero() {
+		return dto.User{}
+	}
+	return dto.User{
+		ID:           u.ID.Hex(),
+		Username:     u.Username,
+		Email:        u.Email,
+		ProfileImage: u.ProfileImage,
+	}
+}
@@ -1,11 +1,21 @@
 package userhdl
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"robinhood/internal/core/constants"
 	"robinhood/internal/core/domains"
 	"robinhood/internal/core/ports"
 	"robinhood/internal/dto"
+	"robinhood/internal/errmsg"
 	"robinhood/pkg/auth"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/golang-jwt/jwt/v5"
@@ -20,6 +30,67 @@ func New(s ports.UserService) *Handler {
 	return &Handler{s: s}
 }
 
+// ParseToken validates the bearer token of an authenticated request,
+// it is used as the ParseTokenFunc of the auth middleware.
+func (h *Handler) ParseToken(c echo.Context, token string) (interface{}, error) {
+	claims, err := h.s.Authenticate(c.Request().Context(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jwt.Token{
+		Raw:    token,
+		Claims: claims,
+		Valid:  true,
+	}, nil
+}
+
+// ParseTokenOrAPIKey also accepts an api key in place of the bearer token,
+// it is used by the auth middleware of the apis open to scripts.
+func (h *Handler) ParseTokenOrAPIKey(c echo.Context, token string) (interface{}, error) {
+	if !strings.HasPrefix(token, constants.API_KEY_PREFIX) {
+		return h.ParseToken(c, token)
+	}
+
+	claims, err := h.s.AuthenticateAPIKey(c.Request().Context(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jwt.Token{
+		Raw:    token,
+		Claims: claims,
+		Valid:  true,
+	}, nil
+}
+
+// JWKS serves the public keys so other services can verify access tokens locally,
+// it lives at /.well-known/jwks.json outside of the api base path.
+func (h *Handler) JWKS(c echo.Context) error {
+	keys, err := auth.JWKS()
+	if err != nil {
+		return err
+	}
+
+	data := make([]dto.JSONWebKey, len(keys))
+	for i, k := range keys {
+		data[i] = dto.JSONWebKey{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Use: k.Use,
+			Alg: k.Alg,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.JSONWebKeySet{
+		Keys: data,
+	})
+}
+
 // @Summary      Register
 // @Tags         User
 // @Accept       json
@@ -66,6 +137,7 @@ func (h *Handler) Register(c echo.Context) error {
 // @Param password body string true "password"
 // @Response 200 {object} dto.BaseResponseWithData[dto.LoginResponse]
 // @Response 400 {object} dto.BaseErrorResponse
+// @Response 429 {object} dto.BaseErrorResponse
 // @Response 500 {object} dto.BaseErrorResponse
 func (h *Handler) Login(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -83,41 +155,192 @@ func (h *Handler) Login(c echo.Context) error {
 	res, err := h.s.Login(ctx, &domains.LoginRequest{
 		Username: req.Username,
 		Password: req.Password,
+		IP:       c.RealIP(),
 	})
 	if err != nil {
+		return loginError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.LoginResponse]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.LoginResponse{
+			Token:        res.Token,
+			RefreshToken: res.RefreshToken,
+			MFARequired:  res.MFARequired,
+			MFAToken:     res.MFAToken,
+		},
+	})
+}
+
+// @Summary      Login with two-factor code
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/login/mfa [post]
+// @Param mfaToken body string true "mfa token returned by login"
+// @Param code body string true "totp code or recovery code"
+// @Response 200 {object} dto.BaseResponseWithData[dto.LoginResponse]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 429 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) LoginMFA(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.LoginMFARequest
+	if err := c.Bind(&req); err != nil {
 		return err
 	}
 
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// verify the second factor
+	res, err := h.s.LoginMFA(ctx, &domains.LoginMFARequest{
+		MFAToken: req.MFAToken,
+		Code:     req.Code,
+		IP:       c.RealIP(),
+	})
+	if err != nil {
+		return loginError(c, err)
+	}
+
 	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.LoginResponse]{
 		BaseResponse: dto.BaseResponse{
 			Code: 0,
 		},
 		Data: dto.LoginResponse{
-			Token: res.Token,
+			Token:        res.Token,
+			RefreshToken: res.RefreshToken,
 		},
 	})
 }
 
-// @Summary      Update user
+// @Summary      Login with the identity provider
+// @Tags         User
+// @Router       /user/oidc/login [get]
+// @Response 302
+// @Response 404 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) OIDCLogin(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// send the user to the identity provider, it comes back to the callback
+	res, err := h.s.OIDCLogin(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.Redirect(http.StatusFound, res.URL)
+}
+
+// @Summary      Callback of the identity provider
+// @Tags         User
+// @Produce      json
+// @Router       /user/oidc/callback [get]
+// @Param state query string true "state of the login"
+// @Param code query string true "authorization code"
+// @Response 200 {object} dto.BaseResponseWithData[dto.LoginResponse]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) OIDCCallback(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.OIDCCallbackRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// the user didn't sign in or the provider refused the login
+	if req.Error != "" {
+		c.Logger().Warnf("oidc login refused: %s %s", req.Error, req.ErrorDescription)
+		return errmsg.OIDCLoginFailed
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// exchange the code and login the user of the identity
+	res, err := h.s.OIDCCallback(ctx, &domains.OIDCCallbackRequest{
+		State: req.State,
+		Code:  req.Code,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.LoginResponse]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.LoginResponse{
+			Token:        res.Token,
+			RefreshToken: res.RefreshToken,
+		},
+	})
+}
+
+// @Summary      Enroll TOTP
 // @Tags         User
 // @Accept       json
 // @Produce      json
-// @Router       /user [put]
+// @Router       /user/mfa/totp [post]
 // @Security     ApiKeyAuth
-// @Param profileImage body string true "url of profile image"
-// @Response 200 {object} dto.BaseResponseWithData[dto.User]
+// @Response 200 {object} dto.BaseResponseWithData[dto.EnrollTOTPResponse]
 // @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
 // @Response 500 {object} dto.BaseErrorResponse
-func (h *Handler) UpdateUser(c echo.Context) error {
+func (h *Handler) EnrollTOTP(c echo.Context) error {
 	ctx := c.Request().Context()
 	user := c.Get("user").(*jwt.Token)
 	claims, ok := user.Claims.(*auth.JWTCustomClaims)
 	if !ok {
 		return echo.ErrUnauthorized
 	}
-	userId := claims.UserId
 
-	var req dto.UpdateUserRequest
+	// generate a pending secret
+	res, err := h.s.EnrollTOTP(ctx, claims.UserId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.EnrollTOTPResponse]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.EnrollTOTPResponse{
+			Secret: res.Secret,
+			URI:    res.URI,
+		},
+	})
+}
+
+// @Summary      Confirm TOTP
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/mfa/totp/confirm [post]
+// @Security     ApiKeyAuth
+// @Param code body string true "totp code"
+// @Response 200 {object} dto.BaseResponseWithData[dto.ConfirmTOTPResponse]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ConfirmTOTP(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.ConfirmTOTPRequest
 	if err := c.Bind(&req); err != nil {
 		return err
 	}
@@ -127,25 +350,1137 @@ func (h *Handler) UpdateUser(c echo.Context) error {
 		return err
 	}
 
-	// update user
-	updatedUser, err := h.s.Update(ctx, &domains.UpdateUserRequest{
-		UserId:       userId,
-		ProfileImage: req.ProfileImage,
+	// enable two-factor authentication
+	res, err := h.s.ConfirmTOTP(ctx, &domains.ConfirmTOTPRequest{
+		UserId: claims.UserId,
+		Code:   req.Code,
 	})
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.User]{
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.ConfirmTOTPResponse]{
 		BaseResponse: dto.BaseResponse{
 			Code: 0,
 		},
-		Data: dto.User{
-			ID:           updatedUser.ID.Hex(),
-			Username:     updatedUser.Username,
-			Email:        updatedUser.Email,
-			ProfileImage: updatedUser.ProfileImage,
+		Data: dto.ConfirmTOTPResponse{
+			RecoveryCodes: res.RecoveryCodes,
+		},
+	})
+}
+
+// @Summary      Refresh token
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/token/refresh [post]
+// @Param refreshToken body string true "refresh token"
+// @Response 200 {object} dto.BaseResponseWithData[dto.LoginResponse]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) RefreshToken(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// rotate refresh token
+	res, err := h.s.RefreshToken(ctx, &domains.RefreshTokenRequest{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.LoginResponse]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.LoginResponse{
+			Token:        res.Token,
+			RefreshToken: res.RefreshToken,
 		},
 	})
+}
+
+// @Summary      Logout
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/logout [post]
+// @Security     ApiKeyAuth
+// @Response 200 {object} dto.BaseResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) Logout(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	// revoke current token
+	if err := h.s.Logout(ctx, &domains.LogoutRequest{
+		UserId:    claims.UserId,
+		TokenId:   claims.ID,
+		SessionId: claims.SessionId,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Logout from all devices
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/logout-all [post]
+// @Security     ApiKeyAuth
+// @Response 200 {object} dto.BaseResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) LogoutAll(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	// revoke every token of the user
+	if err := h.s.LogoutAll(ctx, claims.UserId); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Forgot password
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/password/forgot [post]
+// @Param email body string true "email"
+// @Response 200 {object} dto.BaseResponse
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ForgotPassword(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.ForgotPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// send reset password email
+	if err := h.s.ForgotPassword(ctx, &domains.ForgotPasswordRequest{
+		Email: req.Email,
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Reset password
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/password/reset [post]
+// @Param token body string true "reset password token"
+// @Param password body string true "new password"
+// @Response 200 {object} dto.BaseResponse
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ResetPassword(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// reset password
+	if err := h.s.ResetPassword(ctx, &domains.ResetPasswordRequest{
+		Token:    req.Token,
+		Password: req.Password,
+	}); err != nil {
+		return err
+	}
 
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Verify email
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/verify [get]
+// @Param token query string true "email verification token"
+// @Response 200 {object} dto.BaseResponse
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) VerifyEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.VerifyEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// verify email
+	if err := h.s.VerifyEmail(ctx, &domains.VerifyEmailRequest{
+		Token: req.Token,
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Resend verification email
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/verify/resend [post]
+// @Security     ApiKeyAuth
+// @Response 200 {object} dto.BaseResponse
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 429 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ResendVerification(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	// send a new verification email
+	if err := h.s.ResendVerification(ctx, claims.UserId); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Get my profile
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/me [get]
+// @Security     ApiKeyAuth
+// @Response 200 {object} dto.BaseResponseWithData[dto.Profile]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) GetMe(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	me, err := h.s.GetUserByID(ctx, claims.UserId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.Profile]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.Profile{
+			ID:           me.ID.Hex(),
+			Username:     me.Username,
+			Email:        me.Email,
+			Role:         me.Role,
+			Verified:     me.Verified,
+			ProfileImage: me.ProfileImage,
+			MFAEnabled:   me.MFAEnabled,
+			CreatedAt:    me.CreatedAt.String(),
+		},
+	})
+}
+
+// @Summary      Get user
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/{userId} [get]
+// @Security     ApiKeyAuth
+// @Param userId path string true "user id"
+// @Response 200 {object} dto.BaseResponseWithData[dto.PublicUser]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) GetUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.GetUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	user, err := h.s.GetUserByID(ctx, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.PublicUser]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: toPublicUser(*user),
+	})
+}
+
+// @Summary      Search users
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user [get]
+// @Security     ApiKeyAuth
+// @Param q query string false "username prefix"
+// @Param page query uint32 false "page number"
+// @Param limit query uint32 false "limit per page"
+// @Response 200 {object} dto.BaseResponseWithData[dto.SearchUserResponse]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) SearchUsers(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.SearchUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	res, err := h.s.SearchUser(ctx, &domains.SearchUserRequest{
+		Query: req.Query,
+		Page:  req.Page,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	users := []dto.PublicUser{}
+	for _, u := range res.Data {
+		users = append(users, toPublicUser(u))
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.SearchUserResponse]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.SearchUserResponse{
+			Users:   users,
+			HasNext: res.HasNext,
+		},
+	})
+}
+
+// @Summary      Update user
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user [put]
+// @Security     ApiKeyAuth
+// @Param profileImage body string true "url of profile image"
+// @Response 200 {object} dto.BaseResponseWithData[dto.User]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) UpdateUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+	userId := claims.UserId
+
+	var req dto.UpdateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// update user
+	updatedUser, err := h.s.Update(ctx, &domains.UpdateUserRequest{
+		UserId:       userId,
+		ProfileImage: req.ProfileImage,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.User]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.User{
+			ID:           updatedUser.ID.Hex(),
+			Username:     updatedUser.Username,
+			Email:        updatedUser.Email,
+			Role:         updatedUser.Role,
+			Verified:     updatedUser.Verified,
+			ProfileImage: updatedUser.ProfileImage,
+		},
+	})
+
+}
+
+// @Summary      Upload profile image
+// @Description  JPEG, PNG or GIF, 64 and 256 pixels square thumbnails are made from it
+// @Tags         User
+// @Accept       mpfd
+// @Produce      json
+// @Router       /user/profile-image [put]
+// @Security     ApiKeyAuth
+// @Param image formData file true "profile image"
+// @Response 200 {object} dto.BaseResponseWithData[dto.User]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) UploadProfileImage(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	fh, err := c.FormFile("image")
+	if err != nil {
+		return errmsg.ErrorInvalidRequest("image is required")
+	}
+	file, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	updatedUser, err := h.s.UploadProfileImage(ctx, &domains.UploadProfileImageRequest{
+		UserId: claims.UserId,
+		Size:   fh.Size,
+		File:   file,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.User]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.User{
+			ID:           updatedUser.ID.Hex(),
+			Username:     updatedUser.Username,
+			Email:        updatedUser.Email,
+			Role:         updatedUser.Role,
+			Verified:     updatedUser.Verified,
+			ProfileImage: updatedUser.ProfileImage,
+		},
+	})
+}
+
+// @Summary      Get profile image
+// @Description  redirects to a signed url of the uploaded image, it doesn't need a login so it works in an img tag
+// @Tags         User
+// @Router       /user/{userId}/profile-image [get]
+// @Param userId path string true "user id"
+// @Param size query string false "original, 64 or 256 (default)"
+// @Response 302
+// @Response 404 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) GetProfileImage(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.GetProfileImageRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	link, err := h.s.ProfileImageURL(ctx, &domains.ProfileImageURLRequest{
+		UserId: req.UserId,
+		Size:   req.Size,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the signed url expires, the redirect must not be cached longer
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.Redirect(http.StatusFound, link)
+}
+
+// @Summary      Unlock user
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/{userId}/unlock [post]
+// @Security     ApiKeyAuth
+// @Param userId path string true "user id"
+// @Response 200 {object} dto.BaseResponse
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) UnlockUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.UnlockUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// clear the failed logins of the user
+	if err := h.s.Unlock(ctx, &domains.UnlockUserRequest{
+		UserId: req.UserId,
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Change password
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/password [put]
+// @Security     ApiKeyAuth
+// @Param currentPassword body string true "current password"
+// @Param newPassword body string true "new password"
+// @Response 200 {object} dto.BaseResponseWithData[dto.LoginResponse]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ChangePassword(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// change password and issue new tokens for this session
+	res, err := h.s.ChangePassword(ctx, &domains.ChangePasswordRequest{
+		UserId:          claims.UserId,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.LoginResponse]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.LoginResponse{
+			Token:        res.Token,
+			RefreshToken: res.RefreshToken,
+		},
+	})
+}
+
+// @Summary      Change email
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/email [put]
+// @Security     ApiKeyAuth
+// @Param email body string true "new email"
+// @Param password body string false "current password, not needed when the account was created by the identity provider"
+// @Response 200 {object} dto.BaseResponseWithData[dto.User]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ChangeEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.ChangeEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// change email and send a verification email to it
+	updatedUser, err := h.s.ChangeEmail(ctx, &domains.ChangeEmailRequest{
+		UserId:   claims.UserId,
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.User]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.User{
+			ID:           updatedUser.ID.Hex(),
+			Username:     updatedUser.Username,
+			Email:        updatedUser.Email,
+			Role:         updatedUser.Role,
+			Verified:     updatedUser.Verified,
+			ProfileImage: updatedUser.ProfileImage,
+		},
+	})
+}
+
+// @Summary      Change username
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/username [put]
+// @Security     ApiKeyAuth
+// @Param username body string true "new username"
+// @Response 200 {object} dto.BaseResponseWithData[dto.User]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ChangeUsername(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.ChangeUsernameRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// change username
+	updatedUser, err := h.s.ChangeUsername(ctx, &domains.ChangeUsernameRequest{
+		UserId:   claims.UserId,
+		Username: req.Username,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.User]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.User{
+			ID:           updatedUser.ID.Hex(),
+			Username:     updatedUser.Username,
+			Email:        updatedUser.Email,
+			Role:         updatedUser.Role,
+			Verified:     updatedUser.Verified,
+			ProfileImage: updatedUser.ProfileImage,
+		},
+	})
+}
+
+// @Summary      Update user role
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/{userId}/role [put]
+// @Security     ApiKeyAuth
+// @Param userId path string true "user id"
+// @Param role body string true "admin, editor, member or viewer"
+// @Response 200 {object} dto.BaseResponseWithData[dto.User]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 403 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) UpdateUserRole(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req dto.UpdateUserRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// update role
+	updatedUser, err := h.s.UpdateRole(ctx, &domains.UpdateUserRoleRequest{
+		UserId: req.UserId,
+		Role:   req.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.User]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.User{
+			ID:           updatedUser.ID.Hex(),
+			Username:     updatedUser.Username,
+			Email:        updatedUser.Email,
+			Role:         updatedUser.Role,
+			Verified:     updatedUser.Verified,
+			ProfileImage: updatedUser.ProfileImage,
+		},
+	})
+}
+
+// @Summary      Deactivate account
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/me/deactivate [post]
+// @Security     ApiKeyAuth
+// @Param password body string false "current password, not needed when the account was created by the identity provider"
+// @Response 200 {object} dto.BaseResponse
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) DeactivateUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.DeactivateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// deactivate the account and logout from all devices
+	if err := h.s.Deactivate(ctx, &domains.DeactivateUserRequest{
+		UserId:   claims.UserId,
+		Password: req.Password,
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Delete account
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/me [delete]
+// @Security     ApiKeyAuth
+// @Param password body string true "current password"
+// @Response 200 {object} dto.BaseResponse
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) DeleteUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.DeleteUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// delete the account, blogs and comments are kept without an author
+	if err := h.s.Delete(ctx, &domains.DeleteUserRequest{
+		UserId:   claims.UserId,
+		Password: req.Password,
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// @Summary      Export account data
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/me/export [get]
+// @Security     ApiKeyAuth
+// @Response 200 {object} dto.UserExport
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ExportUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	// the headers are sent with the profile, an error after it can only
+	// abort the stream
+	w := &exportWriter{res: c.Response(), enc: json.NewEncoder(c.Response())}
+	if err := h.s.Export(ctx, claims.UserId, w); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// exportWriter streams the archive in the shape of dto.UserExport, each blog
+// or comment is encoded as it is read so the archive is never held in memory.
+type exportWriter struct {
+	res *echo.Response
+	enc *json.Encoder
+	// the array being written, "" before the blogs
+	section string
+	empty   bool
+}
+
+func (w *exportWriter) WriteProfile(profile domains.User) error {
+	w.res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	w.res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="robinhood-export.json"`)
+	w.res.WriteHeader(http.StatusOK)
+
+	if err := w.write(fmt.Sprintf(`{"exportedAt":%q,"profile":`, time.Now().UTC().String())); err != nil {
+		return err
+	}
+	return w.enc.Encode(dto.User{
+		ID:           profile.ID.Hex(),
+		Username:     profile.Username,
+		Email:        profile.Email,
+		Role:         profile.Role,
+		Verified:     profile.Verified,
+		ProfileImage: profile.ProfileImage,
+	})
+}
+
+func (w *exportWriter) WriteBlog(blog domains.Blog) error {
+	if err := w.next("blogs"); err != nil {
+		return err
+	}
+	return w.enc.Encode(dto.Blog{
+		ID:        blog.ID.Hex(),
+		Title:     blog.Title,
+		Content:   blog.Content,
+		AuthorId:  blog.AuthorId.Hex(),
+		Status:    blog.Status,
+		CreatedAt: blog.CreatedAt.String(),
+	})
+}
+
+func (w *exportWriter) WriteComment(comment domains.Comment) error {
+	if err := w.next("comments"); err != nil {
+		return err
+	}
+	return w.enc.Encode(dto.Comment{
+		ID:        comment.ID.Hex(),
+		BlogId:    comment.BlogId.Hex(),
+		AuthorId:  comment.AuthorId.Hex(),
+		Content:   comment.Content,
+		CreatedAt: comment.CreatedAt.String(),
+	})
+}
+
+// Close opens the arrays nothing was written to and ends the archive.
+func (w *exportWriter) Close() error {
+	if err := w.open("comments"); err != nil {
+		return err
+	}
+	return w.write("]}\n")
+}
+
+// next writes what comes before an item of the section.
+func (w *exportWriter) next(section string) error {
+	if err := w.open(section); err != nil {
+		return err
+	}
+	if !w.empty {
+		return w.write(",")
+	}
+	w.empty = false
+	return nil
+}
+
+// open closes the arrays up to the section and opens it, the blogs come
+// before the comments.
+func (w *exportWriter) open(section string) error {
+	if w.section == section {
+		return nil
+	}
+	if w.section == "" {
+		if err := w.write(`,"blogs":[`); err != nil {
+			return err
+		}
+		w.section, w.empty = "blogs", true
+	}
+	if section == "comments" && w.section == "blogs" {
+		w.res.Flush()
+		if err := w.write(`],"comments":[`); err != nil {
+			return err
+		}
+		w.section, w.empty = "comments", true
+	}
+	return nil
+}
+
+func (w *exportWriter) write(s string) error {
+	_, err := io.WriteString(w.res, s)
+	return err
+}
+
+// @Summary      Create API key
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/api-keys [post]
+// @Security     ApiKeyAuth
+// @Param name body string true "name of the key"
+// @Param scopes body []string true "blog:read, blog:write, comment:read or comment:write"
+// @Param expiresAt body string false "expiry in RFC 3339, the key never expires when it's empty"
+// @Response 200 {object} dto.BaseResponseWithData[dto.CreateAPIKeyResponse]
+// @Response 400 {object} dto.BaseErrorResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) CreateAPIKey(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// create the key, it is shown only in this response
+	res, err := h.s.CreateAPIKey(ctx, &domains.CreateAPIKeyRequest{
+		UserId:    claims.UserId,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[dto.CreateAPIKeyResponse]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: dto.CreateAPIKeyResponse{
+			APIKey: toAPIKey(res.APIKey),
+			Key:    res.Key,
+		},
+	})
+}
+
+// @Summary      List API keys
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/api-keys [get]
+// @Security     ApiKeyAuth
+// @Response 200 {object} dto.BaseResponseWithData[[]dto.APIKey]
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) ListAPIKeys(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	apiKeys, err := h.s.ListAPIKeys(ctx, claims.UserId)
+	if err != nil {
+		return err
+	}
+
+	data := make([]dto.APIKey, len(apiKeys))
+	for i, k := range apiKeys {
+		data[i] = toAPIKey(k)
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponseWithData[[]dto.APIKey]{
+		BaseResponse: dto.BaseResponse{
+			Code: 0,
+		},
+		Data: data,
+	})
+}
+
+// @Summary      Revoke API key
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Router       /user/api-keys/{apiKeyId} [delete]
+// @Security     ApiKeyAuth
+// @Param apiKeyId path string true "api key id"
+// @Response 200 {object} dto.BaseResponse
+// @Response 401 {object} dto.BaseErrorResponse
+// @Response 500 {object} dto.BaseErrorResponse
+func (h *Handler) RevokeAPIKey(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims, ok := user.Claims.(*auth.JWTCustomClaims)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var req dto.RevokeAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// validate request
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	// the key stops working right away
+	if err := h.s.RevokeAPIKey(ctx, &domains.RevokeAPIKeyRequest{
+		UserId:   claims.UserId,
+		APIKeyId: req.APIKeyId,
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.BaseResponse{
+		Code: 0,
+	})
+}
+
+// toPublicUser keeps only the fields anyone logged in may see.
+func toPublicUser(u domains.User) dto.PublicUser {
+	return dto.PublicUser{
+		ID:           u.ID.Hex(),
+		Username:     u.Username,
+		ProfileImage: u.ProfileImage,
+		CreatedAt:    u.CreatedAt.String(),
+	}
+}
+
+func toAPIKey(k domains.APIKey) dto.APIKey {
+	res := dto.APIKey{
+		ID:        k.ID.Hex(),
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt.String(),
+	}
+	if k.ExpiresAt != nil {
+		res.ExpiresAt = k.ExpiresAt.String()
+	}
+	if k.LastUsedAt != nil {
+		res.LastUsedAt = k.LastUsedAt.String()
+	}
+	return res
+}
+
+// loginError sets the Retry-After header when the login is locked.
+func loginError(c echo.Context, err error) error {
+	var locked *errmsg.LoginLockedError
+	if errors.As(err, &locked) {
+		seconds := int(math.Ceil(locked.RetryAfter.Seconds()))
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+		return locked.MetaError
+	}
+	return err
 }
@@ -0,0 +1,183 @@
+package userhdl_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"robinhood/cmd/httpserver"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"robinhood/internal/core/ports/mocks"
+	"robinhood/internal/dto"
+	"robinhood/internal/errmsg"
+	"robinhood/internal/handlers/userhdl"
+	"robinhood/pkg/auth"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLoginIP(t *testing.T) {
+	type test struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		expectedIP     string
+	}
+
+	tests := []test{
+		{
+			name:         "should ignore a spoofed X-Forwarded-For without trusted proxies",
+			remoteAddr:   "203.0.113.7:51234",
+			forwardedFor: "198.51.100.1",
+			expectedIP:   "203.0.113.7",
+		},
+		{
+			name:           "should ignore X-Forwarded-For from a client that is not a trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "203.0.113.7:51234",
+			forwardedFor:   "198.51.100.1",
+			expectedIP:     "203.0.113.7",
+		},
+		{
+			name:           "should read X-Forwarded-For behind a trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:51234",
+			forwardedFor:   "198.51.100.1",
+			expectedIP:     "198.51.100.1",
+		},
+		{
+			name:           "should skip a spoofed X-Forwarded-For entry in front of a trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:51234",
+			forwardedFor:   "198.51.100.1, 203.0.113.7",
+			expectedIP:     "203.0.113.7",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := mocks.NewUserService(t)
+			s.On("Login", mock.Anything, mock.MatchedBy(func(req *domains.LoginRequest) bool {
+				return req.IP == tc.expectedIP
+			})).Return(&domains.LoginResponse{Token: "token"}, nil).Once()
+
+			ipExtractor, err := httpserver.IPExtractor(tc.trustedProxies)
+			assert.NoError(t, err)
+			e := echo.New()
+			e.IPExtractor = ipExtractor
+			e.POST("/user/login", userhdl.New(s).Login)
+
+			req := httptest.NewRequest(http.MethodPost, "/user/login", strings.NewReader(`{"username":"alice","password":"secret"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderXForwardedFor, tc.forwardedFor)
+			req.RemoteAddr = tc.remoteAddr
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
+func TestIPExtractorInvalidProxy(t *testing.T) {
+	_, err := httpserver.IPExtractor([]string{"10.0.0.0"})
+	assert.Error(t, err)
+}
+
+func TestExportUser(t *testing.T) {
+	user := domains.User{ID: primitive.NewObjectID(), Username: "username"}
+	blogs := []domains.Blog{
+		{ID: primitive.NewObjectID(), AuthorId: user.ID, Title: "title"},
+		{ID: primitive.NewObjectID(), AuthorId: user.ID, Title: "title 2"},
+	}
+	comments := []domains.Comment{
+		{ID: primitive.NewObjectID(), AuthorId: user.ID, Content: "content"},
+		{ID: primitive.NewObjectID(), AuthorId: user.ID, Content: "content 2"},
+	}
+
+	type test struct {
+		name     string
+		blogs    []domains.Blog
+		comments []domains.Comment
+	}
+
+	tests := []test{
+		{name: "should stream the blogs and the comments", blogs: blogs, comments: comments},
+		{name: "should stream only comments", comments: comments},
+		{name: "should stream only blogs", blogs: blogs},
+		{name: "should stream empty arrays"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := mocks.NewUserService(t)
+			s.On("Export", mock.Anything, user.ID.Hex(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				w := args.Get(2).(ports.UserExportWriter)
+				assert.NoError(t, w.WriteProfile(user))
+				for _, blog := range tc.blogs {
+					assert.NoError(t, w.WriteBlog(blog))
+				}
+				for _, comment := range tc.comments {
+					assert.NoError(t, w.WriteComment(comment))
+				}
+			})
+
+			rec, err := exportUser(s, user.ID.Hex())
+			assert.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, `attachment; filename="robinhood-export.json"`, rec.Header().Get(echo.HeaderContentDisposition))
+			var export dto.UserExport
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &export))
+			assert.Equal(t, user.ID.Hex(), export.Profile.ID)
+			assert.Len(t, export.Blogs, len(tc.blogs))
+			for i, blog := range tc.blogs {
+				assert.Equal(t, blog.ID.Hex(), export.Blogs[i].ID)
+			}
+			assert.Len(t, export.Comments, len(tc.comments))
+			for i, comment := range tc.comments {
+				assert.Equal(t, comment.ID.Hex(), export.Comments[i].ID)
+			}
+		})
+	}
+
+	t.Run("should return the error before the stream starts", func(t *testing.T) {
+		s := mocks.NewUserService(t)
+		s.On("Export", mock.Anything, user.ID.Hex(), mock.Anything).Return(errmsg.UserNotFound)
+
+		rec, err := exportUser(s, user.ID.Hex())
+		assert.Equal(t, errmsg.UserNotFound, err)
+		assert.False(t, rec.Flushed)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("should leave a failed stream unterminated", func(t *testing.T) {
+		s := mocks.NewUserService(t)
+		s.On("Export", mock.Anything, user.ID.Hex(), mock.Anything).Return(errmsg.UserExportFailed).Run(func(args mock.Arguments) {
+			w := args.Get(2).(ports.UserExportWriter)
+			assert.NoError(t, w.WriteProfile(user))
+			assert.NoError(t, w.WriteBlog(blogs[0]))
+		})
+
+		rec, err := exportUser(s, user.ID.Hex())
+		assert.Equal(t, errmsg.UserExportFailed, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var export dto.UserExport
+		assert.Error(t, json.Unmarshal(rec.Body.Bytes(), &export))
+	})
+}
+
+func exportUser(s *mocks.UserService, userId string) (*httptest.ResponseRecorder, error) {
+	req := httptest.NewRequest(http.MethodGet, "/user/me/export", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.Set("user", &jwt.Token{Claims: &auth.JWTCustomClaims{UserId: userId}})
+	return rec, userhdl.New(s).ExportUser(c)
+}
@@ -0,0 +1,264 @@
+package identityproviders_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/identityproviders"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ctx = context.TODO()
+	b64 = base64.RawURLEncoding
+)
+
+const (
+	clientId     = "robinhood"
+	clientSecret = "secret"
+	redirectURL  = "http://localhost:8080/api/v1/user/oidc/callback"
+	verifier     = "verifier-verifier-verifier-verifier-verifier"
+)
+
+// stubIdP is a local OpenID Connect provider, it issues an id token for the
+// code "code" when the code verifier matches the challenge of the login. The
+// id token is signed with signKey which is the published key unless a test
+// changes it.
+type stubIdP struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	kid       string
+	signKey   *rsa.PrivateKey
+	signKid   string
+	challenge string
+	claims    jwt.MapClaims
+	jwksCalls int
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &stubIdP{key: key, kid: "key-1", signKey: key, signKid: "key-1"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.URL,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"jwks_uri":               idp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.jwksCalls++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": idp.kid,
+				"use": "sig",
+				"n":   b64.EncodeToString(idp.key.N.Bytes()),
+				"e":   b64.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if id != clientId || secret != clientSecret || r.PostFormValue("code") != "code" ||
+			r.PostFormValue("redirect_uri") != redirectURL || b64.EncodeToString(sum[:]) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims)
+		token.Header["kid"] = idp.signKid
+		signed, _ := token.SignedString(idp.signKey)
+		json.NewEncoder(w).Encode(map[string]string{"id_token": signed, "token_type": "Bearer"})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+
+	sum := sha256.Sum256([]byte(verifier))
+	idp.challenge = b64.EncodeToString(sum[:])
+	idp.claims = jwt.MapClaims{
+		"iss":                idp.URL,
+		"aud":                clientId,
+		"sub":                "subject",
+		"nonce":              "nonce",
+		"email":              "alice@company.com",
+		"email_verified":     true,
+		"preferred_username": "alice",
+		"iat":                time.Now().Unix(),
+		"exp":                time.Now().Add(time.Hour).Unix(),
+	}
+	return idp
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	idp := newStubIdP(t)
+	p := identityproviders.NewOIDCProvider(idp.URL, clientId, clientSecret, redirectURL, []string{"openid", "email"})
+
+	u, err := p.AuthCodeURL(ctx, &domains.AuthCodeURLRequest{
+		State:         "state",
+		Nonce:         "nonce",
+		CodeChallenge: idp.challenge,
+	})
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(u)
+	assert.NoError(t, err)
+	assert.Equal(t, idp.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	q := parsed.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, clientId, q.Get("client_id"))
+	assert.Equal(t, redirectURL, q.Get("redirect_uri"))
+	assert.Equal(t, "openid email", q.Get("scope"))
+	assert.Equal(t, "state", q.Get("state"))
+	assert.Equal(t, "nonce", q.Get("nonce"))
+	assert.Equal(t, idp.challenge, q.Get("code_challenge"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+}
+
+func TestExchange(t *testing.T) {
+	var result *domains.OIDCIdentity
+	var err error
+	mockReq := &domains.ExchangeCodeRequest{
+		Code:         "code",
+		CodeVerifier: verifier,
+		Nonce:        "nonce",
+	}
+
+	tests := []struct {
+		name     string
+		req      *domains.ExchangeCodeRequest
+		mockFn   func(*stubIdP)
+		assertFn func(*stubIdP)
+	}{
+		{
+			name:   "return error when code verifier doesn't match the challenge",
+			req:    &domains.ExchangeCodeRequest{Code: "code", CodeVerifier: "other", Nonce: "nonce"},
+			mockFn: func(idp *stubIdP) {},
+			assertFn: func(idp *stubIdP) {
+				assert.ErrorIs(t, err, identityproviders.ErrTokenRequest)
+			},
+		},
+		{
+			name:   "return error when nonce doesn't match",
+			req:    &domains.ExchangeCodeRequest{Code: "code", CodeVerifier: verifier, Nonce: "other"},
+			mockFn: func(idp *stubIdP) {},
+			assertFn: func(idp *stubIdP) {
+				assert.ErrorIs(t, err, identityproviders.ErrIDToken)
+			},
+		},
+		{
+			name: "return error when id token is for another client",
+			req:  mockReq,
+			mockFn: func(idp *stubIdP) {
+				idp.claims["aud"] = "other"
+			},
+			assertFn: func(idp *stubIdP) {
+				assert.ErrorIs(t, err, identityproviders.ErrIDToken)
+			},
+		},
+		{
+			name: "return error when id token is from another issuer",
+			req:  mockReq,
+			mockFn: func(idp *stubIdP) {
+				idp.claims["iss"] = "https://evil.local"
+			},
+			assertFn: func(idp *stubIdP) {
+				assert.ErrorIs(t, err, identityproviders.ErrIDToken)
+			},
+		},
+		{
+			name: "return error when id token is expired",
+			req:  mockReq,
+			mockFn: func(idp *stubIdP) {
+				idp.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			},
+			assertFn: func(idp *stubIdP) {
+				assert.ErrorIs(t, err, identityproviders.ErrIDToken)
+			},
+		},
+		{
+			name: "return error when id token is signed by a key the provider doesn't publish",
+			req:  mockReq,
+			mockFn: func(idp *stubIdP) {
+				idp.signKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+				idp.signKid = "unknown"
+			},
+			assertFn: func(idp *stubIdP) {
+				assert.ErrorIs(t, err, identityproviders.ErrIDToken)
+			},
+		},
+		{
+			name: "return error when discovery document is of another issuer",
+			req:  mockReq,
+			mockFn: func(idp *stubIdP) {
+				idp.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					json.NewEncoder(w).Encode(map[string]string{"issuer": "https://evil.local"})
+				})
+			},
+			assertFn: func(idp *stubIdP) {
+				assert.ErrorIs(t, err, identityproviders.ErrDiscovery)
+			},
+		},
+		{
+			name:   "success",
+			req:    mockReq,
+			mockFn: func(idp *stubIdP) {},
+			assertFn: func(idp *stubIdP) {
+				assert.NoError(t, err)
+				assert.Equal(t, &domains.OIDCIdentity{
+					Issuer:        idp.URL,
+					Subject:       "subject",
+					Email:         "alice@company.com",
+					EmailVerified: true,
+					Username:      "alice",
+				}, result)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			idp := newStubIdP(t)
+			tc.mockFn(idp)
+			p := identityproviders.NewOIDCProvider(idp.URL, clientId, clientSecret, redirectURL, []string{"openid"})
+			result, err = p.Exchange(ctx, tc.req)
+			tc.assertFn(idp)
+		})
+	}
+}
+
+func TestExchangeAfterKeyRotation(t *testing.T) {
+	idp := newStubIdP(t)
+	p := identityproviders.NewOIDCProvider(idp.URL, clientId, clientSecret, redirectURL, []string{"openid"})
+	req := &domains.ExchangeCodeRequest{Code: "code", CodeVerifier: verifier, Nonce: "nonce"}
+
+	_, err := p.Exchange(ctx, req)
+	assert.NoError(t, err)
+	_, err = p.Exchange(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, idp.jwksCalls)
+
+	// the keys are fetched again once the provider signs with a new key
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	idp.key, idp.kid, idp.signKey, idp.signKid = key, "key-2", key, "key-2"
+	_, err = p.Exchange(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, idp.jwksCalls)
+}
@@ -0,0 +1,54 @@
+package mailers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"robinhood/internal/core/domains"
+	"sync"
+	"time"
+)
+
+// OutboxMailer doesn't deliver anything, it keeps the sent mails in memory
+// and also writes them as json files when a directory is given.
+type OutboxMailer struct {
+	mu    sync.Mutex
+	dir   string
+	mails []domains.Mail
+}
+
+func NewOutboxMailer(dir string) *OutboxMailer {
+	return &OutboxMailer{dir: dir}
+}
+
+func (m *OutboxMailer) Send(ctx context.Context, mail *domains.Mail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mails = append(m.mails, *mail)
+	if m.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(mail, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%03d.json", time.Now().UTC().UnixNano(), len(m.mails))
+	return os.WriteFile(filepath.Join(m.dir, name), b, 0o644)
+}
+
+// Mails returns a copy of every mail sent so far.
+func (m *OutboxMailer) Mails() []domains.Mail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]domains.Mail, len(m.mails))
+	copy(result, m.mails)
+	return result
+}
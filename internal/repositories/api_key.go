@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type apiKeyRepository struct {
+	mc  *mongo.Client
+	db  string
+	cn  string
+	col *mongo.Collection
+}
+
+func NewAPIKeyRepository(mc *mongo.Client, db string) ports.APIKeyRepository {
+	cn := "api_key"
+	col := mc.Database(db).Collection(cn)
+	// create index
+	col.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.M{"keyHash": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.M{"userId": 1},
+		},
+	})
+	return &apiKeyRepository{
+		mc:  mc,
+		db:  db,
+		cn:  cn,
+		col: col,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, req *domains.StoreAPIKeyRequest) (*domains.APIKey, error) {
+	in := domains.APIKey{
+		UserId:    req.UserId,
+		Name:      req.Name,
+		Prefix:    req.Prefix,
+		KeyHash:   req.KeyHash,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: time.Now().UTC(),
+	}
+	result, err := r.col.InsertOne(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	in.ID, _ = result.InsertedID.(primitive.ObjectID)
+	return &in, nil
+}
+
+func (r *apiKeyRepository) GetByKeyHash(ctx context.Context, hash string) (*domains.APIKey, error) {
+	var result domains.APIKey
+	if err := r.col.FindOne(ctx, bson.M{"keyHash": hash}).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *apiKeyRepository) ListByUserID(ctx context.Context, userId primitive.ObjectID) ([]domains.APIKey, error) {
+	result := []domains.APIKey{}
+	opts := options.Find().SetSort(bson.M{"createdAt": -1})
+	cursor, err := r.col.Find(ctx, bson.M{"userId": userId}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *apiKeyRepository) UpdateLastUsedAt(ctx context.Context, id primitive.ObjectID, t time.Time) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"lastUsedAt": t}})
+	return err
+}
+
+// Delete removes the key only when it belongs to the user, it reports false
+// when there is no such key.
+func (r *apiKeyRepository) Delete(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) (bool, error) {
+	result, err := r.col.DeleteOne(ctx, bson.M{"_id": id, "userId": userId})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount == 1, nil
+}
+
+func (r *apiKeyRepository) DeleteByUserID(ctx context.Context, userId primitive.ObjectID) error {
+	_, err := r.col.DeleteMany(ctx, bson.M{"userId": userId})
+	return err
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// authorStages populates the author of blogs and comments. Deactivated authors
+// are not looked up and anonymized documents have no author, either way the
+// document is kept with an empty author so the profile isn't shown.
+func authorStages() []bson.M {
+	return userStages("authorId", "author")
+}
+
+// assigneeStages populates the assignees of blogs ordered by username,
+// deactivated or deleted users are left out.
+func assigneeStages() []bson.M {
+	return []bson.M{
+		{
+			"$lookup": bson.M{
+				"from": "user",
+				"let":  bson.M{"assigneeIds": bson.M{"$ifNull": bson.A{"$assigneeIds", bson.A{}}}},
+				"pipeline": []bson.M{
+					{"$match": bson.M{
+						"$expr":       bson.M{"$in": bson.A{"$_id", "$$assigneeIds"}},
+						"deactivated": bson.M{"$ne": true},
+					}},
+					{"$sort": bson.M{"username": 1}},
+				},
+				"as": "assignees",
+			},
+		},
+	}
+}
+
+// labelStages populates the labels of blogs ordered by name.
+func labelStages() []bson.M {
+	return []bson.M{
+		{
+			"$lookup": bson.M{
+				"from": "label",
+				"let":  bson.M{"labelIds": bson.M{"$ifNull": bson.A{"$labelIds", bson.A{}}}},
+				"pipeline": []bson.M{
+					{"$match": bson.M{"$expr": bson.M{"$in": bson.A{"$_id", "$$labelIds"}}}},
+					{"$sort": bson.M{"name": 1}},
+				},
+				"as": "labels",
+			},
+		},
+	}
+}
+
+// userStages populates the user referenced by the field into as, with the
+// same rules as the author.
+func userStages(field string, as string) []bson.M {
+	return []bson.M{
+		{
+			"$lookup": bson.M{
+				"from": "user",
+				"let":  bson.M{"userId": "$" + field},
+				"pipeline": []bson.M{
+					{"$match": bson.M{
+						"$expr":       bson.M{"$eq": bson.A{"$_id", "$$userId"}},
+						"deactivated": bson.M{"$ne": true},
+					}},
+				},
+				"as": as,
+			},
+		},
+		{"$unwind": bson.M{"path": "$" + as, "preserveNullAndEmptyArrays": true}},
+	}
+}
@@ -3,7 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
-	"robinhood/internal/core/constants"
+	"regexp"
 	"robinhood/internal/core/domains"
 	"robinhood/internal/core/ports"
 	"time"
@@ -24,6 +24,24 @@ type blogRepository struct {
 func NewBlogRepository(mc *mongo.Client, db string) ports.BlogRepository {
 	cn := "blog"
 	col := mc.Database(db).Collection(cn)
+	// create index
+	col.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.M{"authorId": 1}},
+		{Keys: bson.D{{Key: "isArchived", Value: 1}, {Key: "archivedAt", Value: 1}}},
+		{Keys: bson.D{{Key: "assigneeIds", Value: 1}, {Key: "isArchived", Value: 1}}},
+		{Keys: bson.D{{Key: "isArchived", Value: 1}, {Key: "dueAt", Value: 1}}},
+		{Keys: bson.D{{Key: "labelIds", Value: 1}, {Key: "isArchived", Value: 1}}},
+		{Keys: bson.D{{Key: "isArchived", Value: 1}, {Key: "status", Value: 1}, {Key: "createdAt", Value: 1}}},
+		// one for each of the sort fields, walked backward for a descending sort
+		{Keys: bson.D{{Key: "isArchived", Value: 1}, {Key: "priority", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "isArchived", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "isArchived", Value: 1}, {Key: "updatedAt", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "isArchived", Value: 1}, {Key: "dueAt", Value: 1}, {Key: "_id", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "isArchived", Value: 1}, {Key: "title", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetCollation(caseInsensitive),
+		},
+	})
 	return &blogRepository{
 		mc:  mc,
 		db:  db,
@@ -34,13 +52,18 @@ func NewBlogRepository(mc *mongo.Client, db string) ports.BlogRepository {
 
 func (r *blogRepository) Create(ctx context.Context, req *domains.CreateBlogRequest) (*domains.Blog, error) {
 	aid, _ := primitive.ObjectIDFromHex(req.AuthorId)
-	return r.insertOne(ctx, domains.Blog{
+	blog := domains.Blog{
 		Title:      req.Title,
 		Content:    req.Content,
 		AuthorId:   aid,
-		Status:     constants.TO_DO,
+		Status:     req.Status,
 		IsArchived: false,
-	})
+	}
+	blog.Priority, _ = domains.ParsePriority(req.Priority)
+	if req.DueAt != nil {
+		blog.DueAt = req.DueAt.UTC()
+	}
+	return r.insertOne(ctx, blog)
 }
 
 func (r *blogRepository) CreateTx(ctx context.Context, req *domains.CreateBlogRequest, fn domains.CreateBlogFn) (*domains.PopulatedBlog, error) {
@@ -63,22 +86,40 @@ func (r *blogRepository) CreateTx(ctx context.Context, req *domains.CreateBlogRe
 	return res.(*domains.PopulatedBlog), nil
 }
 
+func (r *blogRepository) GetByID(ctx context.Context, id string) (*domains.Blog, error) {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var result domains.Blog
+	if err := r.col.FindOne(ctx, bson.M{"_id": oid, "isArchived": false}).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *blogRepository) GetArchivedByID(ctx context.Context, id string) (*domains.Blog, error) {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var result domains.Blog
+	if err := r.col.FindOne(ctx, bson.M{"_id": oid, "isArchived": true}).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
 func (r *blogRepository) GetPopulatedBlogByID(ctx context.Context, id string) (*domains.PopulatedBlog, error) {
 	oid, _ := primitive.ObjectIDFromHex(id)
 	result := &domains.PopulatedBlog{}
 	pipeline := []bson.M{
 		{"$match": bson.M{"_id": oid, "isArchived": false}},
 		{"$limit": 1},
-		{
-			"$lookup": bson.M{
-				"from":         "user",
-				"localField":   "authorId",
-				"foreignField": "_id",
-				"as":           "author",
-			},
-		},
-		{"$unwind": "$author"},
 	}
+	pipeline = append(pipeline, authorStages()...)
+	pipeline = append(pipeline, assigneeStages()...)
+	pipeline = append(pipeline, labelStages()...)
 
 	cursor, err := r.col.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -94,26 +135,29 @@ func (r *blogRepository) GetPopulatedBlogByID(ctx context.Context, id string) (*
 	return result, nil
 }
 
-func (r *blogRepository) List(ctx context.Context, req *domains.PaginationOptions) ([]domains.PopulatedBlog, error) {
+func (r *blogRepository) List(ctx context.Context, filter *domains.BlogFilter, sort *domains.BlogSort, req *domains.PaginationOptions) ([]domains.PopulatedBlog, error) {
 	result := []domains.PopulatedBlog{}
+	// _id breaks the ties in the same direction so the sort indexes serve both
+	dir := 1
+	if sort.Desc {
+		dir = -1
+	}
+	opts := options.Aggregate()
+	if sort.Field == "title" {
+		opts.SetCollation(caseInsensitive)
+	}
 	// aggregate pipeline to get populated blog + count numbers of blogs and map to result object
 	pipeline := []bson.M{
-		{"$match": bson.M{"isArchived": false}},
-		{"$sort": bson.M{"createdAt": -1}},
+		{"$match": blogFilter(filter)},
+		{"$sort": bson.D{{Key: sort.Field, Value: dir}, {Key: "_id", Value: dir}}},
 		{"$skip": req.Offset},
 		{"$limit": req.Limit},
-		{
-			"$lookup": bson.M{
-				"from":         "user",
-				"localField":   "authorId",
-				"foreignField": "_id",
-				"as":           "author",
-			},
-		},
-		{"$unwind": "$author"},
-		{"$project": bson.M{"comments": 0}},
 	}
-	cursor, err := r.col.Aggregate(ctx, pipeline)
+	pipeline = append(pipeline, authorStages()...)
+	pipeline = append(pipeline, assigneeStages()...)
+	pipeline = append(pipeline, labelStages()...)
+	pipeline = append(pipeline, bson.M{"$project": bson.M{"comments": 0}})
+	cursor, err := r.col.Aggregate(ctx, pipeline, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -125,24 +169,282 @@ func (r *blogRepository) List(ctx context.Context, req *domains.PaginationOption
 	return result, nil
 }
 
-func (r *blogRepository) Count(ctx context.Context, req *domains.PaginationOptions) (int64, error) {
-	filter := bson.M{"isArchived": false}
+func (r *blogRepository) Count(ctx context.Context, filter *domains.BlogFilter, req *domains.PaginationOptions) (int64, error) {
 	opts := options.Count().SetSkip(req.Offset).SetLimit(req.Limit)
-	return r.col.CountDocuments(ctx, filter, opts)
+	return r.col.CountDocuments(ctx, blogFilter(filter), opts)
 }
 
-func (r *blogRepository) UpdateStatus(ctx context.Context, req *domains.UpdateBlogStatusRequest) error {
+// UpdateStatus returns the blog as it was before, so the previous status is
+// read in the same write.
+func (r *blogRepository) UpdateStatus(ctx context.Context, req *domains.UpdateBlogStatusRequest) (*domains.Blog, error) {
 	oid, _ := primitive.ObjectIDFromHex(req.BlogId)
-	_, err := r.updateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"status": req.Status}})
+	var result domains.Blog
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	err := r.col.FindOneAndUpdate(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"status": req.Status}}, opts).Decode(&result)
+	return &result, err
+}
+
+func (r *blogRepository) UpdateStatusTx(ctx context.Context, req *domains.UpdateBlogStatusRequest, fn domains.UpdateBlogStatusFn) error {
+	session, err := r.mc.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc, req)
+	})
+	return err
+}
+
+// Edit changes the title and the content which are given.
+func (r *blogRepository) Edit(ctx context.Context, req *domains.EditBlogRequest) error {
+	oid, _ := primitive.ObjectIDFromHex(req.BlogId)
+	uid, _ := primitive.ObjectIDFromHex(req.UserId)
+	set := bson.M{"updatedAt": time.Now().UTC(), "updatedBy": uid}
+	if req.Title != nil {
+		set["title"] = *req.Title
+	}
+	if req.Content != nil {
+		set["content"] = *req.Content
+	}
+	if req.Priority != nil {
+		set["priority"], _ = domains.ParsePriority(*req.Priority)
+	}
+	_, err := r.updateOne(ctx, bson.M{"_id": oid, "isArchived": false}, bson.M{"$set": set})
+	return err
+}
+
+// SetDue changes the due date, the reminders of the previous one are forgotten.
+func (r *blogRepository) SetDue(ctx context.Context, req *domains.SetBlogDueRequest) error {
+	oid, _ := primitive.ObjectIDFromHex(req.BlogId)
+	update := bson.M{"$unset": bson.M{"dueAt": "", "reminders": ""}}
+	if req.DueAt != nil {
+		update = bson.M{
+			"$set":   bson.M{"dueAt": req.DueAt.UTC()},
+			"$unset": bson.M{"reminders": ""},
+		}
+	}
+	_, err := r.updateOne(ctx, bson.M{"_id": oid, "isArchived": false}, update)
+	return err
+}
+
+func (r *blogRepository) ListForReminder(ctx context.Context, req *domains.ListBlogReminderRequest) ([]domains.Blog, error) {
+	due := bson.M{"$lt": req.DueBefore}
+	if !req.DueAfter.IsZero() {
+		due["$gte"] = req.DueAfter
+	}
+	filter := bson.M{
+		"isArchived":            false,
+		"dueAt":                 due,
+		"status":                bson.M{"$nin": req.DoneStatuses},
+		"reminders." + req.Kind: bson.M{"$exists": false},
+	}
+	result := []domains.Blog{}
+	opts := options.Find().SetSort(bson.M{"dueAt": 1}).SetLimit(req.Limit)
+	cursor, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MarkReminded records the kind of reminder as sent, it is false when
+// another run has already sent it.
+func (r *blogRepository) MarkReminded(ctx context.Context, id string, kind string) (bool, error) {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	filter := bson.M{"_id": oid, "reminders." + kind: bson.M{"$exists": false}}
+	result, err := r.col.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"reminders." + kind: time.Now().UTC()}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *blogRepository) AttachLabel(ctx context.Context, req *domains.AttachLabelRequest) error {
+	oid, _ := primitive.ObjectIDFromHex(req.BlogId)
+	lid, _ := primitive.ObjectIDFromHex(req.LabelId)
+	_, err := r.updateOne(ctx, bson.M{"_id": oid, "isArchived": false}, bson.M{"$addToSet": bson.M{"labelIds": lid}})
+	return err
+}
+
+func (r *blogRepository) DetachLabel(ctx context.Context, req *domains.DetachLabelRequest) error {
+	oid, _ := primitive.ObjectIDFromHex(req.BlogId)
+	lid, _ := primitive.ObjectIDFromHex(req.LabelId)
+	_, err := r.updateOne(ctx, bson.M{"_id": oid, "isArchived": false}, bson.M{"$pull": bson.M{"labelIds": lid}})
+	return err
+}
+
+// RemoveLabel detaches a deleted label from every blog, archived ones too.
+func (r *blogRepository) RemoveLabel(ctx context.Context, labelId string) error {
+	lid, _ := primitive.ObjectIDFromHex(labelId)
+	_, err := r.col.UpdateMany(ctx, bson.M{"labelIds": lid}, bson.M{"$pull": bson.M{"labelIds": lid}})
+	return err
+}
+
+func (r *blogRepository) Assign(ctx context.Context, req *domains.AssignBlogRequest) error {
+	oid, _ := primitive.ObjectIDFromHex(req.BlogId)
+	aid, _ := primitive.ObjectIDFromHex(req.AssigneeId)
+	_, err := r.updateOne(ctx, bson.M{"_id": oid, "isArchived": false}, bson.M{"$addToSet": bson.M{"assigneeIds": aid}})
+	return err
+}
+
+func (r *blogRepository) Unassign(ctx context.Context, req *domains.UnassignBlogRequest) error {
+	oid, _ := primitive.ObjectIDFromHex(req.BlogId)
+	aid, _ := primitive.ObjectIDFromHex(req.AssigneeId)
+	_, err := r.updateOne(ctx, bson.M{"_id": oid, "isArchived": false}, bson.M{"$pull": bson.M{"assigneeIds": aid}})
 	return err
 }
 
 func (r *blogRepository) Archive(ctx context.Context, req *domains.ArchiveBlogRequest) error {
 	oid, _ := primitive.ObjectIDFromHex(req.BlogId)
-	_, err := r.updateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"isArchived": true}})
+	uid, _ := primitive.ObjectIDFromHex(req.UserId)
+	_, err := r.updateOne(ctx, bson.M{"_id": oid, "isArchived": false}, bson.M{"$set": bson.M{
+		"isArchived": true,
+		"archivedAt": time.Now().UTC(),
+		"archivedBy": uid,
+	}})
+	return err
+}
+
+func (r *blogRepository) Restore(ctx context.Context, req *domains.RestoreBlogRequest) error {
+	oid, _ := primitive.ObjectIDFromHex(req.BlogId)
+	_, err := r.updateOne(ctx, bson.M{"_id": oid, "isArchived": true}, bson.M{
+		"$set":   bson.M{"isArchived": false},
+		"$unset": bson.M{"archivedAt": "", "archivedBy": ""},
+	})
+	return err
+}
+
+// ListArchivedIDs returns the blogs archived before the time, a blog archived
+// before archivedAt was recorded is never returned.
+func (r *blogRepository) ListArchivedIDs(ctx context.Context, req *domains.PurgeArchivedBlogsRequest) ([]string, error) {
+	filter := bson.M{"isArchived": true, "archivedAt": bson.M{"$lt": req.ArchivedBefore}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1}).SetSort(bson.M{"archivedAt": 1}).SetLimit(req.Limit)
+	cursor, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	blogs := []domains.Blog{}
+	if err := cursor.All(ctx, &blogs); err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(blogs))
+	for i, blog := range blogs {
+		result[i] = blog.ID.Hex()
+	}
+	return result, nil
+}
+
+func (r *blogRepository) DeleteByIDs(ctx context.Context, ids []string) error {
+	_, err := r.col.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": objectIDs(ids)}})
+	return err
+}
+
+func (r *blogRepository) PurgeTx(ctx context.Context, req *domains.PurgeArchivedBlogsRequest, fn domains.PurgeArchivedBlogsFn) (int64, error) {
+	session, err := r.mc.StartSession()
+	if err != nil {
+		return 0, err
+	}
+	defer session.EndSession(ctx)
+	res, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return fn(sc, req)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.(int64), nil
+}
+
+// EachByAuthorID calls fn with the blogs of the author, the oldest first, one
+// at a time as they are read from the cursor.
+func (r *blogRepository) EachByAuthorID(ctx context.Context, authorId string, fn func(domains.Blog) error) error {
+	aid, _ := primitive.ObjectIDFromHex(authorId)
+	opts := options.Find().SetSort(bson.M{"createdAt": 1})
+	cursor, err := r.col.Find(ctx, bson.M{"authorId": aid}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var blog domains.Blog
+		if err := cursor.Decode(&blog); err != nil {
+			return err
+		}
+		if err := fn(blog); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func (r *blogRepository) AnonymizeAuthor(ctx context.Context, authorId string) error {
+	aid, _ := primitive.ObjectIDFromHex(authorId)
+	_, err := r.col.UpdateMany(ctx, bson.M{"authorId": aid}, bson.M{"$set": bson.M{"authorId": primitive.NilObjectID}})
+	return err
+}
+
+// UnassignUser removes the user from every blog it is assigned to.
+func (r *blogRepository) UnassignUser(ctx context.Context, userId string) error {
+	uid, _ := primitive.ObjectIDFromHex(userId)
+	_, err := r.col.UpdateMany(ctx, bson.M{"assigneeIds": uid}, bson.M{"$pull": bson.M{"assigneeIds": uid}})
 	return err
 }
 
+func blogFilter(filter *domains.BlogFilter) bson.M {
+	match := bson.M{"isArchived": filter.Archived}
+	if filter.AssigneeId != "" {
+		aid, _ := primitive.ObjectIDFromHex(filter.AssigneeId)
+		match["assigneeIds"] = aid
+	}
+	if len(filter.LabelIds) > 0 {
+		op := "$in"
+		if filter.AllLabels {
+			op = "$all"
+		}
+		match["labelIds"] = bson.M{op: objectIDs(filter.LabelIds)}
+	}
+	if filter.AuthorId != "" {
+		aid, _ := primitive.ObjectIDFromHex(filter.AuthorId)
+		match["authorId"] = aid
+	}
+	// the overdue blogs and the statuses both narrow the status
+	status := bson.M{}
+	if len(filter.Statuses) > 0 {
+		status["$in"] = filter.Statuses
+	}
+	if !filter.OverdueAt.IsZero() {
+		match["dueAt"] = bson.M{"$lt": filter.OverdueAt}
+		status["$nin"] = filter.DoneStatuses
+	}
+	if len(status) > 0 {
+		match["status"] = status
+	}
+	created := bson.M{}
+	if !filter.CreatedFrom.IsZero() {
+		created["$gte"] = filter.CreatedFrom
+	}
+	if !filter.CreatedTo.IsZero() {
+		created["$lt"] = filter.CreatedTo
+	}
+	if len(created) > 0 {
+		match["createdAt"] = created
+	}
+	if filter.Title != "" {
+		// the title is matched as it's written, not as a pattern
+		match["title"] = bson.M{"$regex": regexp.QuoteMeta(filter.Title), "$options": "i"}
+	}
+	return match
+}
+
 func (r *blogRepository) insertOne(ctx context.Context, in domains.Blog) (*domains.Blog, error) {
 	in.CreatedAt = time.Now().UTC()
 	fmt.Printf("in: %+v\n", in)
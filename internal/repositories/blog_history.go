@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type blogHistoryRepository struct {
+	mc  *mongo.Client
+	db  string
+	cn  string
+	col *mongo.Collection
+}
+
+func NewBlogHistoryRepository(mc *mongo.Client, db string) ports.BlogHistoryRepository {
+	cn := "blog_history"
+	col := mc.Database(db).Collection(cn)
+	// create index
+	col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "blogId", Value: 1}, {Key: "createdAt", Value: 1}},
+	})
+	return &blogHistoryRepository{
+		mc:  mc,
+		db:  db,
+		cn:  cn,
+		col: col,
+	}
+}
+
+func (r *blogHistoryRepository) Create(ctx context.Context, req *domains.CreateBlogHistoryRequest) (*domains.BlogHistory, error) {
+	bid, _ := primitive.ObjectIDFromHex(req.BlogId)
+	aid, _ := primitive.ObjectIDFromHex(req.ActorId)
+	history := domains.BlogHistory{
+		BlogId:    bid,
+		From:      req.From,
+		To:        req.To,
+		ActorId:   aid,
+		CreatedAt: time.Now().UTC(),
+	}
+	result, err := r.col.InsertOne(ctx, history)
+	if err != nil {
+		return nil, err
+	}
+	history.ID, _ = result.InsertedID.(primitive.ObjectID)
+	return &history, nil
+}
+
+// ListByBlogID returns the status changes of a blog, the oldest first.
+func (r *blogHistoryRepository) ListByBlogID(ctx context.Context, blogId string) ([]domains.PopulatedBlogHistory, error) {
+	bid, _ := primitive.ObjectIDFromHex(blogId)
+	result := []domains.PopulatedBlogHistory{}
+	pipeline := []bson.M{
+		{"$match": bson.M{"blogId": bid}},
+		{"$sort": bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+	pipeline = append(pipeline, userStages("actorId", "actor")...)
+	cursor, err := r.col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *blogHistoryRepository) DeleteByBlogIDs(ctx context.Context, blogIds []string) error {
+	_, err := r.col.DeleteMany(ctx, bson.M{"blogId": bson.M{"$in": objectIDs(blogIds)}})
+	return err
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"robinhood/internal/core/domains"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestEachByAuthorID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+	aid := primitive.NewObjectID()
+	ids := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+
+	// the blogs come in two batches, the second one is only fetched once the
+	// first is handed over
+	batches := func(mt *mtest.T) []bson.D {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		return []bson.D{
+			mtest.CreateCursorResponse(1, ns, mtest.FirstBatch,
+				bson.D{{Key: "_id", Value: ids[0]}, {Key: "authorId", Value: aid}},
+				bson.D{{Key: "_id", Value: ids[1]}, {Key: "authorId", Value: aid}}),
+			mtest.CreateCursorResponse(0, ns, mtest.NextBatch,
+				bson.D{{Key: "_id", Value: ids[2]}, {Key: "authorId", Value: aid}}),
+		}
+	}
+
+	mt.Run("should hand over every blog of the author in order", func(mt *mtest.T) {
+		r := &blogRepository{col: mt.Coll}
+		mt.AddMockResponses(batches(mt)...)
+
+		var got []primitive.ObjectID
+		err := r.EachByAuthorID(context.TODO(), aid.Hex(), func(blog domains.Blog) error {
+			got = append(got, blog.ID)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, ids, got)
+
+		find := mt.GetStartedEvent().Command
+		assert.Equal(t, aid, find.Lookup("filter", "authorId").ObjectID())
+		assert.Equal(t, int32(1), find.Lookup("sort", "createdAt").Int32())
+	})
+
+	mt.Run("should stop at the first error of fn", func(mt *mtest.T) {
+		r := &blogRepository{col: mt.Coll}
+		mt.AddMockResponses(batches(mt)...)
+		want := errors.New("error")
+
+		n := 0
+		err := r.EachByAuthorID(context.TODO(), aid.Hex(), func(blog domains.Blog) error {
+			n++
+			return want
+		})
+		assert.Equal(t, want, err)
+		assert.Equal(t, 1, n)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type commentRepository struct {
@@ -22,8 +23,9 @@ func NewCommentRepository(mc *mongo.Client, db string) ports.CommentRepository {
 	cn := "comment"
 	col := mc.Database(db).Collection(cn)
 	// create index
-	col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
-		Keys: bson.M{"blogId": 1},
+	col.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.M{"blogId": 1}},
+		{Keys: bson.M{"authorId": 1}},
 	})
 	return &commentRepository{
 		mc:  mc,
@@ -69,16 +71,8 @@ func (r *commentRepository) List(ctx context.Context, blogId string) ([]domains.
 	pipeline := []bson.M{
 		{"$match": bson.M{"blogId": oid}},
 		{"$sort": bson.M{"createdAt": -1}},
-		{
-			"$lookup": bson.M{
-				"from":         "user",
-				"localField":   "authorId",
-				"foreignField": "_id",
-				"as":           "author",
-			},
-		},
-		{"$unwind": "$author"},
 	}
+	pipeline = append(pipeline, authorStages()...)
 
 	cursor, err := r.col.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -92,6 +86,40 @@ func (r *commentRepository) List(ctx context.Context, blogId string) ([]domains.
 	return result, nil
 }
 
+// EachByAuthorID calls fn with the comments of the author, the oldest first,
+// one at a time as they are read from the cursor.
+func (r *commentRepository) EachByAuthorID(ctx context.Context, authorId string, fn func(domains.Comment) error) error {
+	aid, _ := primitive.ObjectIDFromHex(authorId)
+	opts := options.Find().SetSort(bson.M{"createdAt": 1})
+	cursor, err := r.col.Find(ctx, bson.M{"authorId": aid}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var comment domains.Comment
+		if err := cursor.Decode(&comment); err != nil {
+			return err
+		}
+		if err := fn(comment); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func (r *commentRepository) DeleteByBlogIDs(ctx context.Context, blogIds []string) error {
+	_, err := r.col.DeleteMany(ctx, bson.M{"blogId": bson.M{"$in": objectIDs(blogIds)}})
+	return err
+}
+
+func (r *commentRepository) AnonymizeAuthor(ctx context.Context, authorId string) error {
+	aid, _ := primitive.ObjectIDFromHex(authorId)
+	_, err := r.col.UpdateMany(ctx, bson.M{"authorId": aid}, bson.M{"$set": bson.M{"authorId": primitive.NilObjectID}})
+	return err
+}
+
 func (r *commentRepository) insertOne(ctx context.Context, in domains.Comment) (*domains.Comment, error) {
 	in.CreatedAt = time.Now().UTC()
 	result, err := r.col.InsertOne(ctx, in)
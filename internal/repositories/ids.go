@@ -0,0 +1,13 @@
+package repositories
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// objectIDs parses the hex ids, an invalid one becomes the nil id which
+// matches nothing.
+func objectIDs(ids []string) []primitive.ObjectID {
+	result := make([]primitive.ObjectID, len(ids))
+	for i, id := range ids {
+		result[i], _ = primitive.ObjectIDFromHex(id)
+	}
+	return result
+}
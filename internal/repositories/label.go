@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type labelRepository struct {
+	mc  *mongo.Client
+	db  string
+	cn  string
+	col *mongo.Collection
+}
+
+func NewLabelRepository(mc *mongo.Client, db string) ports.LabelRepository {
+	cn := "label"
+	col := mc.Database(db).Collection(cn)
+	// create index
+	col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.M{"name": 1},
+		Options: options.Index().SetUnique(true).SetCollation(caseInsensitive),
+	})
+	return &labelRepository{
+		mc:  mc,
+		db:  db,
+		cn:  cn,
+		col: col,
+	}
+}
+
+func (r *labelRepository) Create(ctx context.Context, req *domains.CreateLabelRequest) (*domains.Label, error) {
+	label := domains.Label{
+		Name:      req.Name,
+		Color:     req.Color,
+		CreatedAt: time.Now().UTC(),
+	}
+	result, err := r.col.InsertOne(ctx, label)
+	if err != nil {
+		return nil, labelNameError(err)
+	}
+	label.ID, _ = result.InsertedID.(primitive.ObjectID)
+	return &label, nil
+}
+
+func (r *labelRepository) GetByID(ctx context.Context, id string) (*domains.Label, error) {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var result domains.Label
+	if err := r.col.FindOne(ctx, bson.M{"_id": oid}).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// List returns every label ordered by name.
+func (r *labelRepository) List(ctx context.Context) ([]domains.Label, error) {
+	result := []domains.Label{}
+	opts := options.Find().SetSort(bson.M{"name": 1}).SetCollation(caseInsensitive)
+	cursor, err := r.col.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Update changes the name and the color which are given, it returns nil when
+// the label doesn't exist.
+func (r *labelRepository) Update(ctx context.Context, req *domains.UpdateLabelRequest) (*domains.Label, error) {
+	oid, _ := primitive.ObjectIDFromHex(req.LabelId)
+	set := bson.M{"updatedAt": time.Now().UTC()}
+	if req.Name != nil {
+		set["name"] = *req.Name
+	}
+	if req.Color != nil {
+		set["color"] = *req.Color
+	}
+	var result domains.Label
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := r.col.FindOneAndUpdate(ctx, bson.M{"_id": oid}, bson.M{"$set": set}, opts).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, labelNameError(err)
+	}
+	return &result, nil
+}
+
+func (r *labelRepository) Delete(ctx context.Context, id string) error {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.col.DeleteOne(ctx, bson.M{"_id": oid})
+	return err
+}
+
+func (r *labelRepository) DeleteTx(ctx context.Context, id string, fn domains.DeleteLabelFn) error {
+	session, err := r.mc.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc, id)
+	})
+	return err
+}
+
+func labelNameError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return domains.ErrLabelNameTaken
+	}
+	return err
+}
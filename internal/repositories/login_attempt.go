@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type loginAttemptRepository struct {
+	mc  *mongo.Client
+	db  string
+	cn  string
+	col *mongo.Collection
+}
+
+func NewLoginAttemptRepository(mc *mongo.Client, db string) ports.LoginAttemptRepository {
+	cn := "login_attempt"
+	col := mc.Database(db).Collection(cn)
+	// create index
+	col.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.M{"key": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// failures are forgotten after a quiet window
+			Keys:    bson.M{"expiresAt": 1},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	return &loginAttemptRepository{
+		mc:  mc,
+		db:  db,
+		cn:  cn,
+		col: col,
+	}
+}
+
+func (r *loginAttemptRepository) GetByKeys(ctx context.Context, keys []string) ([]domains.LoginAttempt, error) {
+	cursor, err := r.col.Find(ctx, bson.M{"key": bson.M{"$in": keys}})
+	if err != nil {
+		return nil, err
+	}
+	result := []domains.LoginAttempt{}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// IncrementFailures counts one more failure of the key atomically and returns the updated attempt.
+func (r *loginAttemptRepository) IncrementFailures(ctx context.Context, key string, expiresAt time.Time) (*domains.LoginAttempt, error) {
+	var result domains.LoginAttempt
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	update := bson.M{
+		"$inc": bson.M{"failures": 1},
+		"$set": bson.M{"lastFailedAt": time.Now().UTC()},
+		"$max": bson.M{"expiresAt": expiresAt},
+	}
+	if err := r.col.FindOneAndUpdate(ctx, bson.M{"key": key}, update, opts).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	// keep the document at least as long as the lock
+	_, err := r.col.UpdateOne(ctx, bson.M{"key": key}, bson.M{
+		"$set": bson.M{"lockedUntil": until},
+		"$max": bson.M{"expiresAt": until},
+	})
+	return err
+}
+
+func (r *loginAttemptRepository) DeleteByKey(ctx context.Context, key string) error {
+	_, err := r.col.DeleteOne(ctx, bson.M{"key": key})
+	return err
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type oidcStateRepository struct {
+	mc  *mongo.Client
+	db  string
+	cn  string
+	col *mongo.Collection
+}
+
+func NewOIDCStateRepository(mc *mongo.Client, db string) ports.OIDCStateRepository {
+	cn := "oidc_state"
+	col := mc.Database(db).Collection(cn)
+	// create index
+	col.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.M{"stateHash": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// remove logins which never came back
+			Keys:    bson.M{"expiresAt": 1},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	return &oidcStateRepository{
+		mc:  mc,
+		db:  db,
+		cn:  cn,
+		col: col,
+	}
+}
+
+func (r *oidcStateRepository) Create(ctx context.Context, req *domains.CreateOIDCStateRequest) (*domains.OIDCState, error) {
+	in := domains.OIDCState{
+		StateHash:    req.StateHash,
+		Nonce:        req.Nonce,
+		CodeVerifier: req.CodeVerifier,
+		ExpiresAt:    req.ExpiresAt,
+		CreatedAt:    time.Now().UTC(),
+	}
+	result, err := r.col.InsertOne(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	in.ID, _ = result.InsertedID.(primitive.ObjectID)
+	return &in, nil
+}
+
+// Consume removes the state and returns it, only the first callback of a
+// login gets it.
+func (r *oidcStateRepository) Consume(ctx context.Context, hash string) (*domains.OIDCState, error) {
+	var result domains.OIDCState
+	if err := r.col.FindOneAndDelete(ctx, bson.M{"stateHash": hash}).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
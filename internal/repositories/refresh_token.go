@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type refreshTokenRepository struct {
+	mc  *mongo.Client
+	db  string
+	cn  string
+	col *mongo.Collection
+}
+
+func NewRefreshTokenRepository(mc *mongo.Client, db string) ports.RefreshTokenRepository {
+	cn := "refresh_token"
+	col := mc.Database(db).Collection(cn)
+	// create index
+	col.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.M{"tokenHash": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.M{"familyId": 1},
+		},
+		{
+			Keys: bson.M{"userId": 1},
+		},
+		{
+			// remove refresh tokens once they are expired
+			Keys:    bson.M{"expiresAt": 1},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	return &refreshTokenRepository{
+		mc:  mc,
+		db:  db,
+		cn:  cn,
+		col: col,
+	}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, req *domains.CreateRefreshTokenRequest) (*domains.RefreshToken, error) {
+	return r.insertOne(ctx, domains.RefreshToken{
+		UserId:    req.UserId,
+		FamilyId:  req.FamilyId,
+		TokenHash: req.TokenHash,
+		ExpiresAt: req.ExpiresAt,
+	})
+}
+
+func (r *refreshTokenRepository) GetByTokenHash(ctx context.Context, hash string) (*domains.RefreshToken, error) {
+	var result domains.RefreshToken
+	if err := r.col.FindOne(ctx, bson.M{"tokenHash": hash}).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MarkUsed flags the refresh token as used, it reports false when the token
+// was already used so that concurrent rotations of the same token can't both win.
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	result, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "usedAt": nil}, bson.M{"$set": bson.M{"usedAt": time.Now().UTC()}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyId string) error {
+	_, err := r.col.UpdateMany(ctx, bson.M{"familyId": familyId, "revokedAt": nil}, bson.M{"$set": bson.M{"revokedAt": time.Now().UTC()}})
+	return err
+}
+
+func (r *refreshTokenRepository) RevokeByUserID(ctx context.Context, userId primitive.ObjectID) error {
+	_, err := r.col.UpdateMany(ctx, bson.M{"userId": userId, "revokedAt": nil}, bson.M{"$set": bson.M{"revokedAt": time.Now().UTC()}})
+	return err
+}
+
+func (r *refreshTokenRepository) insertOne(ctx context.Context, in domains.RefreshToken) (*domains.RefreshToken, error) {
+	in.CreatedAt = time.Now().UTC()
+	result, err := r.col.InsertOne(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	oid, _ := result.InsertedID.(primitive.ObjectID)
+	in.ID = oid
+	return &in, nil
+}
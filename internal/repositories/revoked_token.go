@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type revokedTokenRepository struct {
+	mc  *mongo.Client
+	db  string
+	cn  string
+	col *mongo.Collection
+}
+
+func NewRevokedTokenRepository(mc *mongo.Client, db string) ports.RevokedTokenRepository {
+	cn := "revoked_token"
+	col := mc.Database(db).Collection(cn)
+	// create index
+	col.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.M{"tokenId": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// a revoked token only has to be remembered until the token itself expires
+			Keys:    bson.M{"expiresAt": 1},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	return &revokedTokenRepository{
+		mc:  mc,
+		db:  db,
+		cn:  cn,
+		col: col,
+	}
+}
+
+func (r *revokedTokenRepository) Create(ctx context.Context, req *domains.RevokeTokenRequest) error {
+	uid, _ := primitive.ObjectIDFromHex(req.UserId)
+	_, err := r.col.InsertOne(ctx, domains.RevokedToken{
+		TokenId:   req.TokenId,
+		UserId:    uid,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: time.Now().UTC(),
+	})
+	// revoking the same token twice is not an error
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *revokedTokenRepository) IsRevoked(ctx context.Context, tokenId string) (bool, error) {
+	count, err := r.col.CountDocuments(ctx, bson.M{"tokenId": tokenId}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -2,8 +2,11 @@ package repositories
 
 import (
 	"context"
+	"log"
+	"regexp"
 	"robinhood/internal/core/domains"
 	"robinhood/internal/core/ports"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -12,6 +15,15 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const (
+	usernameIndex = "username_ci_unique"
+	emailIndex    = "email_ci_unique"
+)
+
+// caseInsensitive makes "Alice" and "alice" the same username, queries have
+// to use the same collation to be served by the unique indexes.
+var caseInsensitive = &options.Collation{Locale: "en", Strength: 2}
+
 type userRepository struct {
 	mc  *mongo.Client
 	db  string
@@ -23,9 +35,28 @@ func NewUserRepository(mc *mongo.Client, db string) ports.UserRepository {
 	cn := "user"
 	col := mc.Database(db).Collection(cn)
 	// create index
-	col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
-		Keys: bson.M{"username": 1},
-	})
+	if _, err := col.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.M{"username": 1},
+			Options: options.Index().SetName(usernameIndex).SetUnique(true).SetCollation(caseInsensitive),
+		},
+		{
+			Keys:    bson.M{"email": 1},
+			Options: options.Index().SetName(emailIndex).SetUnique(true).SetCollation(caseInsensitive),
+		},
+		{
+			// only users linked to an identity provider have a subject
+			Keys: bson.D{{Key: "oidcIssuer", Value: 1}, {Key: "oidcSubject", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"oidcSubject": bson.M{"$exists": true}}),
+		},
+	}); err != nil {
+		log.Printf("[userRepository::NewUserRepository::CreateMany] error => %+v", err)
+	} else {
+		// the unique indexes replace the plain ones
+		col.Indexes().DropOne(context.Background(), "username_1")
+		col.Indexes().DropOne(context.Background(), "email_1")
+	}
 	return &userRepository{
 		mc:  mc,
 		db:  db,
@@ -35,12 +66,17 @@ func NewUserRepository(mc *mongo.Client, db string) ports.UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, req *domains.CreateUserRequest) (*domains.User, error) {
-	return r.insertOne(ctx, domains.User{
+	user, err := r.insertOne(ctx, domains.User{
 		Username:     req.Username,
 		Password:     req.Password,
 		Email:        req.Email,
+		Role:         req.Role,
+		Verified:     req.Verified,
 		ProfileImage: "",
+		OIDCIssuer:   req.OIDCIssuer,
+		OIDCSubject:  req.OIDCSubject,
 	})
+	return user, duplicateKeyError(err)
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domains.User, error) {
@@ -48,20 +84,163 @@ func (r *userRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*d
 }
 
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domains.User, error) {
-	return r.findOne(ctx, bson.M{"username": username}, nil)
+	return r.findOne(ctx, bson.M{"username": username}, options.FindOne().SetCollation(caseInsensitive))
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domains.User, error) {
+	return r.findOne(ctx, bson.M{"email": email}, options.FindOne().SetCollation(caseInsensitive))
+}
+
+func (r *userRepository) Search(ctx context.Context, query string, req *domains.PaginationOptions) ([]domains.User, error) {
+	result := []domains.User{}
+	opts := options.Find().
+		SetCollation(caseInsensitive).
+		SetSort(bson.M{"username": 1}).
+		SetSkip(req.Offset).
+		SetLimit(req.Limit)
+	cursor, err := r.col.Find(ctx, searchFilter(query), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *userRepository) CountSearch(ctx context.Context, query string, req *domains.PaginationOptions) (int64, error) {
+	opts := options.Count().SetSkip(req.Offset).SetLimit(req.Limit)
+	return r.col.CountDocuments(ctx, searchFilter(query), opts)
+}
+
+func (r *userRepository) GetByOIDCSubject(ctx context.Context, issuer string, subject string) (*domains.User, error) {
+	return r.findOne(ctx, bson.M{"oidcIssuer": issuer, "oidcSubject": subject}, nil)
 }
 
 func (r *userRepository) Update(ctx context.Context, req *domains.UpdateUserRequest) (*domains.User, error) {
 	oid, _ := primitive.ObjectIDFromHex(req.UserId)
-	return r.updateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"profileImage": req.ProfileImage}})
+	return r.updateOne(ctx, bson.M{"_id": oid}, bson.M{
+		"$set":   bson.M{"profileImage": req.ProfileImage},
+		"$unset": bson.M{"profileImageKeys": ""},
+	})
+}
+
+// UpdateProfileImage sets an uploaded profile image, the link points at the
+// api which redirects to the blob of a size.
+func (r *userRepository) UpdateProfileImage(ctx context.Context, id primitive.ObjectID, link string, keys map[string]string) (*domains.User, error) {
+	return r.updateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"profileImage": link, "profileImageKeys": keys}})
+}
+
+func (r *userRepository) UpdateLastActiveAt(ctx context.Context, id primitive.ObjectID, t time.Time) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"lastActiveAt": t}})
+	return err
+}
+
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"tokenVersion": 1}})
+	return err
+}
+
+func (r *userRepository) UpdateRole(ctx context.Context, req *domains.UpdateUserRoleRequest) (*domains.User, error) {
+	oid, _ := primitive.ObjectIDFromHex(req.UserId)
+	user, err := r.updateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"role": req.Role}})
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id primitive.ObjectID, password string) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"password": password}})
+	return err
+}
+
+// UpdateEmail changes the email, a new email has to be verified again.
+func (r *userRepository) UpdateEmail(ctx context.Context, id primitive.ObjectID, email string) (*domains.User, error) {
+	user, err := r.updateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"email": email, "verified": false}})
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return user, duplicateKeyError(err)
+}
+
+func (r *userRepository) UpdateUsername(ctx context.Context, id primitive.ObjectID, username string) (*domains.User, error) {
+	user, err := r.updateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"username": username}})
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return user, duplicateKeyError(err)
+}
+
+func (r *userRepository) UpdateVerified(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"verified": true}})
+	return err
+}
+
+// UpdateTOTPSecret stores a pending secret, it never replaces the secret of an enabled MFA.
+func (r *userRepository) UpdateTOTPSecret(ctx context.Context, id primitive.ObjectID, secret string) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "mfaEnabled": bson.M{"$ne": true}}, bson.M{"$set": bson.M{"totpSecret": secret}})
+	return err
+}
+
+// EnableMFA starts the used TOTP periods over for the new secret.
+func (r *userRepository) EnableMFA(ctx context.Context, id primitive.ObjectID, recoveryCodes []string) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"mfaEnabled": true, "recoveryCodes": recoveryCodes, "totpLastCounter": int64(0)}})
+	return err
+}
+
+// UseTOTPCounter moves the last used TOTP period forward, it reports false
+// when the period was already used so a code can't be replayed. A user who
+// existed before the counter has no totpLastCounter, which $lt never matches.
+func (r *userRepository) UseTOTPCounter(ctx context.Context, id primitive.ObjectID, counter int64) (bool, error) {
+	filter := bson.M{
+		"_id": id,
+		"$or": bson.A{
+			bson.M{"totpLastCounter": bson.M{"$lt": counter}},
+			bson.M{"totpLastCounter": bson.M{"$exists": false}},
+		},
+	}
+	result, err := r.col.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"totpLastCounter": counter}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// UseRecoveryCode removes the hashed recovery code, it reports false when the code doesn't exist.
+func (r *userRepository) UseRecoveryCode(ctx context.Context, id primitive.ObjectID, hash string) (bool, error) {
+	result, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "recoveryCodes": hash}, bson.M{"$pull": bson.M{"recoveryCodes": hash}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *userRepository) LinkOIDC(ctx context.Context, id primitive.ObjectID, issuer string, subject string) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"oidcIssuer": issuer, "oidcSubject": subject}})
+	return err
+}
+
+func (r *userRepository) Deactivate(ctx context.Context, id primitive.ObjectID, t time.Time) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"deactivated": true, "deactivatedAt": t}})
+	return err
+}
+
+func (r *userRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.col.DeleteOne(ctx, bson.M{"_id": id})
+	return err
 }
 
 func (r *userRepository) insertOne(ctx context.Context, in domains.User) (*domains.User, error) {
 	in.CreatedAt = time.Now().UTC()
 	result, err := r.col.InsertOne(ctx, in)
+	if err != nil {
+		return nil, err
+	}
 	oid, _ := result.InsertedID.(primitive.ObjectID)
 	in.ID = oid
-	return &in, err
+	return &in, nil
 }
 
 func (r *userRepository) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*domains.User, error) {
@@ -84,3 +263,27 @@ func (r *userRepository) updateOne(ctx context.Context, filter bson.M, update bs
 	err := r.col.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
 	return &result, err
 }
+
+// searchFilter matches the usernames starting with the query, deactivated
+// users can't be found.
+func searchFilter(query string) bson.M {
+	filter := bson.M{"deactivated": bson.M{"$ne": true}}
+	if query != "" {
+		filter["username"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(query), Options: "i"}
+	}
+	return filter
+}
+
+// duplicateKeyError tells which unique index the write violated.
+func duplicateKeyError(err error) error {
+	if !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	switch {
+	case strings.Contains(err.Error(), usernameIndex):
+		return domains.ErrUsernameTaken
+	case strings.Contains(err.Error(), emailIndex):
+		return domains.ErrEmailTaken
+	}
+	return err
+}
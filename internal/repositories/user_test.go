@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"robinhood/internal/core/domains"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestUseTOTPCounter(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+	id := primitive.NewObjectID()
+
+	mt.Run("should match a user without a counter", func(mt *mtest.T) {
+		r := &userRepository{col: mt.Coll}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+
+		ok, err := r.UseTOTPCounter(context.TODO(), id, 37037037)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		var filter bson.M
+		assert.NoError(t, bson.Unmarshal(update.Lookup("q").Document(), &filter))
+		assert.Equal(t, bson.M{
+			"_id": id,
+			"$or": bson.A{
+				bson.M{"totpLastCounter": bson.M{"$lt": int64(37037037)}},
+				bson.M{"totpLastCounter": bson.M{"$exists": false}},
+			},
+		}, filter)
+	})
+
+	mt.Run("should refuse a used period", func(mt *mtest.T) {
+		r := &userRepository{col: mt.Coll}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+
+		ok, err := r.UseTOTPCounter(context.TODO(), id, 37037037)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestEnableMFA(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("should start the counter of a user without one", func(mt *mtest.T) {
+		r := &userRepository{col: mt.Coll}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+
+		assert.NoError(t, r.EnableMFA(context.TODO(), primitive.NewObjectID(), []string{"hash"}))
+
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		set := update.Lookup("u", "$set").Document()
+		assert.Equal(t, int64(0), set.Lookup("totpLastCounter").Int64())
+		assert.True(t, set.Lookup("mfaEnabled").Boolean())
+	})
+}
+
+func TestDuplicateKeyError(t *testing.T) {
+	// the message of a duplicate key on the index, as the server writes it
+	duplicate := func(index string) string {
+		return fmt.Sprintf(`E11000 duplicate key error collection: robinhood.user index: %s dup key: { : "alice" }`, index)
+	}
+	other := errors.New("connection reset")
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "should tell the username index of an insert",
+			err: mongo.WriteException{WriteErrors: []mongo.WriteError{
+				{Index: 0, Code: 11000, Message: duplicate(usernameIndex)},
+			}},
+			want: domains.ErrUsernameTaken,
+		},
+		{
+			name: "should tell the email index of an insert",
+			err: mongo.WriteException{WriteErrors: []mongo.WriteError{
+				{Index: 0, Code: 11000, Message: duplicate(emailIndex)},
+			}},
+			want: domains.ErrEmailTaken,
+		},
+		{
+			name: "should tell the username index of a find and update",
+			err:  mongo.CommandError{Code: 11000, Message: duplicate(usernameIndex)},
+			want: domains.ErrUsernameTaken,
+		},
+		{
+			name: "should tell the email index of a find and update",
+			err:  mongo.CommandError{Code: 11000, Message: duplicate(emailIndex)},
+			want: domains.ErrEmailTaken,
+		},
+		{
+			name: "should keep a duplicate key of another index",
+			err: mongo.WriteException{WriteErrors: []mongo.WriteError{
+				{Index: 0, Code: 11000, Message: duplicate("oidcIssuer_1_oidcSubject_1")},
+			}},
+		},
+		{
+			name: "should keep an error which isn't a duplicate key",
+			err:  other,
+			want: other,
+		},
+		{
+			name: "should keep nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := duplicateKeyError(tt.err)
+			if tt.want == nil && tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"robinhood/internal/core/domains"
+	"robinhood/internal/core/ports"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type userTokenRepository struct {
+	mc  *mongo.Client
+	db  string
+	cn  string
+	col *mongo.Collection
+}
+
+func NewUserTokenRepository(mc *mongo.Client, db string) ports.UserTokenRepository {
+	cn := "user_token"
+	col := mc.Database(db).Collection(cn)
+	// create index
+	col.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.M{"tokenHash": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "userId", Value: 1}, {Key: "purpose", Value: 1}},
+		},
+		{
+			// remove tokens once they are expired
+			Keys:    bson.M{"expiresAt": 1},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	return &userTokenRepository{
+		mc:  mc,
+		db:  db,
+		cn:  cn,
+		col: col,
+	}
+}
+
+func (r *userTokenRepository) Create(ctx context.Context, req *domains.CreateUserTokenRequest) (*domains.UserToken, error) {
+	in := domains.UserToken{
+		UserId:    req.UserId,
+		Purpose:   req.Purpose,
+		TokenHash: req.TokenHash,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: time.Now().UTC(),
+	}
+	result, err := r.col.InsertOne(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	in.ID, _ = result.InsertedID.(primitive.ObjectID)
+	return &in, nil
+}
+
+func (r *userTokenRepository) GetByTokenHash(ctx context.Context, purpose string, hash string) (*domains.UserToken, error) {
+	var result domains.UserToken
+	if err := r.col.FindOne(ctx, bson.M{"tokenHash": hash, "purpose": purpose}).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *userTokenRepository) GetLatestByUserID(ctx context.Context, userId primitive.ObjectID, purpose string) (*domains.UserToken, error) {
+	var result domains.UserToken
+	opts := options.FindOne().SetSort(bson.M{"createdAt": -1})
+	if err := r.col.FindOne(ctx, bson.M{"userId": userId, "purpose": purpose}, opts).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MarkUsed flags the token as used, it reports false when the token was
+// already used so a token can't be redeemed twice.
+func (r *userTokenRepository) MarkUsed(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	result, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "usedAt": nil}, bson.M{"$set": bson.M{"usedAt": time.Now().UTC()}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *userTokenRepository) DeleteByUserID(ctx context.Context, userId primitive.ObjectID, purpose string) error {
+	_, err := r.col.DeleteMany(ctx, bson.M{"userId": userId, "purpose": purpose})
+	return err
+}
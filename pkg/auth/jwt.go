@@ -2,34 +2,107 @@ package auth
 
 import (
 	"robinhood/config"
+	"robinhood/pkg/utils"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type JWTCustomClaims struct {
-	UserId string `json:"userId"`
+	UserId       string `json:"userId"`
+	Role         string `json:"role"`
+	Verified     bool   `json:"verified"`
+	SessionId    string `json:"sid"`
+	TokenVersion int    `json:"ver"`
+	// Scopes limits the permissions of an api key, tokens of a login have none
+	Scopes []string `json:"scopes,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(UserId string) (string, error) {
-	claims := JWTCustomClaims{
-		UserId,
-		jwt.RegisteredClaims{},
+// HasScope reports whether the scopes allow the permission, claims without
+// scopes are limited by the role only.
+func (c *JWTCustomClaims) HasScope(permission string) bool {
+	if c.Scopes == nil {
+		return true
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(config.Get().JWT.Secret))
+	for _, s := range c.Scopes {
+		if s == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateToken signs the custom claims, the registered claims are always
+// filled from the JWT config.
+func GenerateToken(claims JWTCustomClaims) (string, error) {
+	jti, err := utils.GenerateRandomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        jti,
+		Issuer:    config.Get().JWT.ISS,
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(config.Get().JWT.ExpiresHours) * time.Hour)),
+		NotBefore: jwt.NewNumericDate(now),
+		IssuedAt:  jwt.NewNumericDate(now),
+	}
+	if aud := config.Get().JWT.AUD; aud != "" {
+		claims.Audience = jwt.ClaimStrings{aud}
+	}
+
+	set, err := getKeySet()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(set.method, claims)
+	if set.signing.id != "" {
+		token.Header["kid"] = set.signing.id
+	}
+	return token.SignedString(set.signing.privateKey)
 }
 
 func ParseToken(tokenString string) (*JWTCustomClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.Get().JWT.Secret), nil
-	})
+	set, err := getKeySet()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{set.method.Alg()}),
+		jwt.WithIssuedAt(),
+	}
+	if aud := config.Get().JWT.AUD; aud != "" {
+		opts = append(opts, jwt.WithAudience(aud))
+	}
+	if iss := config.Get().JWT.ISS; iss != "" {
+		opts = append(opts, jwt.WithIssuer(iss))
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &JWTCustomClaims{}, set.keyFunc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	claims, ok := token.Claims.(*JWTCustomClaims)
 	if !ok {
-		return nil, err
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	// tokens issued before expiry was enforced carry no exp/jti and must not be accepted
+	if claims.ExpiresAt == nil || claims.ID == "" {
+		return nil, jwt.ErrTokenRequiredClaimMissing
 	}
 	return claims, nil
 }
+
+// AutoLogoffDuration returns the idle window after which a session is logged off,
+// zero means the auto logoff is disabled.
+func AutoLogoffDuration() time.Duration {
+	return time.Duration(config.Get().JWT.AutoLogoffHours) * time.Hour
+}
+
+// RefreshTokenDuration returns how long a refresh token stays valid.
+func RefreshTokenDuration() time.Duration {
+	return time.Duration(config.Get().JWT.RefreshHours) * time.Hour
+}
@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"robinhood/config"
+	"sort"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingKey is a key identified by kid, a retired key only has a public key
+// so it can still verify the tokens it signed but never sign new ones.
+type signingKey struct {
+	id         string
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+}
+
+type keySet struct {
+	method  jwt.SigningMethod
+	signing *signingKey
+	keys    map[string]*signingKey
+}
+
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+var (
+	ks     *keySet
+	ksErr  error
+	ksOnce sync.Once
+	b64    = base64.RawURLEncoding
+)
+
+// LoadKeys loads the signing keys from the JWT config, it is safe to call
+// more than once and returns the same result every time.
+func LoadKeys() error {
+	_, err := getKeySet()
+	return err
+}
+
+// JWKS returns the public keys which can verify our tokens, a shared HMAC
+// secret is never published.
+func JWKS() ([]JSONWebKey, error) {
+	set, err := getKeySet()
+	if err != nil {
+		return nil, err
+	}
+
+	kids := make([]string, 0, len(set.keys))
+	for kid := range set.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	result := []JSONWebKey{}
+	for _, kid := range kids {
+		k := set.keys[kid]
+		switch pub := k.publicKey.(type) {
+		case *rsa.PublicKey:
+			result = append(result, JSONWebKey{
+				Kty: "RSA",
+				Kid: k.id,
+				Use: "sig",
+				Alg: set.method.Alg(),
+				N:   b64.EncodeToString(pub.N.Bytes()),
+				E:   b64.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			result = append(result, JSONWebKey{
+				Kty: "OKP",
+				Kid: k.id,
+				Use: "sig",
+				Alg: set.method.Alg(),
+				Crv: "Ed25519",
+				X:   b64.EncodeToString(pub),
+			})
+		}
+	}
+	return result, nil
+}
+
+func getKeySet() (*keySet, error) {
+	ksOnce.Do(func() {
+		ks, ksErr = newKeySet()
+	})
+	return ks, ksErr
+}
+
+func newKeySet() (*keySet, error) {
+	cfg := config.Get().JWT
+	method := jwt.GetSigningMethod(cfg.Algorithm)
+	switch method {
+	case jwt.SigningMethodHS256:
+		// symmetric tokens are signed and verified with the shared secret only
+		return &keySet{
+			method: method,
+			signing: &signingKey{
+				privateKey: []byte(cfg.Secret),
+				publicKey:  []byte(cfg.Secret),
+			},
+			keys: map[string]*signingKey{},
+		}, nil
+	case jwt.SigningMethodRS256, jwt.SigningMethodEdDSA:
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm %q", cfg.Algorithm)
+	}
+
+	set := &keySet{
+		method: method,
+		keys:   map[string]*signingKey{},
+	}
+	for kid, path := range cfg.PrivateKeyFiles {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read private key %q: %w", kid, err)
+		}
+		key, err := parsePrivateKey(method, pem)
+		if err != nil {
+			return nil, fmt.Errorf("parse private key %q: %w", kid, err)
+		}
+		key.id = kid
+		set.keys[kid] = key
+	}
+	for kid, path := range cfg.PublicKeyFiles {
+		if _, ok := set.keys[kid]; ok {
+			return nil, fmt.Errorf("duplicated key id %q", kid)
+		}
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read public key %q: %w", kid, err)
+		}
+		key, err := parsePublicKey(method, pem)
+		if err != nil {
+			return nil, fmt.Errorf("parse public key %q: %w", kid, err)
+		}
+		key.id = kid
+		set.keys[kid] = key
+	}
+
+	signing, ok := set.keys[cfg.SigningKeyId]
+	if !ok || signing.privateKey == nil {
+		return nil, fmt.Errorf("private key of signing key id %q is not configured", cfg.SigningKeyId)
+	}
+	set.signing = signing
+
+	return set, nil
+}
+
+func parsePrivateKey(method jwt.SigningMethod, pem []byte) (*signingKey, error) {
+	if method == jwt.SigningMethodRS256 {
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		return &signingKey{privateKey: key, publicKey: &key.PublicKey}, nil
+	}
+
+	key, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{privateKey: key, publicKey: key.(ed25519.PrivateKey).Public()}, nil
+}
+
+func parsePublicKey(method jwt.SigningMethod, pem []byte) (*signingKey, error) {
+	var (
+		key crypto.PublicKey
+		err error
+	)
+	if method == jwt.SigningMethodRS256 {
+		key, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+	} else {
+		key, err = jwt.ParseEdPublicKeyFromPEM(pem)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{publicKey: key}, nil
+}
+
+// keyFunc selects the verification key by the kid header of the token.
+func (s *keySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	if s.method == jwt.SigningMethodHS256 {
+		return s.signing.publicKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key.publicKey, nil
+}
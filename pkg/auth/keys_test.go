@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"robinhood/config"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// writePEM writes the block to a file in dir and returns its path.
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name+".pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func publicDER(t *testing.T, pub interface{}) []byte {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+// loadKeySet reads the keys from the JWT config set by env and makes them the
+// keys of GenerateToken, ParseToken and JWKS.
+func loadKeySet(t *testing.T, env map[string]string) (*keySet, error) {
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+	config.New()
+	set, err := newKeySet()
+	ksOnce.Do(func() {})
+	ks, ksErr = set, err
+	t.Cleanup(func() { ks, ksErr = nil, nil })
+	return set, err
+}
+
+func signWith(t *testing.T, kid string, key interface{}, method jwt.SigningMethod) string {
+	now := time.Now()
+	token := jwt.NewWithClaims(method, JWTCustomClaims{
+		UserId: "user_id",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti",
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestRS256KeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	active, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retired, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := loadKeySet(t, map[string]string{
+		"JWT_ALGORITHM":         "RS256",
+		"JWT_SIGNING_KEY_ID":    "key-2",
+		"JWT_PRIVATE_KEY_FILES": "key-2:" + writePEM(t, dir, "key-2", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(active)),
+		"JWT_PUBLIC_KEY_FILES":  "key-1:" + writePEM(t, dir, "key-1", "PUBLIC KEY", publicDER(t, &retired.PublicKey)),
+		"JWT_ISS":               "",
+		"JWT_AUD":               "",
+	})
+	assert.NoError(t, err)
+
+	t.Run("should sign with the active kid", func(t *testing.T) {
+		signed, err := GenerateToken(JWTCustomClaims{UserId: "user_id"})
+		assert.NoError(t, err)
+		token, _, err := jwt.NewParser().ParseUnverified(signed, &JWTCustomClaims{})
+		assert.NoError(t, err)
+		assert.Equal(t, "key-2", token.Header["kid"])
+		assert.Equal(t, "RS256", token.Header["alg"])
+
+		claims, err := ParseToken(signed)
+		assert.NoError(t, err)
+		assert.Equal(t, "user_id", claims.UserId)
+	})
+
+	t.Run("should verify a token of the retired key", func(t *testing.T) {
+		claims, err := ParseToken(signWith(t, "key-1", retired, jwt.SigningMethodRS256))
+		assert.NoError(t, err)
+		assert.Equal(t, "user_id", claims.UserId)
+	})
+
+	t.Run("should reject an unknown kid", func(t *testing.T) {
+		_, err := ParseToken(signWith(t, "key-3", active, jwt.SigningMethodRS256))
+		assert.ErrorContains(t, err, `unknown key id "key-3"`)
+	})
+
+	t.Run("should reject a token signed by another key under a known kid", func(t *testing.T) {
+		_, err := ParseToken(signWith(t, "key-1", active, jwt.SigningMethodRS256))
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("should publish both public keys", func(t *testing.T) {
+		keys, err := JWKS()
+		assert.NoError(t, err)
+		raw, err := json.Marshal(keys)
+		assert.NoError(t, err)
+
+		var published []map[string]string
+		assert.NoError(t, json.Unmarshal(raw, &published))
+		assert.Len(t, published, 2)
+		for i, key := range []*rsa.PublicKey{&retired.PublicKey, &active.PublicKey} {
+			assert.Equal(t, "RSA", published[i]["kty"])
+			assert.Equal(t, "sig", published[i]["use"])
+			assert.Equal(t, "RS256", published[i]["alg"])
+			n, err := b64.DecodeString(published[i]["n"])
+			assert.NoError(t, err)
+			assert.Equal(t, key.N, new(big.Int).SetBytes(n))
+			assert.Equal(t, "AQAB", published[i]["e"])
+			assert.NotContains(t, published[i], "x")
+		}
+		assert.Equal(t, "key-1", published[0]["kid"])
+		assert.Equal(t, "key-2", published[1]["kid"])
+	})
+
+	assert.NotNil(t, set.keys["key-1"].publicKey)
+	assert.Nil(t, set.keys["key-1"].privateKey)
+}
+
+func TestRetiredKeyCannotSign(t *testing.T) {
+	dir := t.TempDir()
+	retired, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = loadKeySet(t, map[string]string{
+		"JWT_ALGORITHM":         "RS256",
+		"JWT_SIGNING_KEY_ID":    "key-1",
+		"JWT_PRIVATE_KEY_FILES": "",
+		"JWT_PUBLIC_KEY_FILES":  "key-1:" + writePEM(t, dir, "key-1", "PUBLIC KEY", publicDER(t, &retired.PublicKey)),
+	})
+	assert.ErrorContains(t, err, `private key of signing key id "key-1" is not configured`)
+}
+
+func TestEdDSAKeys(t *testing.T) {
+	dir := t.TempDir()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = loadKeySet(t, map[string]string{
+		"JWT_ALGORITHM":         "EdDSA",
+		"JWT_SIGNING_KEY_ID":    "ed-1",
+		"JWT_PRIVATE_KEY_FILES": "ed-1:" + writePEM(t, dir, "ed-1", "PRIVATE KEY", der),
+		"JWT_PUBLIC_KEY_FILES":  "",
+		"JWT_ISS":               "",
+		"JWT_AUD":               "",
+	})
+	assert.NoError(t, err)
+
+	signed, err := GenerateToken(JWTCustomClaims{UserId: "user_id"})
+	assert.NoError(t, err)
+	claims, err := ParseToken(signed)
+	assert.NoError(t, err)
+	assert.Equal(t, "user_id", claims.UserId)
+
+	keys, err := JWKS()
+	assert.NoError(t, err)
+	raw, err := json.Marshal(keys)
+	assert.NoError(t, err)
+	var published []map[string]string
+	assert.NoError(t, json.Unmarshal(raw, &published))
+	assert.Equal(t, []map[string]string{{
+		"kty": "OKP",
+		"kid": "ed-1",
+		"use": "sig",
+		"alg": "EdDSA",
+		"crv": "Ed25519",
+		"x":   b64.EncodeToString(pub),
+	}}, published)
+}
+
+func TestHS256PublishesNoKeys(t *testing.T) {
+	_, err := loadKeySet(t, map[string]string{
+		"JWT_ALGORITHM":         "HS256",
+		"JWT_SECRET":            "secret",
+		"JWT_SIGNING_KEY_ID":    "",
+		"JWT_PRIVATE_KEY_FILES": "",
+		"JWT_PUBLIC_KEY_FILES":  "",
+	})
+	assert.NoError(t, err)
+
+	keys, err := JWKS()
+	assert.NoError(t, err)
+	raw, err := json.Marshal(keys)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(raw))
+}
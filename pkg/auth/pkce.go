@@ -0,0 +1,9 @@
+package auth
+
+import "crypto/sha256"
+
+// CodeChallengeS256 derives the PKCE code challenge of a code verifier, see RFC 7636 4.2.
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return b64.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"robinhood/pkg/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the ascii secret "12345678901234567890" of RFC 6238
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPCode(t *testing.T) {
+	// SHA1 vectors of RFC 6238 Appendix B, the 6 digit code is the last 6
+	// digits of the 8 digit one
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(time.Unix(tt.unix, 0).UTC().String(), func(t *testing.T) {
+			code, err := auth.GenerateTOTPCode(rfcSecret, time.Unix(tt.unix, 0))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	// the code of the period [1111111110, 1111111140), its counter is 37037037
+	const counter = int64(37037037)
+	periodStart := time.Unix(counter*30, 0)
+	code, err := auth.GenerateTOTPCode(rfcSecret, periodStart)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		at    time.Time
+		valid bool
+	}{
+		{"should accept in the same period", periodStart.Add(15 * time.Second), true},
+		{"should accept at the start of the previous period", periodStart.Add(-30 * time.Second), true},
+		{"should reject just before the previous period", periodStart.Add(-31 * time.Second), false},
+		{"should accept at the end of the next period", periodStart.Add(59 * time.Second), true},
+		{"should reject at the start of the period after the next", periodStart.Add(60 * time.Second), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, ok := auth.ValidateTOTP(rfcSecret, code, tt.at)
+			assert.Equal(t, tt.valid, ok)
+			if tt.valid {
+				// the counter of the code, not of the time it was checked at, so
+				// the same code is refused again in the next period
+				assert.Equal(t, counter, matched)
+			} else {
+				assert.Zero(t, matched)
+			}
+		})
+	}
+
+	t.Run("should reject a code of the wrong length", func(t *testing.T) {
+		_, ok := auth.ValidateTOTP(rfcSecret, "0"+code, periodStart)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject an invalid secret", func(t *testing.T) {
+		_, ok := auth.ValidateTOTP("not base32!", code, periodStart)
+		assert.False(t, ok)
+	})
+}
@@ -18,6 +18,9 @@ var (
 	MetaErrorBadRequest = &MetaError{
 		HttpStatus: http.StatusBadRequest,
 	}
+	MetaErrorTooManyRequests = &MetaError{
+		HttpStatus: http.StatusTooManyRequests,
+	}
 	Error = &MetaError{}
 )
 
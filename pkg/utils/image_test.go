@@ -0,0 +1,88 @@
+package utils_test
+
+import (
+	"image"
+	"image/color"
+	"robinhood/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	red   = color.RGBA{R: 255, A: 255}
+	green = color.RGBA{G: 255, A: 255}
+	blue  = color.RGBA{B: 255, A: 255}
+)
+
+// striped fills the first and the last quarter along the longer side of a
+// w x h image with red and blue and the rest with green, so the centered
+// square of a 2:1 image is all green.
+func striped(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(10, 20, 10+w, 20+h))
+	long := w
+	if h > w {
+		long = h
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := x
+			if h > w {
+				i = y
+			}
+			c := green
+			switch {
+			case i < long/4:
+				c = red
+			case i >= long-long/4:
+				c = blue
+			}
+			img.Set(10+x, 20+y, c)
+		}
+	}
+	return img
+}
+
+func TestThumbnail(t *testing.T) {
+	type test struct {
+		name string
+		src  image.Image
+		size int
+	}
+
+	tests := []test{
+		{name: "should crop the center of a wide image", src: striped(400, 200), size: 64},
+		{name: "should crop the center of a tall image", src: striped(200, 400), size: 64},
+		{name: "should scale up a small image", src: striped(20, 10), size: 256},
+		{name: "should scale the square crop down to the size", src: striped(64, 32), size: 32},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			thumb := utils.Thumbnail(tc.src, tc.size)
+
+			// always size x size from the origin, the aspect ratio of the
+			// source is kept by cropping and not by stretching
+			assert.Equal(t, image.Rect(0, 0, tc.size, tc.size), thumb.Bounds())
+			for y := 0; y < tc.size; y++ {
+				for x := 0; x < tc.size; x++ {
+					if !assert.Equal(t, green, thumb.RGBAAt(x, y), "pixel %d,%d", x, y) {
+						return
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestThumbnailAverage(t *testing.T) {
+	// a 2x2 checkerboard of black and white becomes one gray pixel
+	src := image.NewGray(image.Rect(0, 0, 2, 2))
+	src.SetGray(0, 0, color.Gray{Y: 255})
+	src.SetGray(1, 1, color.Gray{Y: 255})
+
+	thumb := utils.Thumbnail(src, 1)
+
+	assert.Equal(t, image.Rect(0, 0, 1, 1), thumb.Bounds())
+	assert.Equal(t, color.RGBA{R: 127, G: 127, B: 127, A: 255}, thumb.RGBAAt(0, 0))
+}